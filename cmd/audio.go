@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/audio"
+	"github.com/spf13/cobra"
+)
+
+var audioCmd = &cobra.Command{
+	Use:   "audio",
+	Short: "Link monitor inputs to local audio outputs",
+	Long: `Link monitor inputs to audio outputs of this machine. After 'switch' changes
+a monitor to a linked input, the linked output becomes the default, e.g. back to
+the laptop speakers when the monitor (and its speakers) go to another machine.
+
+Outputs are PulseAudio/PipeWire sink names on Linux (pactl), device names on
+macOS (SwitchAudioSource) and playback device names on Windows
+(AudioDeviceCmdlets PowerShell module).`,
+}
+
+var audioLinkCmd = &cobra.Command{
+	Use:     "link [monitor] [input] [output]",
+	Short:   "Select an audio output whenever a monitor switches to an input",
+	Example: `  monitorswitch audio link 1 HDMI-1 alsa_output.pci-0000_00_1f.3.analog-stereo`,
+	Args:    cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l, err := audio.Load()
+		if err != nil {
+			return err
+		}
+		l.Set(args[0], args[1], args[2])
+		if err := l.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Monitor %s: switching to %s selects audio output %q\n", args[0], args[1], args[2])
+		return nil
+	},
+}
+
+var audioUnlinkCmd = &cobra.Command{
+	Use:   "unlink [monitor] [input]",
+	Short: "Remove an audio link",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l, err := audio.Load()
+		if err != nil {
+			return err
+		}
+		if !l.Remove(args[0], args[1]) {
+			return fmt.Errorf("monitor %s has no audio link for %s", args[0], args[1])
+		}
+		return l.Save()
+	},
+}
+
+var audioListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List audio links and available outputs",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l, err := audio.Load()
+		if err != nil {
+			return err
+		}
+		if len(l) == 0 {
+			fmt.Println("No audio links defined")
+		}
+		for monitorID, inputs := range l {
+			fmt.Printf("Monitor %s:\n", monitorID)
+			for input, device := range inputs {
+				fmt.Printf("  %s → %s\n", input, device)
+			}
+		}
+
+		outputs, err := audio.Outputs()
+		if err != nil {
+			if verbose {
+				fmt.Printf("[VERBOSE] Could not list audio outputs: %v\n", err)
+			}
+			return nil
+		}
+		fmt.Println("\nAvailable outputs:")
+		for _, o := range outputs {
+			fmt.Printf("  %s\n", o)
+		}
+		return nil
+	},
+}
+
+func init() {
+	audioCmd.AddCommand(audioLinkCmd, audioUnlinkCmd, audioListCmd)
+	rootCmd.AddCommand(audioCmd)
+}
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var batchKeepGoing bool
+
+// inBatch is set while batch runs its commands, which share the config,
+// logging and session set up for batch itself
+var inBatch bool
+
+// batchSessionFlags only take effect when the config, logging and session are
+// set up, which batch does once for all its commands. A command line can't
+// change them: it would be ignored, or for the safety flags, escape the
+// read-only mode and guards batch was started with.
+var batchSessionFlags = []string{
+	"config", "read-only", "force", "no-cache", "no-coexist", "inject-faults",
+	"log-level", "log-file", "log-format", "host", "remote-bin",
+}
+
+// batchPersistent holds the global flags batch was run with
+var batchPersistent map[string]flagValue
+
+var batchCmd = &cobra.Command{
+	Use:   "batch [file|-]",
+	Short: "Run several commands with one detection pass",
+	Long: `Run newline-separated commands (or a JSON array of commands) from a file or
+stdin. Monitors are detected once and the same backend session is reused for
+every command, so scripts that configure many monitors stay fast.
+
+Blank lines and lines starting with # are ignored. JSON input may be an array
+of strings ("power off --monitor 2") or an array of argument arrays.
+
+Global flags that set up the session (--config, --read-only, --force, the log
+flags, ...) go on batch itself; a command that sets them differently fails.`,
+	Example: `  printf 'power on\nmode DP-1 2560x1440@120\n' | monitorswitch batch -
+  echo '[["power","standby","--monitor","2"]]' | monitorswitch batch -`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var in io.Reader = os.Stdin
+		if args[0] != "-" {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			in = file
+		}
+
+		data, err := io.ReadAll(in)
+		if err != nil {
+			return fmt.Errorf("failed to read batch input: %w", err)
+		}
+
+		commands, err := parseBatch(data)
+		if err != nil {
+			return err
+		}
+
+		if _, err := getSession(); err != nil {
+			return err
+		}
+
+		// Every command starts from the global flags batch was run with
+		batchPersistent = saveFlags(rootCmd.PersistentFlags())
+		inBatch = true
+		defer func() { inBatch = false }()
+
+		failed := 0
+		for i, cmdArgs := range commands {
+			if len(cmdArgs) > 0 && cmdArgs[0] == "batch" {
+				return fmt.Errorf("command %d: batch cannot be nested", i+1)
+			}
+			if verbose {
+				fmt.Printf("[VERBOSE] [%d/%d] %s\n", i+1, len(commands), strings.Join(cmdArgs, " "))
+			}
+
+			if err := runBatchCommand(cmdArgs, batchPersistent); err != nil {
+				fmt.Fprintf(os.Stderr, "x command %d (%s): %v\n", i+1, strings.Join(cmdArgs, " "), err)
+				failed++
+				if !batchKeepGoing {
+					return fmt.Errorf("batch stopped at command %d", i+1)
+				}
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d commands failed", failed, len(commands))
+		}
+		return nil
+	},
+}
+
+// runBatchCommand executes one command line through the root command, after
+// resetting the command's own flags and restoring the global ones so values
+// don't leak from the previous command
+func runBatchCommand(args []string, persistent map[string]flagValue) error {
+	if target, _, err := rootCmd.Find(args); err == nil {
+		for c := target; c != nil && c != rootCmd; c = c.Parent() {
+			resetFlags(c.LocalFlags())
+		}
+	}
+	restoreFlags(rootCmd.PersistentFlags(), persistent)
+
+	rootCmd.SetArgs(args)
+	return rootCmd.Execute()
+}
+
+// checkBatchFlags refuses a batch command line that sets one of the
+// batchSessionFlags to something else than batch itself was given
+func checkBatchFlags(flags *pflag.FlagSet) error {
+	for _, name := range batchSessionFlags {
+		f := flags.Lookup(name)
+		if f == nil || !f.Changed || f.Value.String() == batchPersistent[name].value {
+			continue
+		}
+		return fmt.Errorf("--%s applies to the whole batch; pass it to batch instead of a command", name)
+	}
+	return nil
+}
+
+func resetFlags(flags *pflag.FlagSet) {
+	flags.VisitAll(func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			sv.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+// flagValue is a flag's value as saved by saveFlags
+type flagValue struct {
+	value   string
+	slice   []string
+	changed bool
+}
+
+func saveFlags(flags *pflag.FlagSet) map[string]flagValue {
+	saved := make(map[string]flagValue)
+	flags.VisitAll(func(f *pflag.Flag) {
+		v := flagValue{value: f.Value.String(), changed: f.Changed}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			v.slice = sv.GetSlice()
+		}
+		saved[f.Name] = v
+	})
+	return saved
+}
+
+func restoreFlags(flags *pflag.FlagSet, saved map[string]flagValue) {
+	flags.VisitAll(func(f *pflag.Flag) {
+		v, ok := saved[f.Name]
+		if !ok {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			sv.Replace(v.slice)
+		} else {
+			f.Value.Set(v.value)
+		}
+		f.Changed = v.changed
+	})
+}
+
+// parseBatch accepts either a JSON array or newline-separated command lines
+func parseBatch(data []byte) ([][]string, error) {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		return parseBatchJSON(trimmed)
+	}
+
+	var commands [][]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		args, err := splitCommandLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		commands = append(commands, args)
+	}
+
+	return commands, scanner.Err()
+}
+
+func parseBatchJSON(data []byte) ([][]string, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("invalid JSON batch: %w", err)
+	}
+
+	var commands [][]string
+	for i, item := range items {
+		var line string
+		if err := json.Unmarshal(item, &line); err == nil {
+			args, err := splitCommandLine(line)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i+1, err)
+			}
+			commands = append(commands, args)
+			continue
+		}
+
+		var args []string
+		if err := json.Unmarshal(item, &args); err != nil {
+			return nil, fmt.Errorf("item %d: expected a string or an array of strings", i+1)
+		}
+		commands = append(commands, args)
+	}
+	return commands, nil
+}
+
+// splitCommandLine splits a line into arguments, honouring single and double quotes
+func splitCommandLine(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+func init() {
+	batchCmd.Flags().BoolVar(&batchKeepGoing, "keep-going", false, "continue after a failing command")
+	rootCmd.AddCommand(batchCmd)
+}
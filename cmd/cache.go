@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/pkg/monitorswitch"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the monitor capabilities cache",
+	Long: `Capabilities are read from each monitor once and reused for a week, keyed by
+the serial number in its EDID, so detection doesn't wait on every monitor each
+run. Clear the cache after a firmware update, or pass --no-cache to bypass it.`,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Forget cached capabilities",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := monitorswitch.ClearCapabilitiesCache(); err != nil {
+			return fmt.Errorf("failed to clear the capabilities cache: %w", err)
+		}
+		dir, _ := ddc.CapabilitiesCacheDir()
+		fmt.Printf("✓ Cleared the capabilities cache in %s\n", dir)
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
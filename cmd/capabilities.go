@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/mccs"
+	"github.com/spf13/cobra"
+)
+
+var (
+	capsMonitor string
+	capsSave    string
+)
+
+// capabilityReport is the saved/printed form of a monitor's capabilities
+type capabilityReport struct {
+	MonitorID    string            `json:"monitor_id"`
+	Name         string            `json:"name"`
+	Capabilities *ddc.Capabilities `json:"capabilities"`
+}
+
+var capabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "Show a monitor's DDC/CI capabilities",
+	Long:  "Print the capability report of a monitor as JSON, optionally saving it for a later 'capabilities diff'.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := currentCapabilityReport(capsMonitor)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if capsSave != "" {
+			if err := os.WriteFile(capsSave, data, 0o644); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Saved capabilities of monitor %s to %s\n", report.MonitorID, capsSave)
+			return nil
+		}
+
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+var capabilitiesDiffCmd = &cobra.Command{
+	Use:   "diff [saved.json]",
+	Short: "Compare current capabilities against a saved snapshot",
+	Long: `Compare a monitor's current capabilities against a snapshot saved with
+'capabilities --save'. Features that disappeared (after a firmware update, or a
+dock filtering DDC) are marked with "-" and make the command exit non-zero.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		var saved capabilityReport
+		if err := json.Unmarshal(data, &saved); err != nil {
+			return fmt.Errorf("invalid capabilities snapshot %s: %w", args[0], err)
+		}
+
+		monitorID := capsMonitor
+		if monitorID == "" {
+			monitorID = saved.MonitorID
+		}
+		current, err := currentCapabilityReport(monitorID)
+		if err != nil {
+			return err
+		}
+
+		removed, added := diffCapabilities(saved.Capabilities, current.Capabilities)
+		for _, f := range removed {
+			fmt.Printf("- %s\n", f)
+		}
+		for _, f := range added {
+			fmt.Printf("+ %s\n", f)
+		}
+
+		if len(removed) > 0 {
+			return fmt.Errorf("%d features disappeared since the snapshot", len(removed))
+		}
+		if len(added) == 0 {
+			fmt.Println("No capability changes")
+		}
+		return nil
+	},
+}
+
+func currentCapabilityReport(monitorID string) (*capabilityReport, error) {
+	s, err := getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	monitors, err := s.Monitors()
+	if err != nil {
+		return nil, fmt.Errorf("monitor detection failed: %w", err)
+	}
+	targets, err := selectMonitors(monitors, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no DDC/CI compatible monitors detected")
+	}
+	if len(targets) > 1 {
+		return nil, fmt.Errorf("%d monitors detected; choose one with --monitor", len(targets))
+	}
+
+	// Reports are saved and diffed to spot firmware changes, which the
+	// capabilities cache would hide
+	caps, err := ddc.FreshCapabilities(s.client, targets[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	return &capabilityReport{MonitorID: targets[0].ID, Name: targets[0].Name, Capabilities: caps}, nil
+}
+
+// diffCapabilities lists features present only in before (removed) or only in after (added)
+func diffCapabilities(before, after *ddc.Capabilities) (removed, added []string) {
+	if before == nil {
+		before = &ddc.Capabilities{}
+	}
+	if after == nil {
+		after = &ddc.Capabilities{}
+	}
+
+	flags := []struct {
+		name          string
+		before, after bool
+	}{
+		{"brightness", before.SupportedBrightness, after.SupportedBrightness},
+		{"contrast", before.SupportedContrast, after.SupportedContrast},
+		{"volume", before.SupportedVolume, after.SupportedVolume},
+		{"power", before.SupportedPower, after.SupportedPower},
+	}
+	for _, f := range flags {
+		if f.before && !f.after {
+			removed = append(removed, f.name)
+		}
+		if !f.before && f.after {
+			added = append(added, f.name)
+		}
+	}
+
+	for input, code := range before.SupportedInputs {
+		if _, ok := after.SupportedInputs[input]; !ok {
+			removed = append(removed, fmt.Sprintf("input %s (0x%02X)", input, code))
+		}
+	}
+	for input, code := range after.SupportedInputs {
+		if _, ok := before.SupportedInputs[input]; !ok {
+			added = append(added, fmt.Sprintf("input %s (0x%02X)", input, code))
+		}
+	}
+
+	beforeFeatures := make(map[byte]bool)
+	for _, code := range before.Features {
+		beforeFeatures[code] = true
+	}
+	afterFeatures := make(map[byte]bool)
+	for _, code := range after.Features {
+		afterFeatures[code] = true
+		if !beforeFeatures[code] {
+			added = append(added, fmt.Sprintf("feature 0x%02X (%s)", code, mccs.Name(code)))
+		}
+	}
+	for code := range beforeFeatures {
+		if !afterFeatures[code] {
+			removed = append(removed, fmt.Sprintf("feature 0x%02X (%s)", code, mccs.Name(code)))
+		}
+	}
+
+	sort.Strings(removed)
+	sort.Strings(added)
+	return removed, added
+}
+
+func init() {
+	capabilitiesCmd.PersistentFlags().StringVarP(&capsMonitor, "monitor", "m", "", "monitor ID (required with several monitors)")
+	capabilitiesCmd.Flags().StringVar(&capsSave, "save", "", "save the report to this file instead of printing it")
+	capabilitiesCmd.AddCommand(capabilitiesDiffCmd)
+	rootCmd.AddCommand(capabilitiesCmd)
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sibteali786/monitorswitch/internal/compat"
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/spf13/cobra"
+)
+
+var compatURL string
+
+// compatReport is what's known about one model
+type compatReport struct {
+	Model   string         `json:"model"`
+	Entries []compat.Entry `json:"entries"`
+	Quirks  []ddc.Quirk    `json:"local_quirks,omitempty"` // Matching entries from quirks.json
+}
+
+var compatCmd = &cobra.Command{
+	Use:   "compat [model|current]",
+	Short: "Look up what's known to work on a monitor model",
+	Long: `Check the compatibility database for a monitor model: inputs known to work,
+quirks it needs and features known to be broken. Use "current" to look up every
+connected monitor. --url adds a database in the same JSON format fetched over HTTP.`,
+	Example: `  monitorswitch compat "DELL U2720Q"
+  monitorswitch compat current`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := compat.Embedded()
+		if err != nil {
+			return err
+		}
+		if compatURL != "" {
+			online, err := compat.Fetch(context.Background(), compatURL)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, online...)
+		}
+
+		models := []string{args[0]}
+		if args[0] == "current" {
+			s, err := getSession()
+			if err != nil {
+				return err
+			}
+			monitors, err := s.Monitors()
+			if err != nil {
+				return err
+			}
+			models = nil
+			for _, m := range monitors {
+				models = append(models, m.Name)
+			}
+		}
+
+		quirks, _ := ddc.LoadQuirks()
+		var reports []compatReport
+		for _, model := range models {
+			r := compatReport{Model: model, Entries: compat.Lookup(entries, model)}
+			if r.Entries == nil {
+				r.Entries = []compat.Entry{}
+			}
+			for _, q := range quirks {
+				if strings.Contains(strings.ToLower(model), strings.ToLower(q.Model)) {
+					r.Quirks = append(r.Quirks, q)
+				}
+			}
+			reports = append(reports, r)
+		}
+
+		return render(reports, func() {
+			for _, r := range reports {
+				printCompatReport(r)
+			}
+		})
+	},
+}
+
+func printCompatReport(r compatReport) {
+	fmt.Printf("%s:\n", r.Model)
+	if len(r.Entries) == 0 && len(r.Quirks) == 0 {
+		fmt.Println("  Nothing known about this model")
+		return
+	}
+
+	for _, e := range r.Entries {
+		fmt.Printf("  Matches %q (%s)\n", e.Model, e.Source)
+		if len(e.Inputs) > 0 {
+			names := make([]string, 0, len(e.Inputs))
+			for name := range e.Inputs {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			fmt.Printf("    Inputs:")
+			for _, name := range names {
+				fmt.Printf(" %s [%s]", name, e.Inputs[name])
+			}
+			fmt.Println()
+		}
+		for _, q := range e.Quirks {
+			fmt.Printf("    ⚠ %s\n", q)
+		}
+		for _, b := range e.Broken {
+			fmt.Printf("    x Broken: %s\n", b)
+		}
+		if e.Notes != "" {
+			fmt.Printf("    %s\n", e.Notes)
+		}
+	}
+	for _, q := range r.Quirks {
+		fmt.Printf("  Local quirk %q: unsafe codes %v (%s)\n", q.Model, q.Codes, q.Reason)
+	}
+}
+
+func init() {
+	compatCmd.Flags().StringVar(&compatURL, "url", "", "also consult a compatibility database at this URL")
+	rootCmd.AddCommand(compatCmd)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sibteali786/monitorswitch/internal/config"
+	"github.com/spf13/viper"
+)
+
+var (
+	configFile string
+	configErr  error // Reported before the command runs
+)
+
+// initConfig loads config.yaml. A missing default file is fine; a missing
+// --config file or a malformed one fails the command. Commands run by batch
+// keep the config batch loaded.
+func initConfig() {
+	if inBatch {
+		return
+	}
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if configFile != "" {
+		v.SetConfigFile(configFile)
+	} else {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return
+		}
+		v.SetConfigFile(filepath.Join(dir, "monitorswitch", "config.yaml"))
+	}
+
+	if err := v.ReadInConfig(); err != nil {
+		var notFound *os.PathError
+		if configFile == "" && errors.As(err, &notFound) {
+			return
+		}
+		configErr = fmt.Errorf("cannot load config: %w", err)
+		return
+	}
+
+	var cfg config.Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		configErr = fmt.Errorf("invalid config %s: %w", v.ConfigFileUsed(), err)
+		return
+	}
+	config.Set(cfg)
+
+	if verbose {
+		fmt.Printf("[VERBOSE] Loaded config %s\n", v.ConfigFileUsed())
+	}
+}
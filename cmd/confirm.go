@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/cec"
+	"github.com/sibteali786/monitorswitch/internal/state"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// revertOSDInterval is how often the auto-revert countdown is refreshed on the OSD
+const revertOSDInterval = 5 * time.Second
+
+var confirmCmd = &cobra.Command{
+	Use:   "confirm [token]",
+	Short: "Acknowledge a pending switch and cancel its auto-revert",
+	Long:  "Acknowledge a switch made with --revert-after so it is kept. Without a token, every pending revert is cancelled.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var confirmed []state.PendingRevert
+		found := true
+		err := state.Update(func(st *state.State) {
+			if len(args) == 1 {
+				var p state.PendingRevert
+				if p, found = st.TakePendingRevert(args[0]); found {
+					confirmed = append(confirmed, p)
+				}
+				return
+			}
+			confirmed = st.PendingReverts
+			st.PendingReverts = nil
+		})
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("no pending revert with token %q", args[0])
+		}
+		if len(confirmed) == 0 {
+			fmt.Println("Nothing pending")
+			return nil
+		}
+
+		for _, p := range confirmed {
+			fmt.Printf("✓ Confirmed %s (monitor %s)\n", p.Token, p.MonitorID)
+		}
+		return nil
+	},
+}
+
+// revertPendingCmd is started in the background by --revert-after. It waits
+// until the revert is due and restores the previous value unless confirmed.
+var revertPendingCmd = &cobra.Command{
+	Use:    "revert-pending [token]",
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := state.Load()
+		if err != nil {
+			return err
+		}
+
+		var due time.Time
+		for _, p := range st.PendingReverts {
+			if p.Token == args[0] {
+				due = p.Due
+			}
+		}
+		if due.IsZero() {
+			return nil
+		}
+		if !waitForRevert(args[0], due) {
+			return nil
+		}
+
+		// Take the revert under the state lock: confirm may have run while
+		// we slept, or run right now
+		var p state.PendingRevert
+		ok := false
+		if err := state.Update(func(st *state.State) { p, ok = st.TakePendingRevert(args[0]) }); err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		return client.SetVCP(p.MonitorID, p.Code, p.Value)
+	},
+}
+
+// waitForRevert sleeps until due, counting down on the OSD when CEC is
+// available. It returns false if the revert was confirmed in the meantime.
+func waitForRevert(token string, due time.Time) bool {
+	if !cec.Available() {
+		time.Sleep(time.Until(due))
+		return true
+	}
+
+	for remaining := time.Until(due); remaining > 0; remaining = time.Until(due) {
+		cec.ShowOSD(fmt.Sprintf("Revert in %ds", int(remaining.Round(time.Second).Seconds())))
+		time.Sleep(min(remaining, revertOSDInterval))
+
+		st, err := state.Load()
+		if err != nil {
+			continue
+		}
+		pending := false
+		for _, p := range st.PendingReverts {
+			pending = pending || p.Token == token
+		}
+		if !pending {
+			return false
+		}
+	}
+	return true
+}
+
+// scheduleRevert records a pending revert and starts the background process
+// that performs it, returning the token needed to confirm
+func scheduleRevert(monitorID string, code byte, value uint16, after time.Duration) (string, error) {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	err := state.Update(func(st *state.State) {
+		st.AddPendingRevert(state.PendingRevert{
+			Token:     token,
+			MonitorID: monitorID,
+			Code:      code,
+			Value:     value,
+			Due:       time.Now().Add(after),
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	child := exec.Command(exe, append([]string{"revert-pending", token}, revertFlags()...)...)
+	// The revert must outlive the terminal: it's what undoes a switch that
+	// left the user without a screen
+	child.SysProcAttr = detachedProcess()
+	if err := child.Start(); err != nil {
+		return "", err
+	}
+	return token, child.Process.Release()
+}
+
+// revertForwardedFlags are the global flags the background revert inherits,
+// so it writes through the same config, backend and safety settings and logs
+// where the command that scheduled it does
+var revertForwardedFlags = map[string]bool{
+	"config":     true,
+	"read-only":  true,
+	"force":      true,
+	"no-cache":   true,
+	"no-coexist": true,
+	"verbose":    true,
+	"log-level":  true,
+	"log-file":   true,
+	"log-format": true,
+}
+
+// revertFlags returns the forwarded flags set on this invocation
+func revertFlags() []string {
+	var args []string
+	rootCmd.PersistentFlags().Visit(func(f *pflag.Flag) {
+		if revertForwardedFlags[f.Name] {
+			args = append(args, fmt.Sprintf("--%s=%s", f.Name, f.Value.String()))
+		}
+	})
+	return args
+}
+
+func init() {
+	rootCmd.AddCommand(confirmCmd)
+	rootCmd.AddCommand(revertPendingCmd)
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package cmd
+
+import "syscall"
+
+// detachedProcess starts a child in its own session, so it survives the
+// terminal or ssh session closing (SIGHUP)
+func detachedProcess() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,15 @@
+//go:build windows
+
+package cmd
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// detachedProcess starts a child without a console and in its own process
+// group, so closing the console or pressing Ctrl+C doesn't end it
+func detachedProcess() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: windows.DETACHED_PROCESS | windows.CREATE_NEW_PROCESS_GROUP}
+}
@@ -2,65 +2,153 @@ package cmd
 
 import (
 	"fmt"
-	"monitorswitch/internal/ddc"
+	"strings"
 
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/display"
+	"github.com/sibteali786/monitorswitch/internal/service"
 	"github.com/spf13/cobra"
 )
 
+var (
+	detectFast bool
+	detectDeep bool
+)
+
 var detectCmd = &cobra.Command{
 	Use:   "detect",
 	Short: "Detects monitors connected",
-	Long:  "Gets the list of monitors connected to the system and their current input sources.",
-	Run: func(cmd *cobra.Command, args []string) {
-		// Your implementation here
-		detector := ddc.NewDetector()
+	Long: `Gets the list of monitors connected to the system and their current input sources.
+
+  --fast  only list the displays the OS knows about; no DDC/CI traffic
+  --deep  also probe every feature of every monitor (slow, may show the OSD)`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if detectFast && detectDeep {
+			return fmt.Errorf("--fast and --deep are mutually exclusive")
+		}
+		if detectFast {
+			return detectOutputs()
+		}
 
-		fmt.Printf("Operating System: %s\n", detector.GetOSInfo())
+		if verbose && !jsonOutput() {
+			fmt.Println("[VERBOSE] Attempting monitor detection...")
+		}
 
-		supported, message := detector.CheckDDCSupport()
-		if supported {
-			fmt.Printf("✓ DDC/CI Support: %s\n", message)
-		} else {
-			fmt.Printf("✗ DDC/CI Support: %s\n", message)
+		svc, err := newService()
+		if err != nil {
+			return err
 		}
-		// For now, we will just print a placeholder message
+		result := svc.Detect(service.DetectOptions{Deep: detectDeep, GPUs: verbose})
+		return render(result, func() { printDetectResult(result) })
+	},
+}
+
+func printDetectResult(r *service.DetectResult) {
+	fmt.Printf("Operating System: %s\n", formatOS(r.OS))
+	if r.DDCSupported {
+		fmt.Printf("✓ DDC/CI Support: %s\n", r.DDCMessage)
+	} else {
+		fmt.Printf("✗ DDC/CI Support: %s\n", r.DDCMessage)
+	}
+	printSession(r.Session, r.Advice)
+	printVirtualization(r.Virtualized)
+	printGPUs(r.GPUs)
+	if r.DetectError != "" {
+		fmt.Printf("x Monitor Detection Failed: %s\n", r.DetectError)
+	}
+
+	if len(r.Monitors) == 0 {
+		fmt.Println("\nNo DDC/CI compatible monitors detected")
 		if verbose {
-			fmt.Println("\n[VERBOSE] Attempting monitor detection...")
+			fmt.Println("[VERBOSE] This could mean:")
+			fmt.Println("  - No external monitors connected")
+			fmt.Println("  - Monitors don't support DDC/CI")
+			fmt.Println("  - DDC/CI tools not properly configured")
 		}
+	}
 
-		monitors, err := detector.DetectMonitors()
-		if err != nil {
-			fmt.Printf("x Monitor Detection Failed: %v\n", err)
+	fmt.Printf("\nFound %d monitors\n", len(r.Monitors))
+	for i, monitor := range r.Monitors {
+		fmt.Printf("- Monitor %d: %s (ID: %s)\n", i+1, monitor.Name, monitor.ID)
+		if monitor.StableID != "" {
+			fmt.Printf("  Stable ID: %s\n", monitor.StableID)
+		}
+		if monitor.CurrentInput != "" {
+			fmt.Printf("  Current input: %s\n", describeInput(monitor.CurrentInput, monitor.InputLabel))
 		}
 
-		if len(monitors) == 0 {
-			fmt.Println("\nNo DDC/CI compatible monitors detected")
-			if verbose {
-				fmt.Println("[VERBOSE] This could mean:")
-				fmt.Println("  - No external monitors connected")
-				fmt.Println("  - Monitors don't support DDC/CI")
-				fmt.Println("  - DDC/CI tools not properly configured")
-			}
+		if verbose || !monitor.DDCSupported {
+			printDDCSupport(monitor.DDCSupported, monitor.DDCTool, monitor.Asleep)
+		}
+		if verbose && len(monitor.Features) > 0 {
+			fmt.Printf("  Features: %s\n", joinFeatures(monitor.Features))
+		}
+		printValidation(monitor.Validation)
+
+		if monitor.PreviousFingerprint != "" {
+			fmt.Printf("  ⚠ Fingerprint changed (%s → %s): new firmware or a different revision? Review quirks and saved profiles.\n",
+				monitor.PreviousFingerprint, monitor.Fingerprint)
 		}
 
-		fmt.Printf("\nFound %d monitors", len(monitors))
-		for i, monitor := range monitors {
-			fmt.Printf("- Monitor %d: %s (ID: %s)\n", i+1, monitor.Name, monitor.ID)
-			if monitor.CurrentInput != "" {
-				fmt.Printf("  Current input: %s\n", monitor.CurrentInput)
+		if monitor.Asleep {
+			fmt.Println("  State: asleep (skipping DDC queries)")
+		} else if len(monitor.Support) > 0 {
+			fmt.Printf("  Support: ")
+			for _, f := range monitor.Support {
+				fmt.Printf("%s=%s  ", f.Feature, f.Level)
 			}
+			fmt.Println()
+		}
 
-			if verbose && len(monitor.Inputs) > 0 {
-				fmt.Printf("  Available inputs: ")
-				for input, code := range monitor.Inputs {
-					fmt.Printf("%s (0x%02X) ", input, code)
-				}
-				fmt.Println()
+		if verbose && len(monitor.Inputs) > 0 {
+			inputLabels := loadLabels()
+			fmt.Printf("  Available inputs: ")
+			for input, code := range monitor.Inputs {
+				fmt.Printf("%s [0x%02X] ", inputLabels.Describe(ddc.Monitor{ID: monitor.ID, StableID: monitor.StableID}, input), code)
 			}
+			fmt.Println()
 		}
-	},
+	}
+}
+
+// describeInput formats an input with its label, e.g. "HDMI-1 (Work MacBook)"
+func describeInput(input, label string) string {
+	if label == "" {
+		return input
+	}
+	return fmt.Sprintf("%s (%s)", input, label)
+}
+
+// detectOutputs lists displays from the OS display APIs only
+func detectOutputs() error {
+	outputs, err := display.Outputs()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Found %d displays\n", len(outputs))
+	for i, o := range outputs {
+		if o.Description != "" {
+			fmt.Printf("- Display %d: %s (%s)\n", i+1, o.Name, o.Description)
+		} else {
+			fmt.Printf("- Display %d: %s\n", i+1, o.Name)
+		}
+	}
+	return nil
 }
 
 func init() {
+	detectCmd.Flags().BoolVar(&detectFast, "fast", false, "only enumerate displays, without DDC/CI traffic")
+	detectCmd.Flags().BoolVar(&detectDeep, "deep", false, "probe every feature of every monitor")
 	rootCmd.AddCommand(detectCmd)
 }
+
+// joinFeatures lists features for text output, e.g. "brightness, input"
+func joinFeatures(features []ddc.Feature) string {
+	names := make([]string, len(features))
+	for i, f := range features {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
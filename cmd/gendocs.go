@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+var (
+	genDocsDir    string
+	genDocsFormat string
+)
+
+// backendDocs describes the DDC/CI backends used on each OS, for the generated docs
+var backendDocs = []struct {
+	os       string
+	backends string
+}{
+	{"Linux", "ddcutil (DDC/CI); xrandr, wlr-randr, swaymsg, hyprctl (display modes, layout, power)"},
+	{"macOS", "m1ddc on Apple Silicon, ddcctl on Intel (DDC/CI); CoreGraphics (display modes, layout)"},
+	{"Windows", "dxva2 (DDC/CI); user32 display settings APIs (modes, layout, power)"},
+}
+
+var genDocsCmd = &cobra.Command{
+	Use:    "gen-docs",
+	Short:  "Generate man pages or markdown reference docs",
+	Long:   "Generate man pages or per-command markdown from the command tree, for packagers.",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := os.MkdirAll(genDocsDir, 0o755); err != nil {
+			return err
+		}
+
+		root := cmd.Root()
+		root.DisableAutoGenTag = true
+
+		switch genDocsFormat {
+		case "man":
+			header := &doc.GenManHeader{Title: "MONITORSWITCH", Section: "1", Source: "monitorswitch"}
+			if err := doc.GenManTree(root, header, genDocsDir); err != nil {
+				return err
+			}
+		case "markdown":
+			if err := doc.GenMarkdownTree(root, genDocsDir); err != nil {
+				return err
+			}
+			if err := writeBackendDocs(filepath.Join(genDocsDir, "backends.md")); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown format %q (want man or markdown)", genDocsFormat)
+		}
+
+		fmt.Printf("✓ Generated %s docs in %s\n", genDocsFormat, genDocsDir)
+		return nil
+	},
+}
+
+func writeBackendDocs(path string) error {
+	var b strings.Builder
+	b.WriteString("## Backends\n\n| OS | Backends |\n|----|----------|\n")
+	for _, d := range backendDocs {
+		fmt.Fprintf(&b, "| %s | %s |\n", d.os, d.backends)
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+func init() {
+	genDocsCmd.Flags().StringVar(&genDocsDir, "dir", "docs", "directory to write the docs to")
+	genDocsCmd.Flags().StringVar(&genDocsFormat, "format", "markdown", "output format: man or markdown")
+	rootCmd.AddCommand(genDocsCmd)
+}
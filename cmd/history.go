@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/mccs"
+	"github.com/sibteali786/monitorswitch/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var historySince time.Duration
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show changes made by monitorswitch",
+	Long:  "Show the changes monitorswitch recorded in its local state store, and the last-known state of each monitor.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := state.Load()
+		if err != nil {
+			return err
+		}
+
+		changes := st.ChangesSince(time.Now().Add(-historySince))
+		if len(changes) == 0 {
+			fmt.Printf("No changes in the last %s\n", historySince)
+		}
+		for _, c := range changes {
+			name := st.Monitors[c.MonitorID].Name
+			fmt.Printf("%s  Monitor %s (%s): VCP 0x%02X = %s  [%s]\n",
+				c.Time.Format("2006-01-02 15:04:05"), c.MonitorID, name, c.Code, mccs.Decode(c.Code, c.Value), c.Source)
+		}
+
+		if verbose && len(st.Monitors) > 0 {
+			fmt.Println("\nLast-known state:")
+			for id, m := range st.Monitors {
+				fmt.Printf("- Monitor %s (%s): input=%q updated %s\n", id, m.Name, m.Input, m.UpdatedAt.Format(time.RFC3339))
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	historyCmd.Flags().DurationVar(&historySince, "since", 24*time.Hour, "how far back to show changes")
+	rootCmd.AddCommand(historyCmd)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/labels"
+	"github.com/spf13/cobra"
+)
+
+var labelCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Give monitor inputs friendly names",
+	Long: `Label inputs per monitor ("HDMI-1" -> "Work MacBook"). Labels are shown by detect, list and status, and are accepted by switch.
+
+Monitors are named as for --monitor and labels are kept by their stable ID, so
+they follow the monitor across reboots and ports.`,
+}
+
+var labelSetCmd = &cobra.Command{
+	Use:     "set [monitor] [input] [label]",
+	Short:   "Label an input",
+	Example: `  monitorswitch label set 1 HDMI-1 "Work MacBook"`,
+	Args:    cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		m, err := labelMonitor(args[0])
+		if err != nil {
+			return err
+		}
+		l, err := labels.Load()
+		if err != nil {
+			return err
+		}
+		l.Set(m, args[1], args[2])
+		if err := l.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Monitor %s (%s): %s is now %q\n", m.ID, m.Name, args[1], args[2])
+		return nil
+	},
+}
+
+var labelRemoveCmd = &cobra.Command{
+	Use:   "remove [monitor] [input]",
+	Short: "Remove an input label",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Labels of a monitor that is no longer connected are removed by
+		// the key label list shows
+		m, err := labelMonitor(args[0])
+		if err != nil {
+			m = ddc.Monitor{ID: args[0]}
+		}
+		l, err := labels.Load()
+		if err != nil {
+			return err
+		}
+		if !l.Remove(m, args[1]) {
+			return fmt.Errorf("monitor %s has no label for %s", args[0], args[1])
+		}
+		return l.Save()
+	},
+}
+
+var labelListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List input labels",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l, err := labels.Load()
+		if err != nil {
+			return err
+		}
+		if len(l) == 0 {
+			fmt.Println("No labels defined")
+			return nil
+		}
+		keys := make([]string, 0, len(l))
+		for key := range l {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("Monitor %s:\n", key)
+			inputs := make([]string, 0, len(l[key]))
+			for input := range l[key] {
+				inputs = append(inputs, input)
+			}
+			sort.Strings(inputs)
+			for _, input := range inputs {
+				fmt.Printf("  %s → %s\n", input, l[key][input])
+			}
+		}
+		return nil
+	},
+}
+
+// labelMonitor finds the connected monitor a label command names
+func labelMonitor(id string) (ddc.Monitor, error) {
+	s, err := getSession()
+	if err != nil {
+		return ddc.Monitor{}, err
+	}
+	monitors, err := s.Monitors()
+	if err != nil {
+		return ddc.Monitor{}, fmt.Errorf("monitor detection failed: %w", err)
+	}
+	targets, err := selectMonitors(monitors, id)
+	if err != nil {
+		return ddc.Monitor{}, err
+	}
+	return targets[0], nil
+}
+
+func init() {
+	labelCmd.AddCommand(labelSetCmd, labelRemoveCmd, labelListCmd)
+	rootCmd.AddCommand(labelCmd)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/display"
+	"github.com/spf13/cobra"
+)
+
+var (
+	layoutPrimary   string
+	layoutPositions []string
+	layoutMirror    bool
+	layoutExtend    bool
+)
+
+var layoutCmd = &cobra.Command{
+	Use:   "layout",
+	Short: "Arrange displays: positions, primary display, mirroring",
+	Long: `Arrange displays through the OS display APIs (xrandr/wlr-randr on Linux,
+CoreGraphics on macOS, SetDisplayConfig/ChangeDisplaySettingsEx on Windows).
+
+Outputs are named the same way as for the mode command. This does not use DDC/CI.`,
+	Example: `  monitorswitch layout --primary DP-1 --mirror
+  monitorswitch layout --extend --pos DP-1=0,0 --pos HDMI-1=2560,0`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		layout := display.Layout{
+			Primary:   layoutPrimary,
+			Positions: make(map[string]display.Point),
+			Mirror:    layoutMirror,
+			Extend:    layoutExtend,
+		}
+
+		for _, p := range layoutPositions {
+			output, point, err := display.ParsePosition(p)
+			if err != nil {
+				return err
+			}
+			layout.Positions[output] = point
+		}
+
+		if err := checkWritable(); err != nil {
+			return err
+		}
+		if err := display.ApplyLayout(layout); err != nil {
+			return err
+		}
+
+		fmt.Println("✓ Display layout applied")
+		return nil
+	},
+}
+
+func init() {
+	layoutCmd.Flags().StringVar(&layoutPrimary, "primary", "", "output to make the primary display")
+	layoutCmd.Flags().StringArrayVar(&layoutPositions, "pos", nil, "output position as OUTPUT=X,Y (repeatable)")
+	layoutCmd.Flags().BoolVar(&layoutMirror, "mirror", false, "mirror all displays onto the primary display")
+	layoutCmd.Flags().BoolVar(&layoutExtend, "extend", false, "stop mirroring and extend the desktop")
+	rootCmd.AddCommand(layoutCmd)
+}
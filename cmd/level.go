@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sibteali786/monitorswitch/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// negativeLevel matches a relative decrease like "-15", which flag parsing
+// would otherwise take for a shorthand flag
+var negativeLevel = regexp.MustCompile(`^-\d+%?$`)
+
+// parseLevelArgs parses flags for commands taking a level argument. They set
+// DisableFlagParsing so "-15" reaches them, and parse the rest here.
+func parseLevelArgs(cmd *cobra.Command, args []string) (positional []string, help bool, err error) {
+	var levels, rest []string
+	for _, arg := range args {
+		if negativeLevel.MatchString(arg) {
+			levels = append(levels, arg)
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+
+	// ParseFlags is a no-op while DisableFlagParsing is set
+	cmd.DisableFlagParsing = false
+	defer func() { cmd.DisableFlagParsing = true }()
+	if err := cmd.ParseFlags(rest); err != nil {
+		return nil, false, err
+	}
+	if help, _ := cmd.Flags().GetBool("help"); help {
+		return nil, true, cmd.Help()
+	}
+	return append(cmd.Flags().Args(), levels...), false, nil
+}
+
+// parseLevel parses "70", "70%", "+10" or "-15"
+func parseLevel(arg string) (percent int, relative bool, err error) {
+	s := strings.TrimSuffix(arg, "%")
+	relative = strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-")
+	percent, err = strconv.Atoi(s)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid level %q: want 0-100, +N or -N", arg)
+	}
+	if !relative && (percent < 0 || percent > 100) {
+		return 0, false, fmt.Errorf("level %d is out of range 0-100", percent)
+	}
+	return percent, relative, nil
+}
+
+// runLevel reads or sets a continuous feature on the selected monitors
+func runLevel(req service.LevelRequest, args []string) error {
+	// "get" and "set N" are accepted as explicit spellings of the two modes
+	if len(args) > 0 && (args[0] == "get" || args[0] == "set") {
+		if args[0] == "get" && len(args) > 1 || args[0] == "set" && len(args) != 2 {
+			return fmt.Errorf("usage: %[1]s get | %[1]s set <level>", req.Feature)
+		}
+		args = args[1:]
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("expected at most one level, got %d", len(args))
+	}
+	if len(args) == 1 {
+		percent, relative, err := parseLevel(args[0])
+		if err != nil {
+			return err
+		}
+		req.Set, req.Percent, req.Relative = true, percent, relative
+	}
+
+	svc, err := newService()
+	if err != nil {
+		return err
+	}
+
+	result, err := svc.Level(req)
+	if err != nil {
+		return err
+	}
+	if err := render(result, func() { printLevel(result, req.Set) }); err != nil {
+		return err
+	}
+	if n := result.Failed(); n > 0 {
+		return fmt.Errorf("%s failed on %d of %d monitors", result.Feature, n, len(result.Monitors))
+	}
+	return nil
+}
+
+func printLevel(r *service.LevelResult, set bool) {
+	for _, m := range r.Monitors {
+		switch {
+		case m.Error != "":
+			fmt.Printf("x Monitor %s (%s): %s\n", m.ID, m.Name, m.Error)
+		case !set:
+			fmt.Printf("Monitor %s (%s): %s %d%%\n", m.ID, m.Name, r.Feature, m.Percent)
+		case m.Previous != nil:
+			fmt.Printf("✓ Monitor %s (%s): %s %d%% → %d%%\n", m.ID, m.Name, r.Feature, *m.Previous, m.Percent)
+		default:
+			fmt.Printf("✓ Monitor %s (%s): %s set to %d%%\n", m.ID, m.Name, r.Feature, m.Percent)
+		}
+	}
+}
+
+// newLevelCmd builds a command like "brightness [level]" for a continuous feature
+func newLevelCmd(feature string, code byte) *cobra.Command {
+	var (
+		monitorID string
+		all       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   feature + " [get | [set] level]",
+		Short: fmt.Sprintf("Get or set monitor %s", feature),
+		Long: fmt.Sprintf(`Show the %s, or set it to an absolute percentage (70) or change it
+relative to the current value (+10, -15). Levels are clamped to 0-100%% of the
+maximum the monitor reports. "get" and "set" may be spelled out.`, feature),
+		Example: fmt.Sprintf(`  monitorswitch %[1]s
+  monitorswitch %[1]s 70
+  monitorswitch %[1]s +10 --all
+  monitorswitch %[1]s -15 --monitor 2
+  monitorswitch %[1]s set 30`, feature),
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			args, help, err := parseLevelArgs(cmd, args)
+			if help || err != nil {
+				return err
+			}
+			return runLevel(service.LevelRequest{
+				Feature:   feature,
+				Code:      code,
+				MonitorID: monitorID,
+				All:       all,
+			}, args)
+		},
+	}
+	cmd.Flags().StringVarP(&monitorID, "monitor", "m", "", "monitor ID (required with several monitors unless --all)")
+	cmd.Flags().BoolVar(&all, "all", false, "apply to every monitor")
+	return cmd
+}
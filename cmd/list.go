@@ -3,24 +3,60 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/sibteali786/monitorswitch/internal/service"
 	"github.com/spf13/cobra"
 )
 
+var listMonitor string
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "Lists available inputs",
-	Long:  "Lists all available inputs like (hdmi, usb-c, etc.)",
-	Run: func(cmd *cobra.Command, args []string) {
-		// Your implementation here
-		if verbose {
-			fmt.Println(" (Verbose mode enabled: Listing all available inputs in detail...)")
-		} else {
-			fmt.Println("Available inputs: HDMI, USB-C, DisplayPort")
+	Long:  "Lists the inputs (hdmi, usb-c, etc.) each monitor advertises in its capabilities, marking the active one.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := newService()
+		if err != nil {
+			return err
+		}
+
+		result, err := svc.List(listMonitor)
+		if err != nil {
+			return err
 		}
+		return render(result, func() { printList(result) })
 	},
 }
 
-func init() {
+func printList(r *service.ListResult) {
+	if len(r.Monitors) == 0 {
+		fmt.Println("No DDC/CI compatible monitors detected")
+		return
+	}
+
+	for _, m := range r.Monitors {
+		fmt.Printf("Monitor %s (%s):\n", m.ID, m.Name)
+		switch {
+		case m.Asleep:
+			fmt.Println("  asleep (skipping DDC queries)")
+			continue
+		case m.Error != "" && verbose:
+			fmt.Printf("[VERBOSE] Could not read capabilities: %s\n", m.Error)
+		}
+		if len(m.Inputs) == 0 {
+			fmt.Println("  No inputs advertised")
+		}
+		for _, in := range m.Inputs {
+			marker := " "
+			if in.Active {
+				marker = "*"
+			}
+			fmt.Printf("  %s %s [0x%02X]\n", marker, describeInput(in.Name, in.Label), in.Code)
+		}
+	}
+}
 
+func init() {
+	listCmd.Flags().StringVarP(&listMonitor, "monitor", "m", "", "only list this monitor's inputs")
 	rootCmd.AddCommand(listCmd)
 }
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+)
+
+var (
+	logLevel  string
+	logFile   string
+	logFormat string
+)
+
+// logger receives diagnostics from the ddc package. Command output stays on
+// stdout; logs go to stderr or --log-file so they never mix with --output json.
+var logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+// setupLogging builds logger from --log-level, --log-file and --log-format.
+// --verbose lowers the default level to info.
+func setupLogging() error {
+	level := slog.LevelWarn
+	switch strings.ToLower(logLevel) {
+	case "":
+		if verbose {
+			level = slog.LevelInfo
+		}
+	case "debug":
+		level = slog.LevelDebug
+	case "info":
+		level = slog.LevelInfo
+	case "warn", "warning":
+		level = slog.LevelWarn
+	case "error":
+		level = slog.LevelError
+	default:
+		return fmt.Errorf("unknown log level %q (want debug, info, warn or error)", logLevel)
+	}
+
+	var w io.Writer = os.Stderr
+	if logFile != "" {
+		// Left open for the life of the process
+		f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		w = f
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	switch logFormat {
+	case "text", "":
+		logger = slog.New(slog.NewTextHandler(w, opts))
+	case "json":
+		logger = slog.New(slog.NewJSONHandler(w, opts))
+	default:
+		return fmt.Errorf("unknown log format %q (want text or json)", logFormat)
+	}
+	return nil
+}
+
+// newDetector returns a detector, and through it clients, that log to logger
+func newDetector() *ddc.Detector {
+	d := ddc.NewDetector()
+	d.SetLogger(logger)
+	return d
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "log level: debug, info, warn or error (default warn, info with --verbose)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "append logs to this file instead of stderr")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "log format: text or json")
+}
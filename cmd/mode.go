@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/display"
+	"github.com/spf13/cobra"
+)
+
+var modeCmd = &cobra.Command{
+	Use:   "mode [output] [WIDTHxHEIGHT[@HZ]]",
+	Short: "Set display resolution and refresh rate",
+	Long: `Set the resolution and refresh rate of a display through the OS display APIs
+(xrandr/wlr-randr on Linux, CoreGraphics on macOS, ChangeDisplaySettingsEx on Windows).
+
+This does not use DDC/CI. The output is the connector name on Linux (e.g. DP-1),
+the display ID shown by detect on macOS, and the GDI device name on Windows
+(e.g. \\.\DISPLAY1).`,
+	Example: "  monitorswitch mode DP-1 2560x1440@120",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mode, err := display.ParseMode(args[1])
+		if err != nil {
+			return err
+		}
+
+		if verbose {
+			fmt.Printf("[VERBOSE] Setting %s to %s\n", args[0], mode)
+		}
+
+		if err := checkWritable(); err != nil {
+			return err
+		}
+		if err := display.SetMode(args[0], mode); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ %s set to %s\n", args[0], mode)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(modeCmd)
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/audio"
+	"github.com/sibteali786/monitorswitch/internal/config"
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/labels"
+	"github.com/sibteali786/monitorswitch/internal/service"
+	"github.com/sibteali786/monitorswitch/internal/state"
+	"github.com/sibteali786/monitorswitch/pkg/monitorswitch"
+)
+
+// session holds one DDC client and one detection pass. Normally it lives for
+// a single command; batch mode keeps it for every command it runs.
+type session struct {
+	*monitorswitch.Client
+	client ddc.DDCClient
+}
+
+var activeSession *session
+
+// getSession returns the shared session, creating it on first use
+func getSession() (*session, error) {
+	if activeSession != nil {
+		return activeSession, nil
+	}
+
+	retry := retryPolicy(config.Get().Retry)
+	opts := monitorswitch.Options{
+		Tool:      config.Get().Tool,
+		Retry:     &retry,
+		Aliases:   config.Get().Aliases,
+		Logger:    logger,
+		ReadOnly:  readOnly,
+		Force:     force,
+		NoCoexist: noCoexist,
+
+		NoCapabilitiesCache: noCache,
+
+		WatchSettle:   watchSettle,
+		WatchInterval: watchInterval,
+	}
+	if st, err := state.Load(); err == nil {
+		opts.SettleDelays = make(map[string]time.Duration)
+		for key, m := range st.Monitors {
+			if m.SettleDelay > 0 {
+				opts.SettleDelays[key] = m.SettleDelay
+			}
+		}
+	}
+	opts.OnSettleLearn = func(key string, delay time.Duration) {
+		if verbose {
+			fmt.Printf("[VERBOSE] Monitor %s needed a retry; settle delay is now %s\n", key, delay)
+		}
+		updateState(func(st *state.State) { st.RecordSettleDelay(key, delay) })
+	}
+	if injectFaults != "" {
+		opts.WrapBackend = func(client ddc.DDCClient) (ddc.DDCClient, error) {
+			faulty, err := ddc.ParseFaults(client, injectFaults)
+			if err != nil {
+				return nil, err
+			}
+			scope := "reads only"
+			if faulty.Writes {
+				scope = "reads and writes"
+			}
+			fmt.Fprintf(os.Stderr, "⚠ Injecting faults into %s: %.0f%% failure rate, up to %s latency\n",
+				scope, faulty.Rate*100, faulty.Latency)
+			return faulty, nil
+		}
+	}
+
+	warnConflictingArbiters()
+	client, err := monitorswitch.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+	if c := coexistClient(client.Backend()); c != nil && verbose {
+		fmt.Printf("[VERBOSE] Routing brightness changes through %s\n", c.Delegate().Name())
+	}
+
+	activeSession = &session{Client: client, client: client.Backend()}
+	return activeSession, nil
+}
+
+// coexistClient finds the brightness coexistence wrapper in client, if any
+func coexistClient(client ddc.DDCClient) *ddc.CoexistClient {
+	for client != nil {
+		if c, ok := client.(*ddc.CoexistClient); ok {
+			return c
+		}
+		w, ok := client.(interface{ Unwrap() ddc.DDCClient })
+		if !ok {
+			break
+		}
+		client = w.Unwrap()
+	}
+	return nil
+}
+
+// retryPolicy applies the retry settings of the config file to the default
+func retryPolicy(cfg config.Retry) ddc.RetryPolicy {
+	p := ddc.DefaultRetryPolicy
+	if cfg.Attempts > 0 {
+		p.Attempts = cfg.Attempts
+	}
+	if cfg.Backoff > 0 {
+		p.Backoff = cfg.Backoff
+	}
+	if cfg.Timeout > 0 {
+		p.Timeout = cfg.Timeout
+	}
+	if cfg.Verify != nil {
+		p.Verify = *cfg.Verify
+	}
+	return p
+}
+
+// warnConflictingArbiters warns about other DDC/CI controllers. Our own
+// processes are serialized by the client lock, but these aren't.
+func warnConflictingArbiters() {
+	for _, a := range ddc.RunningArbiters() {
+		fmt.Fprintf(os.Stderr, "⚠ %s is running and also talks DDC/CI; simultaneous requests can return corrupt replies. Consider pausing it.\n", a.Name)
+	}
+}
+
+// newClient creates the DDC client for the current OS
+func newClient() (ddc.DDCClient, error) {
+	s, err := getSession()
+	if err != nil {
+		return nil, err
+	}
+	return s.client, nil
+}
+
+// selectMonitors returns the monitor matching id, or every monitor when id is empty
+func selectMonitors(monitors []ddc.Monitor, id string) ([]ddc.Monitor, error) {
+	return service.SelectMonitors(monitors, id, config.Get())
+}
+
+// newService returns the command service bound to the shared session
+func newService() (*service.Service, error) {
+	s, err := getSession()
+	if err != nil {
+		return nil, err
+	}
+	return &service.Service{
+		Client:     s.client,
+		Monitors:   s.Monitors,
+		Labels:     loadLabels(),
+		Audio:      loadAudioLinks(),
+		Config:     config.Get(),
+		StateError: reportStateError,
+		Logger:     logger,
+	}, nil
+}
+
+// updateState loads the state store, applies fn and saves it. State is
+// best-effort: failures never fail the command and only show in verbose mode.
+func updateState(fn func(st *state.State)) {
+	reportStateError(state.Update(fn))
+}
+
+func reportStateError(err error) {
+	if err != nil && verbose {
+		fmt.Printf("[VERBOSE] Could not update state: %v\n", err)
+	}
+}
+
+// checkWritable refuses display changes made outside the DDC client in read-only mode
+func checkWritable() error {
+	if readOnly {
+		return ddc.ErrReadOnly
+	}
+	return nil
+}
+
+// loadAudioLinks returns the input audio links, or none if they can't be read
+func loadAudioLinks() audio.Links {
+	l, err := audio.Load()
+	if err != nil {
+		if verbose {
+			fmt.Printf("[VERBOSE] Could not load audio links: %v\n", err)
+		}
+		return audio.Links{}
+	}
+	return l
+}
+
+// loadLabels returns the input labels, or none if they can't be read
+func loadLabels() labels.Labels {
+	l, err := labels.Load()
+	if err != nil {
+		if verbose {
+			fmt.Printf("[VERBOSE] Could not load input labels: %v\n", err)
+		}
+		return labels.Labels{}
+	}
+	return l
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/cec"
+	"github.com/spf13/cobra"
+)
+
+var osdCmd = &cobra.Command{
+	Use:   "osd [message]",
+	Short: "Show a short message on the screen",
+	Long: `Show a short message on the display's OSD. DDC/CI has no way to display text,
+so this needs HDMI-CEC (most TVs) through libcec's cec-client. CEC allows at most
+13 characters; longer messages are truncated.`,
+	Example: `  monitorswitch osd "Gaming PC"`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkWritable(); err != nil {
+			return err
+		}
+		if err := cec.ShowOSD(args[0]); err != nil {
+			return err
+		}
+		fmt.Println("✓ Message shown")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(osdCmd)
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+)
+
+// outputFormat is the global --output flag: "text" or "json"
+var outputFormat string
+
+// render prints result as JSON when --output json is given, and otherwise
+// calls text to print it for humans
+func render(result any, text func()) error {
+	switch outputFormat {
+	case "json":
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case "text", "":
+		text()
+	default:
+		return fmt.Errorf("unknown output format %q (want text or json)", outputFormat)
+	}
+	return nil
+}
+
+// jsonOutput reports whether machine-readable output was requested
+func jsonOutput() bool {
+	return outputFormat == "json"
+}
+
+// formatOS describes the OS for text output, e.g. "linux (Ubuntu 22.04)"
+func formatOS(info ddc.OSInfo) string {
+	switch {
+	case info.Error != "":
+		return fmt.Sprintf("%s (Error: %s)", info.Type, info.Error)
+	case info.Name == "" && info.Version == "":
+		return string(info.Type)
+	}
+	return fmt.Sprintf("%s (%s %s)", info.Type, info.Name, info.Version)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/packaging"
+	"github.com/spf13/cobra"
+)
+
+var (
+	manifestsDir       string
+	manifestsHomepage  string
+	manifestsChecksums string
+)
+
+var packageManifestsCmd = &cobra.Command{
+	Use:    "package-manifests",
+	Short:  "Render Homebrew, Scoop and Debian packaging metadata",
+	Long:   "Render distribution manifests from the build version and the current command tree. Checksums come from a sha256sum-style file.",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		meta := packaging.Metadata{
+			Name:        "monitorswitch",
+			Version:     version,
+			Description: rootCmd.Short,
+			Homepage:    manifestsHomepage,
+		}
+		for _, c := range rootCmd.Commands() {
+			if c.IsAvailableCommand() {
+				meta.Commands = append(meta.Commands, c.Name())
+			}
+		}
+
+		if manifestsChecksums != "" {
+			sums, err := packaging.ReadChecksums(manifestsChecksums)
+			if err != nil {
+				return err
+			}
+			meta.Checksums = sums
+		}
+
+		written, err := packaging.Render(manifestsDir, meta)
+		if err != nil {
+			return err
+		}
+		for _, path := range written {
+			fmt.Printf("✓ Wrote %s\n", path)
+		}
+		return nil
+	},
+}
+
+func init() {
+	packageManifestsCmd.Flags().StringVar(&manifestsDir, "dir", "dist/manifests", "directory to write the manifests to")
+	packageManifestsCmd.Flags().StringVar(&manifestsHomepage, "homepage", "https://github.com/sibteali786/monitorswitch", "project homepage; release URLs are derived from it")
+	packageManifestsCmd.Flags().StringVar(&manifestsChecksums, "checksums", "", "sha256sum-style file with release archive checksums")
+	rootCmd.AddCommand(packageManifestsCmd)
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/display"
+	"github.com/sibteali786/monitorswitch/internal/state"
+	"github.com/sibteali786/monitorswitch/pkg/monitorswitch"
+	"github.com/spf13/cobra"
+)
+
+var parkMonitor string
+
+// parkedFeatures are snapshotted by park and restored by resume. Input goes
+// last on restore so the picture settings are in place when it appears.
+var parkedFeatures = []byte{ddc.VCPBrightness, ddc.VCPContrast, ddc.VCPVolume, ddc.VCPInputSource}
+
+// resumeWakeDelay gives monitors time to leave standby before restoring
+const resumeWakeDelay = 2 * time.Second
+
+var parkCmd = &cobra.Command{
+	Use:   "park",
+	Short: "Save monitor state and put monitors into standby",
+	Long:  "Record input, brightness, contrast and volume of each monitor, then put it into standby. Use resume to wake it and restore everything.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := getSession()
+		if err != nil {
+			return err
+		}
+
+		monitors, err := s.Monitors()
+		if err != nil {
+			return fmt.Errorf("monitor detection failed: %w", err)
+		}
+		targets, err := selectMonitors(monitors, parkMonitor)
+		if err != nil {
+			return err
+		}
+
+		snapshots := make(map[string]map[byte]uint16)
+		for _, m := range targets {
+			snapshot := make(map[byte]uint16)
+			for _, code := range parkedFeatures {
+				if value, err := s.client.GetVCP(m.ID, code); err == nil {
+					snapshot[code] = value
+				}
+			}
+			if len(snapshot) == 0 {
+				fmt.Printf("x Monitor %s (%s): could not read any settings, not parking\n", m.ID, m.Name)
+				continue
+			}
+			snapshots[m.ID] = snapshot
+
+			if err := s.client.SetVCP(m.ID, ddc.VCPPowerMode, powerModeValues[display.PowerStandby]); err != nil {
+				fmt.Printf("⚠ Monitor %s (%s): state saved, but standby failed: %v\n", m.ID, m.Name, err)
+				continue
+			}
+			fmt.Printf("✓ Monitor %s (%s): parked (%d settings saved)\n", m.ID, m.Name, len(snapshot))
+		}
+
+		// Saved even when standby failed, since resume restores either way
+		return state.Update(func(st *state.State) {
+			for _, m := range targets {
+				if snapshot, ok := snapshots[m.ID]; ok {
+					st.Park(ddc.StableKey(m), m.Name, snapshot)
+				}
+			}
+		})
+	},
+}
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Wake parked monitors and restore their state",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := getSession()
+		if err != nil {
+			return err
+		}
+
+		monitors, err := s.Monitors()
+		if err != nil {
+			return fmt.Errorf("monitor detection failed: %w", err)
+		}
+		targets, err := selectMonitors(monitors, parkMonitor)
+		if err != nil {
+			return err
+		}
+
+		st, err := state.Load()
+		if err != nil {
+			return err
+		}
+
+		plan := &monitorswitch.Plan{Name: "resume"}
+		var resumed []string
+		for _, m := range targets {
+			snapshot, ok := st.Unpark(ddc.StableKey(m))
+			if !ok {
+				continue
+			}
+			resumed = append(resumed, ddc.StableKey(m))
+			if err := s.client.SetVCP(m.ID, ddc.VCPPowerMode, powerModeValues[display.PowerOn]); err != nil {
+				fmt.Printf("⚠ Monitor %s (%s): wake failed: %v\n", m.ID, m.Name, err)
+			}
+			for _, code := range parkedFeatures {
+				if value, ok := snapshot[code]; ok {
+					plan.Operations = append(plan.Operations, monitorswitch.Operation{MonitorID: m.ID, MonitorName: m.Name, Code: code, Value: value})
+				}
+			}
+		}
+
+		if len(plan.Operations) == 0 {
+			fmt.Println("No parked monitors")
+			return nil
+		}
+
+		time.Sleep(resumeWakeDelay)
+		started := time.Now()
+		if _, err := s.Commit(plan); err != nil {
+			return withRecoverHint(started, err)
+		}
+		err = state.Update(func(st *state.State) {
+			for _, key := range resumed {
+				st.Unpark(key)
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Restored %d settings\n", len(plan.Operations))
+		return nil
+	},
+}
+
+func init() {
+	parkCmd.Flags().StringVarP(&parkMonitor, "monitor", "m", "", "monitor ID (default: all)")
+	resumeCmd.Flags().StringVarP(&parkMonitor, "monitor", "m", "", "monitor ID (default: all)")
+	rootCmd.AddCommand(parkCmd)
+	rootCmd.AddCommand(resumeCmd)
+}
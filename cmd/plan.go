@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/mccs"
+	"github.com/sibteali786/monitorswitch/pkg/monitorswitch"
+)
+
+// showPlan is the --plan flag of the commands that write: print the writes
+// a command resolves to and ask before making them
+var showPlan bool
+
+// confirmPlan prints plan and asks whether to apply it. With --output json
+// the plan is printed for review and nothing is applied.
+func confirmPlan(s *session, plan *monitorswitch.Plan) (bool, error) {
+	if jsonOutput() {
+		return false, render(plan, nil)
+	}
+
+	fmt.Println("Plan:")
+	for _, op := range plan.Operations {
+		m, err := s.Monitor(op.MonitorID)
+		if err != nil {
+			m = ddc.Monitor{ID: op.MonitorID, Name: op.MonitorName}
+		}
+		current := "?"
+		if op.Current != nil {
+			current = ddc.DecodeVCPValue(m, op.Code, *op.Current)
+		}
+		line := fmt.Sprintf("  Monitor %s (%s): VCP 0x%02X (%s) %s → %s", op.MonitorID, op.MonitorName,
+			op.Code, mccs.Name(op.Code), current, ddc.DecodeVCPValue(m, op.Code, op.Value))
+		if op.Unchanged() {
+			line += " (unchanged)"
+		}
+		fmt.Println(line)
+	}
+
+	// Commit skips the unchanged operations, so only the rest are writes
+	changes := plan.Changes()
+	if changes == 0 {
+		fmt.Println("Every monitor already has these settings; nothing to do")
+		return false, nil
+	}
+	fmt.Printf("Apply %d writes? [y/N] ", changes)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+		fmt.Println("Nothing changed")
+		return false, nil
+	}
+	return true, nil
+}
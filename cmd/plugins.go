@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/sibteali786/monitorswitch/internal/state"
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix is the executable name prefix of external subcommands
+const pluginPrefix = "monitorswitch-"
+
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "List external plugin subcommands found on PATH",
+	Long: `Executables named monitorswitch-<name> on PATH can be run as
+'monitorswitch <name>'. They receive context through environment variables:
+
+  MONITORSWITCH_BIN         path of the monitorswitch executable
+  MONITORSWITCH_CONFIG_DIR  configuration directory
+  MONITORSWITCH_STATE_DIR   state directory
+  MONITORSWITCH_MONITOR     monitor selected with --monitor/-m before the plugin name
+  MONITORSWITCH_VERBOSE     "1" when --verbose is given before the plugin name
+  MONITORSWITCH_OUTPUT      output format (text or json)`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plugins := findPlugins()
+		if len(plugins) == 0 {
+			fmt.Println("No plugins found on PATH")
+			return nil
+		}
+		for _, name := range sortedPluginNames(plugins) {
+			fmt.Printf("%s\t%s\n", name, plugins[name])
+		}
+		return nil
+	},
+}
+
+// findPlugins returns plugin name -> path for every monitorswitch-* executable on PATH.
+// Earlier PATH entries win, like the shell.
+func findPlugins() map[string]string {
+	plugins := make(map[string]string)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if e.IsDir() || !strings.HasPrefix(name, pluginPrefix) {
+				continue
+			}
+			if runtime.GOOS == "windows" {
+				if !strings.EqualFold(filepath.Ext(name), ".exe") {
+					continue
+				}
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			plugin := strings.TrimPrefix(name, pluginPrefix)
+			if _, seen := plugins[plugin]; !seen {
+				plugins[plugin] = filepath.Join(dir, e.Name())
+			}
+		}
+	}
+	return plugins
+}
+
+func sortedPluginNames(plugins map[string]string) []string {
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runPluginIfRequested runs an external plugin when args name one instead of
+// a built-in command. It only returns if no plugin was run.
+func runPluginIfRequested(args []string) {
+	i, monitor, pluginVerbose, ok := splitPluginArgs(args)
+	if !ok {
+		return
+	}
+	if c, _, err := rootCmd.Find(args[i:]); err == nil && c != rootCmd {
+		return
+	}
+	path, err := exec.LookPath(pluginPrefix + args[i])
+	if err != nil {
+		return
+	}
+
+	plugin := exec.Command(path, args[i+1:]...)
+	plugin.Stdin, plugin.Stdout, plugin.Stderr = os.Stdin, os.Stdout, os.Stderr
+	plugin.Env = append(os.Environ(), pluginEnv(monitor, pluginVerbose)...)
+
+	if err := plugin.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintf(os.Stderr, "Error: plugin %s: %v\n", args[i], err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// splitPluginArgs skips the global flags allowed before a plugin name and
+// returns the index of the name, or ok=false if there isn't one
+func splitPluginArgs(args []string) (i int, monitor string, verbose, ok bool) {
+	for ; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-v" || arg == "--verbose":
+			verbose = true
+		case (arg == "-m" || arg == "--monitor") && i+1 < len(args):
+			i++
+			monitor = args[i]
+		case strings.HasPrefix(arg, "--monitor="):
+			monitor = strings.TrimPrefix(arg, "--monitor=")
+		case strings.HasPrefix(arg, "-"):
+			return 0, "", false, false
+		default:
+			return i, monitor, verbose, true
+		}
+	}
+	return 0, "", false, false
+}
+
+func pluginEnv(monitor string, pluginVerbose bool) []string {
+	var env []string
+	if exe, err := os.Executable(); err == nil {
+		env = append(env, "MONITORSWITCH_BIN="+exe)
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		env = append(env, "MONITORSWITCH_CONFIG_DIR="+filepath.Join(dir, "monitorswitch"))
+	}
+	if dir, err := state.Dir(); err == nil {
+		env = append(env, "MONITORSWITCH_STATE_DIR="+dir)
+	}
+	if monitor != "" {
+		env = append(env, "MONITORSWITCH_MONITOR="+monitor)
+	}
+	if pluginVerbose {
+		env = append(env, "MONITORSWITCH_VERBOSE=1")
+	}
+	return append(env, "MONITORSWITCH_OUTPUT="+outputFormat)
+}
+
+func init() {
+	rootCmd.AddCommand(pluginsCmd)
+}
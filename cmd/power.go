@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/display"
+	"github.com/sibteali786/monitorswitch/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var powerMonitor string
+
+// VCP 0xD6 (Power Mode) values
+var powerModeValues = map[display.PowerState]uint16{
+	display.PowerOn:      0x01,
+	display.PowerStandby: 0x02,
+	display.PowerOff:     0x04,
+}
+
+var powerCmd = &cobra.Command{
+	Use:   "power [on|standby|off]",
+	Short: "Turn monitors on, to standby, or off",
+	Long: `Change monitor power using DDC/CI (VCP 0xD6). Monitors that don't support
+VCP 0xD6 fall back to the OS display power mechanism (sway/Hyprland DPMS or xset
+on Linux, pmset on macOS, SC_MONITORPOWER on Windows), which affects every display.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		powerState, err := display.ParsePowerState(args[0])
+		if err != nil {
+			return err
+		}
+
+		s, err := getSession()
+		if err != nil {
+			return err
+		}
+		client := s.client
+
+		monitors, err := s.Monitors()
+		if err != nil && verbose {
+			fmt.Printf("[VERBOSE] Monitor detection failed: %v\n", err)
+		}
+
+		targets, err := selectMonitors(monitors, powerMonitor)
+		if err != nil {
+			return err
+		}
+
+		needFallback := len(targets) == 0
+		for _, m := range targets {
+			if err := client.SetVCP(m.ID, ddc.VCPPowerMode, powerModeValues[powerState]); err != nil {
+				fmt.Printf("x Monitor %s (%s): DDC power control failed: %v\n", m.ID, m.Name, err)
+				needFallback = true
+				continue
+			}
+			updateState(func(st *state.State) {
+				st.RecordValue(ddc.StableKey(m), m.Name, ddc.VCPPowerMode, powerModeValues[powerState], "power")
+			})
+			fmt.Printf("✓ Monitor %s (%s): %s via DDC/CI (VCP 0xD6)\n", m.ID, m.Name, powerState)
+		}
+
+		if !needFallback {
+			return nil
+		}
+
+		if err := checkWritable(); err != nil {
+			return err
+		}
+		mechanism, err := display.SetPower(powerState)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ All displays: %s via %s\n", powerState, mechanism)
+		return nil
+	},
+}
+
+func init() {
+	powerCmd.Flags().StringVarP(&powerMonitor, "monitor", "m", "", "monitor ID to control (default: all)")
+	rootCmd.AddCommand(powerCmd)
+}
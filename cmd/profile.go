@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/state"
+	"github.com/sibteali786/monitorswitch/pkg/monitorswitch"
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Save and restore monitor configurations",
+	Long: `Profiles snapshot the input, brightness and contrast of every monitor so a whole desk setup can be restored in one step.
+
+A profile file can also set a monitor's resolution and refresh rate (mode: 2560x1440@120,
+optionally with output: DP-1) and the desktop layout (layout: with primary, positions
+such as DP-1: "0,0", mirror and extend), as the mode and layout commands do.`,
+}
+
+var profileSaveCmd = &cobra.Command{
+	Use:     "save [name]",
+	Short:   "Save the current settings of every monitor",
+	Example: "  monitorswitch profile save work",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := getSession()
+		if err != nil {
+			return err
+		}
+		p, err := s.CaptureProfile(args[0])
+		if err != nil {
+			return err
+		}
+		if monitors, err := s.Monitors(); err == nil {
+			for _, m := range monitors {
+				if _, ok := p.Find(m.StableID, m.ID, m.Name); !ok {
+					fmt.Printf("x Monitor %s (%s): could not read any settings, not saved\n", m.ID, m.Name)
+				}
+			}
+		}
+
+		if err := p.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Profile %q saved (%d monitors)\n", p.Name, len(p.Monitors))
+		return nil
+	},
+}
+
+var profileApplyCmd = &cobra.Command{
+	Use:   "apply [name]",
+	Short: "Restore a saved profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !showPlan {
+			return applyProfile(args[0])
+		}
+
+		s, err := getSession()
+		if err != nil {
+			return err
+		}
+		p, err := monitorswitch.LoadProfile(args[0])
+		if err != nil {
+			return err
+		}
+		plan, err := s.PlanProfile(args[0])
+		if err != nil {
+			return err
+		}
+		if ok, err := confirmPlan(s, plan); !ok || err != nil {
+			return err
+		}
+		return commitProfile(s, p, plan)
+	},
+}
+
+// applyProfile restores a saved profile on the connected monitors
+func applyProfile(name string) error {
+	s, err := getSession()
+	if err != nil {
+		return err
+	}
+	p, err := monitorswitch.LoadProfile(name)
+	if err != nil {
+		return err
+	}
+	if verbose {
+		if monitors, err := s.Monitors(); err == nil {
+			for _, m := range monitors {
+				if _, ok := p.Find(m.StableID, m.ID, m.Name); !ok {
+					fmt.Printf("[VERBOSE] Monitor %s (%s) is not in profile %q\n", m.ID, m.Name, p.Name)
+				}
+			}
+		}
+	}
+	plan, err := s.PlanProfile(name)
+	if err != nil {
+		return err
+	}
+	return commitProfile(s, p, plan)
+}
+
+// commitProfile sets the modes and layout of a profile, then runs the plan of
+// its DDC/CI settings, as monitorswitch.Client.ApplyProfile does
+func commitProfile(s *session, p *monitorswitch.Profile, plan *monitorswitch.Plan) error {
+	if err := applyProfileDisplay(s, p); err != nil {
+		return err
+	}
+	started := time.Now()
+	ops, err := s.Commit(plan)
+	settings := make([]monitorswitch.Setting, len(ops))
+	for i, op := range ops {
+		settings[i] = monitorswitch.Setting{MonitorID: op.MonitorID, Code: op.Code, Value: op.Value}
+	}
+	return profileApplied(p.Name, settings, withRecoverHint(started, err))
+}
+
+// applyProfileDisplay sets the modes and layout of a profile through the OS
+// display APIs. It runs before the DDC/CI writes, which can switch a monitor
+// away from this computer, and checks every entry before changing anything.
+func applyProfileDisplay(s *session, p *monitorswitch.Profile) error {
+	d, err := s.ProfileDisplay(p)
+	if err != nil {
+		return err
+	}
+	if len(d.Modes) == 0 && d.Layout == nil {
+		return nil
+	}
+	if err := checkWritable(); err != nil {
+		return err
+	}
+	if err := s.SetDisplay(d); err != nil {
+		return err
+	}
+	for _, m := range d.Modes {
+		fmt.Printf("✓ %s set to %s\n", m.Output, m.Mode)
+	}
+	if d.Layout != nil {
+		fmt.Println("✓ Display layout applied")
+	}
+	return nil
+}
+
+// profileApplied records the settings a profile apply wrote, even a partial
+// one, and reports the outcome
+func profileApplied(name string, settings []monitorswitch.Setting, err error) error {
+	keys := make(map[string]string)
+	if s, sessionErr := getSession(); sessionErr == nil {
+		if monitors, detectErr := s.Monitors(); detectErr == nil {
+			for _, m := range monitors {
+				keys[m.ID] = ddc.StableKey(m)
+			}
+		}
+	}
+	updateState(func(st *state.State) {
+		if err == nil {
+			st.LastProfile = name
+		}
+		for _, setting := range settings {
+			key, ok := keys[setting.MonitorID]
+			if !ok {
+				key = setting.MonitorID
+			}
+			st.RecordValue(key, "", setting.Code, setting.Value, "profile")
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Profile %q applied (%d settings changed)\n", name, len(settings))
+	return nil
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved profiles",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := monitorswitch.Profiles()
+		if err != nil {
+			return err
+		}
+
+		return render(names, func() {
+			if len(names) == 0 {
+				fmt.Println("No profiles saved")
+				return
+			}
+			last := ""
+			if st, err := state.Load(); err == nil {
+				last = st.LastProfile
+			}
+			for _, name := range names {
+				if name == last {
+					fmt.Printf("* %s (last applied)\n", name)
+				} else {
+					fmt.Printf("  %s\n", name)
+				}
+			}
+		})
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "Delete a saved profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := monitorswitch.DeleteProfile(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Profile %q deleted\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	profileApplyCmd.Flags().BoolVar(&showPlan, "plan", false, "show the writes and ask before making them (with -o json, only print them)")
+	profileCmd.AddCommand(profileSaveCmd, profileApplyCmd, profileListCmd, profileDeleteCmd)
+	rootCmd.AddCommand(profileCmd)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var recoverRollback bool
+
+var recoverCmd = &cobra.Command{
+	Use:   "recover",
+	Short: "Finish or roll back an interrupted multi-step operation",
+	Long: `Multi-step operations write an intent journal before touching any monitor. If
+one was interrupted (crash, power loss), recover finishes the remaining steps,
+or with --rollback restores the values recorded before the operation started.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		j, err := state.PendingJournal()
+		if err != nil {
+			return err
+		}
+		if j == nil {
+			fmt.Println("Nothing to recover")
+			return nil
+		}
+
+		s, err := getSession()
+		if err != nil {
+			return err
+		}
+		monitors, err := s.Monitors()
+		if err != nil {
+			return fmt.Errorf("monitor detection failed: %w", err)
+		}
+
+		fmt.Printf("Recovering %q started %s\n", j.Operation, j.StartedAt.Format(time.RFC3339))
+		if recoverRollback {
+			err = rollbackJournal(s.client, monitors, j)
+		} else {
+			err = finishJournal(s.client, monitors, j)
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("✓ Recovery complete")
+		return j.Finish()
+	},
+}
+
+func finishJournal(client ddc.DDCClient, monitors []ddc.Monitor, j *state.Journal) error {
+	ids, err := journalMonitors(monitors, j, func(step state.JournalStep) bool { return !step.Done })
+	if err != nil {
+		return err
+	}
+	for i, step := range j.Steps {
+		if step.Done {
+			continue
+		}
+		if err := client.SetVCP(ids[i], step.Code, step.Value); err != nil {
+			return fmt.Errorf("monitor %s: failed to set VCP 0x%02X: %w", ids[i], step.Code, err)
+		}
+		if err := j.MarkDone(i); err != nil {
+			return err
+		}
+		fmt.Printf("  ✓ Monitor %s: VCP 0x%02X = %d\n", ids[i], step.Code, step.Value)
+	}
+	return nil
+}
+
+func rollbackJournal(client ddc.DDCClient, monitors []ddc.Monitor, j *state.Journal) error {
+	ids, err := journalMonitors(monitors, j, func(step state.JournalStep) bool { return step.Done && step.HasPrevious })
+	if err != nil {
+		return err
+	}
+	for i := len(j.Steps) - 1; i >= 0; i-- {
+		step := j.Steps[i]
+		if !step.Done || !step.HasPrevious {
+			continue
+		}
+		if err := client.SetVCP(ids[i], step.Code, step.Previous); err != nil {
+			return fmt.Errorf("monitor %s: failed to restore VCP 0x%02X: %w", ids[i], step.Code, err)
+		}
+		fmt.Printf("  ✓ Monitor %s: VCP 0x%02X restored to %d\n", ids[i], step.Code, step.Previous)
+	}
+	return nil
+}
+
+// journalMonitors resolves the monitor of every step replay will write, by
+// stable ID when the step has one since the display number may now belong to
+// another monitor. It refuses the whole replay if one of them is gone, before
+// writing anything.
+func journalMonitors(monitors []ddc.Monitor, j *state.Journal, replayed func(state.JournalStep) bool) ([]string, error) {
+	ids := make([]string, len(j.Steps))
+	for i, step := range j.Steps {
+		if !replayed(step) {
+			continue
+		}
+		if step.StableID == "" {
+			ids[i] = step.MonitorID
+			continue
+		}
+		for _, m := range monitors {
+			if m.StableID == step.StableID {
+				ids[i] = m.ID
+				break
+			}
+		}
+		if ids[i] == "" {
+			return nil, fmt.Errorf("monitor %s (%s) is no longer connected; reconnect it and run recover again",
+				step.MonitorID, step.StableID)
+		}
+	}
+	return ids, nil
+}
+
+// withRecoverHint points at recover when a commit that started at started
+// failed and left its journal behind
+func withRecoverHint(started time.Time, err error) error {
+	if err == nil {
+		return nil
+	}
+	if j, jerr := state.PendingJournal(); jerr == nil && j != nil && !j.StartedAt.Before(started) {
+		return fmt.Errorf("%w (run 'monitorswitch recover' to finish or roll back)", err)
+	}
+	return err
+}
+
+// warnPendingJournal tells the user about an interrupted operation on startup
+func warnPendingJournal(cmd *cobra.Command) {
+	if cmd == recoverCmd {
+		return
+	}
+	if j, err := state.PendingJournal(); err == nil && j != nil {
+		fmt.Fprintf(os.Stderr, "⚠ An interrupted %q operation from %s is pending. Run 'monitorswitch recover' (or 'recover --rollback').\n",
+			j.Operation, j.StartedAt.Format(time.RFC3339))
+	}
+}
+
+func init() {
+	recoverCmd.Flags().BoolVar(&recoverRollback, "rollback", false, "restore previous values instead of finishing")
+	rootCmd.AddCommand(recoverCmd)
+}
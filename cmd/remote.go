@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+var (
+	remoteHost string
+	remoteBin  string
+)
+
+// runRemoteIfRequested runs the command on another machine when --host is
+// given, relaying its output and exit code. It only returns if --host isn't set.
+func runRemoteIfRequested(args []string) {
+	host, bin, rest, ok := splitRemoteArgs(args)
+	if !ok {
+		return
+	}
+
+	sshArgs, err := sshCommand(host, bin, rest)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	ssh := exec.Command("ssh", sshArgs...)
+	ssh.Stdin, ssh.Stdout, ssh.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := ssh.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintf(os.Stderr, "Error: ssh: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// splitRemoteArgs removes --host and --remote-bin from args
+func splitRemoteArgs(args []string) (host, bin string, rest []string, ok bool) {
+	bin = "monitorswitch"
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			rest = append(rest, args[i:]...)
+			return host, bin, rest, host != ""
+		case arg == "--host" && i+1 < len(args):
+			i++
+			host = args[i]
+		case strings.HasPrefix(arg, "--host="):
+			host = strings.TrimPrefix(arg, "--host=")
+		case arg == "--remote-bin" && i+1 < len(args):
+			i++
+			bin = args[i]
+		case strings.HasPrefix(arg, "--remote-bin="):
+			bin = strings.TrimPrefix(arg, "--remote-bin=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return host, bin, rest, host != ""
+}
+
+// sshCommand builds the ssh arguments for running bin with args on host
+func sshCommand(host, bin string, args []string) ([]string, error) {
+	u, err := url.Parse(host)
+	if err != nil || u.Scheme != "ssh" || u.Hostname() == "" {
+		return nil, fmt.Errorf("unsupported --host %q: only ssh://[user@]host[:port] is supported", host)
+	}
+
+	target := u.Hostname()
+	if u.User != nil {
+		target = u.User.Username() + "@" + target
+	}
+
+	var sshArgs []string
+	if port := u.Port(); port != "" {
+		sshArgs = append(sshArgs, "-p", port)
+	}
+
+	remote := []string{shellQuote(bin)}
+	for _, a := range args {
+		remote = append(remote, shellQuote(a))
+	}
+	return append(sshArgs, target, strings.Join(remote, " ")), nil
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,+@") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&remoteHost, "host", "", "run on another machine over SSH (ssh://[user@]host[:port])")
+	rootCmd.PersistentFlags().StringVar(&remoteBin, "remote-bin", "monitorswitch", "monitorswitch executable on the remote host")
+}
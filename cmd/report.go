@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/display"
+	"github.com/sibteali786/monitorswitch/internal/mccs"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportJSON   bool
+	reportSample string
+)
+
+// setupReport is everything 'report' collects about the machine and its monitors
+type setupReport struct {
+	OS           ddc.OSInfo          `json:"os"`
+	Arch         string              `json:"arch"`
+	Compositor   string              `json:"compositor,omitempty"`
+	Session      *ddc.Session        `json:"session,omitempty"`
+	Virtualized  *ddc.Virtualization `json:"virtualization,omitempty"`
+	Advice       []string            `json:"advice,omitempty"`
+	GPUs         []ddc.GPU           `json:"gpus,omitempty"`
+	DDCSupported bool                `json:"ddc_supported"`
+	DDCMessage   string              `json:"ddc_message"`
+	Backend      string              `json:"backend,omitempty"`
+	DisplayCount int                 `json:"display_count,omitempty"`
+	DetectError  string              `json:"detect_error,omitempty"`
+	Monitors     []monitorReport     `json:"monitors"`
+}
+
+// monitorReport describes one monitor in a setupReport
+type monitorReport struct {
+	ID           string                   `json:"id"`
+	Name         string                   `json:"name"`
+	Connector    string                   `json:"connector,omitempty"`
+	UUID         string                   `json:"uuid,omitempty"`
+	Main         bool                     `json:"main,omitempty"`
+	Asleep       bool                     `json:"asleep,omitempty"`
+	CurrentInput string                   `json:"current_input,omitempty"`
+	Inputs       map[string]byte          `json:"inputs,omitempty"`
+	Support      map[string]string        `json:"support"`
+	DDCSupported bool                     `json:"ddc_supported"`
+	DDCTool      string                   `json:"ddc_tool,omitempty"`
+	Validation   *ddc.DDCValidationResult `json:"validation,omitempty"`
+	Values       map[string]uint16        `json:"values"`
+	ValueNames   map[string]string        `json:"value_names,omitempty"` // MCCS names of enumerated values
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize the whole display setup",
+	Long: `Print a one-shot report of the OS, GPUs and drivers, DDC backend, every monitor,
+its capability matrix and current values. Paste it into bug reports when input
+switching doesn't work.
+
+With --contribute-sample, save the raw output of the tools monitorswitch
+parses (ddcutil, m1ddc, system_profiler, systeminfo...) with serial numbers
+and host names removed, to help support more tool versions and locales.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if reportSample != "" {
+			return collectSample(reportSample)
+		}
+
+		report := buildReport()
+
+		if reportJSON {
+			outputFormat = "json"
+		}
+		return render(report, func() { printReport(report) })
+	},
+}
+
+func buildReport() *setupReport {
+	detector := newDetector()
+	report := &setupReport{
+		OS:         detector.OSInfo(),
+		Arch:       ddc.DetectArchitecture().String(),
+		Compositor: string(ddc.DetectCompositor()),
+		GPUs:       detector.GPUs(),
+		Monitors:   []monitorReport{},
+	}
+	report.DDCSupported, report.DDCMessage = detector.CheckDDCSupport()
+	if report.Session = detector.Session(); report.Session != nil {
+		report.Advice = report.Session.Advice(report.DDCSupported)
+	}
+	if n, err := display.ActiveDisplayCount(); err == nil {
+		report.DisplayCount = n
+	}
+
+	s, err := getSession()
+	if err != nil {
+		report.DetectError = err.Error()
+		return report
+	}
+	report.Backend = ddc.BackendName(s.client)
+
+	monitors, err := s.Monitors()
+	if err != nil {
+		report.DetectError = err.Error()
+	}
+
+	for _, m := range monitors {
+		mr := monitorReport{
+			ID:           m.ID,
+			Name:         m.Name,
+			Connector:    m.Connector,
+			UUID:         m.UUID,
+			Main:         m.Main,
+			Asleep:       ddc.MonitorAsleep(m),
+			CurrentInput: m.CurrentInput,
+			Inputs:       m.Inputs,
+			Support:      make(map[string]string),
+			Values:       make(map[string]uint16),
+			DDCSupported: m.DDCSupported,
+			DDCTool:      m.DDCTool,
+			Validation:   m.Validation,
+		}
+
+		if mr.Asleep {
+			report.Monitors = append(report.Monitors, mr)
+			continue
+		}
+		for _, f := range ddc.BuildSupportMatrix(s.client, m) {
+			mr.Support[f.Feature] = string(f.Level)
+			if f.Level == ddc.SupportNo {
+				continue
+			}
+			if value, err := s.client.GetVCP(m.ID, f.Code); err == nil {
+				mr.Values[f.Feature] = value
+				if name, ok := mccs.ValueName(f.Code, value); ok {
+					if mr.ValueNames == nil {
+						mr.ValueNames = make(map[string]string)
+					}
+					mr.ValueNames[f.Feature] = name
+				}
+			}
+		}
+		report.Monitors = append(report.Monitors, mr)
+	}
+	return report
+}
+
+func printReport(r *setupReport) {
+	fmt.Printf("Operating System: %s\n", formatOS(r.OS))
+	fmt.Printf("Architecture: %s\n", r.Arch)
+	if r.Compositor != "" {
+		fmt.Printf("Compositor: %s\n", r.Compositor)
+	}
+	printGPUs(r.GPUs)
+	if r.DDCSupported {
+		fmt.Printf("✓ DDC/CI Support: %s\n", r.DDCMessage)
+	} else {
+		fmt.Printf("✗ DDC/CI Support: %s\n", r.DDCMessage)
+	}
+	printSession(r.Session, r.Advice)
+	printVirtualization(r.Virtualized)
+	if r.Backend != "" {
+		fmt.Printf("Backend: %s\n", r.Backend)
+	}
+	if r.DisplayCount > 0 {
+		fmt.Printf("Connected displays: %d\n", r.DisplayCount)
+	}
+	if r.DetectError != "" {
+		fmt.Printf("x Monitor Detection Failed: %s\n", r.DetectError)
+	}
+
+	fmt.Printf("\nFound %d monitors\n", len(r.Monitors))
+	for i, m := range r.Monitors {
+		fmt.Printf("- Monitor %d: %s (ID: %s)\n", i+1, m.Name, m.ID)
+		if m.Connector != "" {
+			fmt.Printf("  Connector: %s\n", m.Connector)
+		}
+		if m.UUID != "" {
+			fmt.Printf("  UUID: %s\n", m.UUID)
+		}
+		if m.CurrentInput != "" {
+			fmt.Printf("  Current input: %s\n", m.CurrentInput)
+		}
+		if m.Asleep {
+			fmt.Println("  State: asleep")
+		}
+		printDDCSupport(m.DDCSupported, m.DDCTool, m.Asleep)
+		printValidation(m.Validation)
+		for _, feature := range sortedKeys(m.Support) {
+			if name, ok := m.ValueNames[feature]; ok {
+				fmt.Printf("  %s: %s (current %d, %s)\n", feature, m.Support[feature], m.Values[feature], name)
+			} else if value, ok := m.Values[feature]; ok {
+				fmt.Printf("  %s: %s (current %d)\n", feature, m.Support[feature], value)
+			} else {
+				fmt.Printf("  %s: %s\n", feature, m.Support[feature])
+			}
+		}
+	}
+}
+
+// printDDCSupport prints how the monitor is reached, or that it can't be
+func printDDCSupport(supported bool, tool string, asleep bool) {
+	switch {
+	case supported && tool != "":
+		fmt.Printf("  DDC/CI: via %s\n", tool)
+	case !supported && tool != "":
+		fmt.Printf("  DDC/CI: no; controlled via %s\n", tool)
+	case !supported && !asleep:
+		fmt.Println("  ⚠ DDC/CI: no answer; this output is only listed and can't be controlled")
+	}
+}
+
+// printValidation prints how well a validated monitor supports DDC/CI
+func printValidation(v *ddc.DDCValidationResult) {
+	if v == nil {
+		return
+	}
+	switch {
+	case !v.CanReadValues:
+		fmt.Printf("  x DDC/CI: %v\n", v.ValidationError)
+	case !v.CanWriteValues:
+		fmt.Printf("  ⚠ DDC/CI: limited support - %v\n", v.ValidationError)
+	default:
+		fmt.Println("  ✓ DDC/CI: full support")
+		return
+	}
+	if v.RecommendedAction != "" {
+		fmt.Printf("  💡 Suggestion: %s\n", v.RecommendedAction)
+	}
+}
+
+// printSession prints the desktop session and what it means for detection
+func printSession(s *ddc.Session, advice []string) {
+	if s == nil {
+		return
+	}
+	fmt.Printf("Session: %s\n", s)
+	for _, a := range advice {
+		fmt.Printf("⚠ %s\n", a)
+	}
+}
+
+func printVirtualization(v *ddc.Virtualization) {
+	if v != nil {
+		fmt.Printf("Virtualization: %s\n", v)
+	}
+}
+
+// printGPUs prints one line per graphics adapter
+func printGPUs(gpus []ddc.GPU) {
+	for _, g := range gpus {
+		line := fmt.Sprintf("GPU: %s %s", g.Vendor, g.Model)
+		if g.Driver != "" {
+			line += fmt.Sprintf(" (driver %s", g.Driver)
+			if g.DriverVersion != "" {
+				line += " " + g.DriverVersion
+			}
+			line += ")"
+		}
+		fmt.Println(line)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&reportSample, "contribute-sample", "", "save anonymized raw tool outputs under this directory instead of reporting")
+	reportCmd.Flags().Lookup("contribute-sample").NoOptDefVal = "."
+	reportCmd.Flags().BoolVar(&reportJSON, "json", false, "print the report as JSON (same as --output json)")
+	rootCmd.AddCommand(reportCmd)
+}
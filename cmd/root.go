@@ -7,20 +7,48 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// version is set at build time with -ldflags "-X github.com/sibteali786/monitorswitch/cmd.version=..."
+var version = "dev"
+
 var (
-	verbose bool
+	verbose   bool
+	noCoexist bool
+	noCache   bool
+	readOnly  bool
+	force     bool
+
+	injectFaults string
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "monitorswitch [command]",
-	Short: "A cross-platform monitor control tool",
+	Use:     "monitorswitch [command]",
+	Version: version,
+	Short:   "A cross-platform monitor control tool",
 	Long: `MonitorSwitch allows you to control monitor settings like input switching,
 brightness, and contrast across Linux, macOS, and Windows using DDC/CI protocol.`,
+	// Execute reports errors itself, and usage only helps for invalid invocations
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if inBatch {
+			return checkBatchFlags(cmd.Root().PersistentFlags())
+		}
+		if configErr != nil {
+			return configErr
+		}
+		if err := setupLogging(); err != nil {
+			return err
+		}
+		warnPendingJournal(cmd)
+		return nil
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
-	// Your code here - what should happen if command execution fails?
+	runRemoteIfRequested(os.Args[1:])
+	runPluginIfRequested(os.Args[1:])
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -29,6 +57,15 @@ func Execute() {
 }
 
 func init() {
-	// This is where you'll add global flags later
+	cobra.OnInitialize(initConfig)
+
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file (default <config dir>/monitorswitch/config.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolVar(&noCoexist, "no-coexist", false, "don't route brightness through other running brightness tools (Windows)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "read capabilities from the monitors instead of the cache from earlier runs")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "output format: text or json")
+	rootCmd.PersistentFlags().BoolVar(&force, "force", false, "skip safety checks: capability validation, unsafe VCP codes, switching away the last display")
+	rootCmd.PersistentFlags().StringVar(&injectFaults, "inject-faults", os.Getenv("MONITORSWITCH_INJECT_FAULTS"), "developer: fail or delay backend calls, e.g. rate=0.2,latency=300ms[,writes] (also MONITORSWITCH_INJECT_FAULTS)")
+	rootCmd.PersistentFlags().MarkHidden("inject-faults")
+	rootCmd.PersistentFlags().BoolVar(&readOnly, "read-only", os.Getenv("MONITORSWITCH_READ_ONLY") != "", "only observe monitors; refuse every write (also MONITORSWITCH_READ_ONLY=1)")
 }
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sampleCommand is one tool invocation captured by --contribute-sample
+type sampleCommand struct {
+	file string
+	name string
+	args []string
+}
+
+// sampleRedactions blank out values that identify the machine or its owner
+var sampleRedactions = []*regexp.Regexp{
+	regexp.MustCompile(`(?im)(\b(?:serial number|serial|serialnumber|sn)\s*[:=]\s*"?)[^"\r\n,]+`),
+	regexp.MustCompile(`(?m)("_spdisplays_display-serial-number"\s*:\s*")[^"]*`),
+	regexp.MustCompile(`(?im)((?:host name|registered owner|registered organization|product id|domain|logon server)\s*:\s*)[^\r\n]+`),
+}
+
+// collectSample runs the external tools the parsers read on this OS and saves
+// their raw, anonymized output under dir for attaching to a bug report
+func collectSample(dir string) error {
+	dir = filepath.Join(dir, "monitorswitch-sample-"+time.Now().Format("20060102-150405"))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	fmt.Printf("Collecting tool outputs into %s\n", dir)
+	for _, c := range sampleCommands() {
+		output, err := runSampleCommand(c)
+		if err != nil {
+			fmt.Printf("  - %s: %v\n", c.file, err)
+			if len(output) == 0 {
+				continue
+			}
+		} else {
+			fmt.Printf("  ✓ %s\n", c.file)
+		}
+
+		header := fmt.Sprintf("$ %s\n", strings.Join(append([]string{c.name}, c.args...), " "))
+		if c.name == "" {
+			header = fmt.Sprintf("# %s\n", c.args[0])
+		}
+		if err := os.WriteFile(filepath.Join(dir, c.file), []byte(header+anonymizeSample(output)), 0o644); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("\nSerial numbers, host and user names were removed. Please review the files,")
+	fmt.Println("then attach them to an issue so these outputs can join the parser corpus.")
+	return nil
+}
+
+func runSampleCommand(c sampleCommand) (string, error) {
+	if c.name == "" {
+		data, err := os.ReadFile(c.args[0])
+		return string(data), err
+	}
+	if _, err := exec.LookPath(c.name); err != nil {
+		return "", fmt.Errorf("%s not installed", c.name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, c.name, c.args...).CombinedOutput()
+	return string(output), err
+}
+
+// sampleCommands lists what to capture for the current OS. Per-display
+// commands are expanded from the tool's own detection output.
+func sampleCommands() []sampleCommand {
+	readFile := func(file, path string) sampleCommand {
+		return sampleCommand{file: file, args: []string{path}}
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		return []sampleCommand{
+			{"systeminfo.txt", "systeminfo", nil},
+			{"wmic-os.txt", "wmic", []string{"os", "get", "Caption,Version,BuildNumber,OSArchitecture", "/value"}},
+			{"wmic-desktopmonitor.txt", "wmic", []string{"path", "Win32_DesktopMonitor", "get", "/format:list"}},
+			{"cim-wmimonitorid.txt", "powershell", []string{"-NoProfile", "-Command", "Get-CimInstance -Namespace root\\wmi -ClassName WmiMonitorID | Format-List *"}},
+			{"cim-videocontroller.txt", "powershell", []string{"-NoProfile", "-Command", "Get-CimInstance -ClassName Win32_VideoController | Format-List *"}},
+		}
+	case "darwin":
+		commands := []sampleCommand{
+			{"sw_vers.txt", "sw_vers", nil},
+			readFile("SystemVersion.plist", "/System/Library/CoreServices/SystemVersion.plist"),
+			{"system_profiler-displays.json", "system_profiler", []string{"SPDisplaysDataType", "-json"}},
+			{"m1ddc-version.txt", "m1ddc", []string{"version"}},
+			{"m1ddc-display-list.txt", "m1ddc", []string{"display", "list"}},
+		}
+		for _, n := range sampleDisplayNumbers("m1ddc", []string{"display", "list"}, `(?m)^\[(\d+)\]`) {
+			for _, feature := range []string{"input", "luminance", "contrast", "volume"} {
+				commands = append(commands, sampleCommand{fmt.Sprintf("m1ddc-display%s-get-%s.txt", n, feature), "m1ddc", []string{"display", n, "get", feature}})
+			}
+		}
+		if _, err := exec.LookPath("ddcctl"); err == nil {
+			for _, flag := range []string{"b", "c", "i", "v"} {
+				commands = append(commands, sampleCommand{"ddcctl-d1-" + flag + ".txt", "ddcctl", []string{"-d", "1", "-" + flag, "?"}})
+			}
+		}
+		return commands
+	default:
+		commands := []sampleCommand{
+			readFile("os-release.txt", "/etc/os-release"),
+			readFile("lsb-release.txt", "/etc/lsb-release"),
+			{"ddcutil-version.txt", "ddcutil", []string{"--version"}},
+			{"ddcutil-detect.txt", "ddcutil", []string{"detect"}},
+			{"xrandr-listmonitors.txt", "xrandr", []string{"--listmonitors"}},
+			{"lspci-vga.txt", "lspci", []string{"-mm", "-d", "::0300"}},
+			{"nvidia-smi.txt", "nvidia-smi", []string{"--query-gpu=name,driver_version", "--format=csv,noheader"}},
+		}
+		for _, n := range sampleDisplayNumbers("ddcutil", []string{"detect"}, `(?m)^Display (\d+)`) {
+			commands = append(commands,
+				sampleCommand{"ddcutil-capabilities-display" + n + ".txt", "ddcutil", []string{"--display", n, "capabilities"}},
+				sampleCommand{"ddcutil-getvcp60-display" + n + ".txt", "ddcutil", []string{"--display", n, "getvcp", "60"}},
+				sampleCommand{"ddcutil-getvcp10-display" + n + ".txt", "ddcutil", []string{"--display", n, "getvcp", "10"}},
+			)
+		}
+		return commands
+	}
+}
+
+// sampleDisplayNumbers runs a tool's detection and returns the display
+// numbers matched by pattern
+func sampleDisplayNumbers(name string, args []string, pattern string) []string {
+	output, err := runSampleCommand(sampleCommand{name: name, args: args})
+	if err != nil {
+		return nil
+	}
+	var numbers []string
+	for _, m := range regexp.MustCompile(pattern).FindAllStringSubmatch(output, -1) {
+		if _, err := strconv.Atoi(m[1]); err == nil {
+			numbers = append(numbers, m[1])
+		}
+	}
+	return numbers
+}
+
+// anonymizeSample removes serial numbers and the host and user names
+func anonymizeSample(output string) string {
+	for _, re := range sampleRedactions {
+		output = re.ReplaceAllString(output, "${1}REDACTED")
+	}
+
+	if host, err := os.Hostname(); err == nil && len(host) > 2 {
+		output = strings.ReplaceAll(output, host, "HOSTNAME")
+	}
+	if u, err := user.Current(); err == nil && len(u.Username) > 2 {
+		name := u.Username
+		if i := strings.LastIndexAny(name, `\`); i >= 0 {
+			name = name[i+1:]
+		}
+		output = strings.ReplaceAll(output, name, "USER")
+	}
+	return output
+}
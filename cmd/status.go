@@ -2,26 +2,75 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"text/tabwriter"
 
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/service"
 	"github.com/spf13/cobra"
 )
 
-// RootCmd is the root command for the CLI application.
+var statusMonitor string
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Get the current status of the monitor",
-	Long:  "Retrieve the current status of the monitor, including input source, brightness, and other settings.",
-	Run: func(cmd *cobra.Command, args []string) {
-		// Your implementation here
-		// For now, we will just print a placeholder message
-		if verbose {
-			fmt.Println("Verbose mode enabled: Displaying detailed monitor status...")
-		} else {
-			fmt.Println("Current input: HDMI")
+	Long:  "Retrieve the current status of each monitor: input source, brightness, contrast and volume.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := newService()
+		if err != nil {
+			return err
+		}
+
+		result, err := svc.Status(statusMonitor)
+		if err != nil {
+			return err
 		}
+		return render(result, func() { printStatus(result) })
 	},
 }
 
+func printStatus(r *service.StatusResult) {
+	if len(r.Monitors) == 0 {
+		fmt.Println("No DDC/CI compatible monitors detected")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tINPUT\tBRIGHTNESS\tCONTRAST\tVOLUME")
+	for _, m := range r.Monitors {
+		if m.Asleep {
+			fmt.Fprintf(w, "%s\t%s\tasleep\t-\t-\t-\n", m.ID, m.Name)
+			continue
+		}
+		input := "?"
+		if m.InputCode != nil {
+			input = describeInput(m.Input, m.InputLabel)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", m.ID, m.Name, input,
+			formatLevel(m.Brightness), formatLevel(m.Contrast), formatLevel(m.Volume))
+	}
+	w.Flush()
+
+	if verbose {
+		for _, m := range r.Monitors {
+			for feature, msg := range m.Errors {
+				fmt.Printf("[VERBOSE] Monitor %s: could not read %s: %s\n", m.ID, feature, msg)
+			}
+		}
+	}
+}
+
+// formatLevel shows a continuous value as "current/max", or "?" if unread
+func formatLevel(v *ddc.VCPValue) string {
+	if v == nil {
+		return "?"
+	}
+	return fmt.Sprintf("%d/%d", v.Current, v.MaxOr(100))
+}
+
 func init() {
+	statusCmd.Flags().StringVarP(&statusMonitor, "monitor", "m", "", "only show this monitor")
 	rootCmd.AddCommand(statusCmd)
 }
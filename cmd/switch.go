@@ -2,28 +2,224 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/sibteali786/monitorswitch/internal/config"
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/display"
+	"github.com/sibteali786/monitorswitch/internal/service"
+	"github.com/sibteali786/monitorswitch/pkg/monitorswitch"
 	"github.com/spf13/cobra"
 )
 
+var (
+	switchMonitor     string
+	switchRevertAfter time.Duration
+	switchAudio       string
+	switchNoAudio     bool
+	switchAll         bool
+	switchOrder       []string
+	switchDelay       time.Duration
+)
+
+// defaultSwitchDelay gives monitors that briefly drop DDC/CI on the other
+// displays while changing input time to recover
+const defaultSwitchDelay = time.Second
+
 var switchCmd = &cobra.Command{
 	Use:   "switch [input]",
 	Short: "Switch monitor input",
-	Long:  "Switch the monitor to a specified input (hdmi, usb-c, etc.)",
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		// Your implementation here
-		input := args[0]
-		// TODO: Actual switching logic will come later
-		if verbose {
-			fmt.Println("Verbose mode enabled: Switching monitor input...")
+	Long: `Switch the monitor to a specified input (hdmi, usb-c, etc.)
+
+Switching the only display attached to this machine away would leave you with
+no screen to see the result on, so it requires --force or --revert-after.
+Inputs the monitor doesn't advertise are refused unless --force is given. With
+--revert-after the previous input is restored automatically unless the switch
+is acknowledged with 'monitorswitch confirm' in time.
+
+With --all every monitor switches to the input, e.g. to hand a multi-monitor
+desk to another machine behind a KVM. Monitors named with --order go first;
+--delay pauses between monitors. Both default to switch_all in the config file.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if switchAll {
+			return runSwitchAll(cmd, args[0])
+		}
+
+		if !force && switchRevertAfter == 0 {
+			if err := checkNotLastDisplay(1); err != nil {
+				return err
+			}
+		}
+
+		svc, err := newService()
+		if err != nil {
+			return err
+		}
+
+		req := service.SwitchRequest{
+			MonitorID:    switchMonitor,
+			Input:        args[0],
+			ReadPrevious: switchRevertAfter > 0,
+			AudioDevice:  switchAudio,
+			SkipAudio:    switchNoAudio,
+		}
+		var result *service.SwitchResult
+		if showPlan {
+			result, err = switchWithPlan(svc, req)
 		} else {
-			fmt.Printf("Switching to input: %s\n", input)
+			result, err = svc.Switch(req)
+		}
+		if err != nil || result == nil {
+			return err
+		}
 
+		if switchRevertAfter > 0 {
+			token, err := scheduleRevert(result.MonitorID, ddc.VCPInputSource, result.Previous, switchRevertAfter)
+			if err != nil {
+				return fmt.Errorf("switched, but failed to schedule the revert: %w", err)
+			}
+			result.RevertToken = token
+			result.RevertAfter = switchRevertAfter.String()
 		}
+
+		return render(result, func() {
+			fmt.Printf("✓ Monitor %s (%s): switched to %s (0x%02X)\n", result.MonitorID, result.MonitorName, result.Input, result.Code)
+			if result.AudioError != "" {
+				fmt.Printf("⚠ Audio output not changed: %s\n", result.AudioError)
+			} else if result.AudioDevice != "" {
+				fmt.Printf("✓ Audio output: %s\n", result.AudioDevice)
+			}
+			if result.RevertToken != "" {
+				fmt.Printf("  Reverting in %s unless confirmed: monitorswitch confirm %s\n", result.RevertAfter, result.RevertToken)
+			}
+		})
 	},
 }
 
+func runSwitchAll(cmd *cobra.Command, input string) error {
+	if switchMonitor != "" {
+		return fmt.Errorf("--monitor and --all are mutually exclusive")
+	}
+	if switchRevertAfter > 0 {
+		return fmt.Errorf("--revert-after can't be combined with --all")
+	}
+	if showPlan {
+		return fmt.Errorf("--plan can't be combined with --all")
+	}
+
+	cfg := config.Get().SwitchAll
+	if !cmd.Flags().Changed("order") {
+		switchOrder = cfg.Order
+	}
+	if !cmd.Flags().Changed("delay") && cfg.Delay > 0 {
+		switchDelay = cfg.Delay
+	}
+
+	svc, err := newService()
+	if err != nil {
+		return err
+	}
+	if !force {
+		monitors, err := svc.Monitors()
+		if err != nil {
+			return fmt.Errorf("monitor detection failed: %w", err)
+		}
+		if err := checkNotLastDisplay(len(service.DDCMonitors(monitors))); err != nil {
+			return err
+		}
+	}
+
+	result, err := svc.SwitchAll(service.SwitchAllRequest{
+		Input:       input,
+		Order:       switchOrder,
+		Delay:       switchDelay,
+		AudioDevice: switchAudio,
+		SkipAudio:   switchNoAudio,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := render(result, func() {
+		for _, m := range result.Skipped {
+			fmt.Printf("- Monitor %s (%s): skipped, doesn't answer DDC/CI\n", m.ID, m.Name)
+		}
+		for _, m := range result.Monitors {
+			if m.Error != "" {
+				fmt.Printf("x Monitor %s (%s): %s\n", m.ID, m.Name, m.Error)
+			} else {
+				fmt.Printf("✓ Monitor %s (%s): switched to %s (0x%02X)\n", m.ID, m.Name, m.Input, m.Code)
+			}
+		}
+		if result.AudioError != "" {
+			fmt.Printf("⚠ Audio output not changed: %s\n", result.AudioError)
+		} else if result.AudioDevice != "" {
+			fmt.Printf("✓ Audio output: %s\n", result.AudioDevice)
+		}
+		fmt.Printf("%d of %d monitors switched to %s\n", len(result.Monitors)-result.Failed(), len(result.Monitors), input)
+	}); err != nil {
+		return err
+	}
+	if n := result.Failed(); n > 0 {
+		return fmt.Errorf("switch failed on %d of %d monitors", n, len(result.Monitors))
+	}
+	return nil
+}
+
+// switchWithPlan shows the write a switch resolves to and makes exactly that
+// write once confirmed. The result is nil if it wasn't.
+func switchWithPlan(svc *service.Service, req service.SwitchRequest) (*service.SwitchResult, error) {
+	target, input, code, err := svc.ResolveSwitch(req)
+	if err != nil {
+		return nil, err
+	}
+	s, err := getSession()
+	if err != nil {
+		return nil, err
+	}
+	plan, err := s.PlanSettings([]monitorswitch.Setting{{MonitorID: target.ID, Code: ddc.VCPInputSource, Value: uint16(code)}})
+	if err != nil {
+		return nil, err
+	}
+	plan.Name = "switch"
+	if ok, err := confirmPlan(s, plan); !ok || err != nil {
+		return nil, err
+	}
+
+	return svc.CompleteSwitch(req, target, input, code, func() error {
+		started := time.Now()
+		_, err := s.Commit(plan)
+		return withRecoverHint(started, err)
+	})
+}
+
+// checkNotLastDisplay refuses to switch away every display this machine can
+// show output on. If the display count can't be determined we don't block.
+func checkNotLastDisplay(switching int) error {
+	active, err := display.ActiveDisplayCount()
+	if err != nil {
+		if verbose {
+			fmt.Printf("[VERBOSE] Could not count active displays: %v\n", err)
+		}
+		return nil
+	}
+
+	if active-switching < 1 {
+		return fmt.Errorf("this would switch away the only display attached to this machine; " +
+			"re-run with --force if you really want to do that")
+	}
+	return nil
+}
+
 func init() {
+	switchCmd.Flags().StringVarP(&switchMonitor, "monitor", "m", "", "monitor ID to switch (required with several monitors unless --all)")
+	switchCmd.Flags().DurationVar(&switchRevertAfter, "revert-after", 0, "restore the previous input after this long unless confirmed")
+	switchCmd.Flags().StringVar(&switchAudio, "audio", "", "audio output to select after switching (overrides 'audio link')")
+	switchCmd.Flags().BoolVar(&switchNoAudio, "no-audio", false, "don't change the audio output")
+	switchCmd.Flags().BoolVar(&switchAll, "all", false, "switch every detected monitor")
+	switchCmd.Flags().StringSliceVar(&switchOrder, "order", nil, "with --all, monitor IDs or aliases to switch first, e.g. 2,1")
+	switchCmd.Flags().DurationVar(&switchDelay, "delay", defaultSwitchDelay, "with --all, pause between monitors")
+	switchCmd.Flags().BoolVar(&showPlan, "plan", false, "show the write and ask before switching (with -o json, only print it)")
 	rootCmd.AddCommand(switchCmd)
 }
@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Control monitors interactively",
+	Long: `Show every detected monitor with its input, brightness and contrast, and
+change them from the keyboard.
+
+  ↑/↓ or k/j   choose a monitor
+  tab          choose input, brightness or contrast
+  ←/→ or h/l   pick an input, or move a slider by 5% (H/L: 1%)
+  enter        switch to the picked input
+  r            re-read the selected monitor
+  q            quit
+
+Sliders are written as you move them; reads run in the background so the
+screen stays responsive while monitors answer.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := newService()
+		if err != nil {
+			return err
+		}
+		// State errors would be printed over the screen
+		svc.StateError = nil
+
+		_, err = tea.NewProgram(newTUIModel(svc), tea.WithAltScreen()).Run()
+		return err
+	},
+}
+
+// tuiField is the control the cursor is on within a monitor
+type tuiField int
+
+const (
+	fieldInput tuiField = iota
+	fieldBrightness
+	fieldContrast
+	fieldCount
+)
+
+// tuiSlider is a continuous feature shown as a slider. Moves update Percent
+// immediately; Pending is written once the previous write finishes, so
+// holding a key doesn't queue up dozens of DDC/CI writes.
+type tuiSlider struct {
+	Code    byte
+	Feature string
+	Percent int
+	Loaded  bool
+	Writing bool
+	Pending bool
+	Err     string
+}
+
+// tuiMonitor is one row of the TUI
+type tuiMonitor struct {
+	Monitor    ddc.Monitor
+	Inputs     []string // Sorted input names
+	Picked     int      // Index into Inputs the arrows have moved to
+	Current    string   // Active input, as last read
+	Brightness tuiSlider
+	Contrast   tuiSlider
+	Status     string
+}
+
+type tuiModel struct {
+	svc      *service.Service
+	monitors []*tuiMonitor
+	cursor   int
+	field    tuiField
+	loading  bool
+	err      error
+}
+
+// Messages delivered by the background commands
+type (
+	tuiDetectedMsg struct {
+		monitors []ddc.Monitor
+		err      error
+	}
+	tuiLevelMsg struct {
+		index   int
+		code    byte
+		percent int
+		write   bool
+		err     error
+	}
+	tuiInputMsg struct {
+		index int
+		input string
+		err   error
+	}
+)
+
+func newTUIModel(svc *service.Service) *tuiModel {
+	return &tuiModel{svc: svc, loading: true}
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return func() tea.Msg {
+		monitors, err := m.svc.Monitors()
+		return tuiDetectedMsg{monitors: monitors, err: err}
+	}
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tuiDetectedMsg:
+		m.loading, m.err = false, msg.err
+		var cmds []tea.Cmd
+		for i, dm := range msg.monitors {
+			row := &tuiMonitor{
+				Monitor:    dm,
+				Current:    dm.CurrentInput,
+				Brightness: tuiSlider{Code: ddc.VCPBrightness, Feature: "brightness"},
+				Contrast:   tuiSlider{Code: ddc.VCPContrast, Feature: "contrast"},
+			}
+			for name := range dm.Inputs {
+				row.Inputs = append(row.Inputs, name)
+			}
+			sort.Strings(row.Inputs)
+			for j, name := range row.Inputs {
+				if strings.EqualFold(name, dm.CurrentInput) {
+					row.Picked = j
+				}
+			}
+			m.monitors = append(m.monitors, row)
+			if !ddc.MonitorAsleep(dm) {
+				cmds = append(cmds, m.readLevel(i, ddc.VCPBrightness), m.readLevel(i, ddc.VCPContrast))
+			}
+		}
+		return m, tea.Batch(cmds...)
+
+	case tuiLevelMsg:
+		row := m.monitors[msg.index]
+		s := row.slider(msg.code)
+		if !msg.write {
+			s.Loaded = msg.err == nil
+			if msg.err != nil {
+				s.Err = msg.err.Error()
+			} else {
+				s.Percent, s.Err = msg.percent, ""
+			}
+			return m, nil
+		}
+
+		s.Writing = false
+		if msg.err != nil {
+			s.Err = msg.err.Error()
+		} else {
+			s.Err = ""
+		}
+		if s.Pending {
+			return m, m.writeLevel(msg.index, s)
+		}
+		return m, nil
+
+	case tuiInputMsg:
+		row := m.monitors[msg.index]
+		if msg.err != nil {
+			row.Status = "x " + msg.err.Error()
+		} else {
+			row.Current = msg.input
+			row.Status = "✓ Switched to " + msg.input
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *tuiModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "ctrl+c":
+		return m, tea.Quit
+	}
+	if len(m.monitors) == 0 {
+		return m, nil
+	}
+
+	row := m.monitors[m.cursor]
+	switch msg.String() {
+	case "up", "k":
+		m.cursor = (m.cursor + len(m.monitors) - 1) % len(m.monitors)
+	case "down", "j":
+		m.cursor = (m.cursor + 1) % len(m.monitors)
+	case "tab":
+		m.field = (m.field + 1) % fieldCount
+	case "shift+tab":
+		m.field = (m.field + fieldCount - 1) % fieldCount
+	case "left", "h":
+		return m, m.adjust(row, -5)
+	case "right", "l":
+		return m, m.adjust(row, 5)
+	case "H":
+		return m, m.adjust(row, -1)
+	case "L":
+		return m, m.adjust(row, 1)
+	case "enter":
+		if m.field == fieldInput && len(row.Inputs) > 0 {
+			row.Status = "Switching..."
+			return m, m.switchInput(m.cursor, row.Inputs[row.Picked])
+		}
+	case "r":
+		row.Status = ""
+		return m, tea.Batch(m.readLevel(m.cursor, ddc.VCPBrightness), m.readLevel(m.cursor, ddc.VCPContrast))
+	}
+	return m, nil
+}
+
+// adjust moves the picked input or the focused slider by delta
+func (m *tuiModel) adjust(row *tuiMonitor, delta int) tea.Cmd {
+	if m.field == fieldInput {
+		if n := len(row.Inputs); n > 0 {
+			step := 1
+			if delta < 0 {
+				step = n - 1
+			}
+			row.Picked = (row.Picked + step) % n
+		}
+		return nil
+	}
+
+	s := row.slider(ddc.VCPBrightness)
+	if m.field == fieldContrast {
+		s = row.slider(ddc.VCPContrast)
+	}
+	if !s.Loaded {
+		return nil
+	}
+	s.Percent = min(max(s.Percent+delta, 0), 100)
+	s.Pending = true
+	if s.Writing {
+		return nil
+	}
+	return m.writeLevel(m.cursor, s)
+}
+
+func (r *tuiMonitor) slider(code byte) *tuiSlider {
+	if code == ddc.VCPContrast {
+		return &r.Contrast
+	}
+	return &r.Brightness
+}
+
+func (m *tuiModel) readLevel(index int, code byte) tea.Cmd {
+	id := m.monitors[index].Monitor.ID
+	return func() tea.Msg {
+		value, err := m.svc.Client.GetVCPValue(id, code)
+		return tuiLevelMsg{index: index, code: code, percent: ddc.RawToPercent(value.Current, value.Max), err: err}
+	}
+}
+
+func (m *tuiModel) writeLevel(index int, s *tuiSlider) tea.Cmd {
+	s.Writing, s.Pending = true, false
+	req := service.LevelRequest{
+		Feature:   s.Feature,
+		Code:      s.Code,
+		MonitorID: m.monitors[index].Monitor.ID,
+		Set:       true,
+		Percent:   s.Percent,
+	}
+	return func() tea.Msg {
+		msg := tuiLevelMsg{index: index, code: req.Code, write: true}
+		result, err := m.svc.Level(req)
+		if err == nil && result.Failed() > 0 {
+			err = fmt.Errorf("%s", result.Monitors[0].Error)
+		}
+		msg.err = err
+		return msg
+	}
+}
+
+func (m *tuiModel) switchInput(index int, input string) tea.Cmd {
+	id := m.monitors[index].Monitor.ID
+	return func() tea.Msg {
+		_, err := m.svc.Switch(service.SwitchRequest{MonitorID: id, Input: input})
+		return tuiInputMsg{index: index, input: input, err: err}
+	}
+}
+
+func (m *tuiModel) View() string {
+	var b strings.Builder
+	b.WriteString("MonitorSwitch\n\n")
+
+	if m.loading {
+		b.WriteString("Detecting monitors...\n")
+		return b.String()
+	}
+	if m.err != nil {
+		fmt.Fprintf(&b, "x Monitor Detection Failed: %v\n", m.err)
+	}
+	if len(m.monitors) == 0 {
+		b.WriteString("No DDC/CI compatible monitors detected\n\nq quit\n")
+		return b.String()
+	}
+
+	for i, row := range m.monitors {
+		selected := i == m.cursor
+		marker := "  "
+		if selected {
+			marker = "▶ "
+		}
+		fmt.Fprintf(&b, "%s%s (ID: %s)\n", marker, row.Monitor.Name, row.Monitor.ID)
+		if ddc.MonitorAsleep(row.Monitor) {
+			b.WriteString("    asleep\n\n")
+			continue
+		}
+
+		input := row.Current
+		if input == "" {
+			input = "unknown"
+		}
+		if selected && len(row.Inputs) > 0 && row.Inputs[row.Picked] != row.Current {
+			input += fmt.Sprintf(" → %s (enter to switch)", row.Inputs[row.Picked])
+		}
+		b.WriteString(tuiLine(selected && m.field == fieldInput, "Input", input))
+		b.WriteString(tuiLine(selected && m.field == fieldBrightness, "Brightness", row.Brightness.bar()))
+		b.WriteString(tuiLine(selected && m.field == fieldContrast, "Contrast", row.Contrast.bar()))
+		if row.Status != "" {
+			fmt.Fprintf(&b, "    %s\n", row.Status)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("↑/↓ monitor · tab control · ←/→ change · enter switch · r refresh · q quit\n")
+	return b.String()
+}
+
+func tuiLine(focused bool, label, value string) string {
+	cursor := " "
+	if focused {
+		cursor = ">"
+	}
+	return fmt.Sprintf("   %s %-11s %s\n", cursor, label+":", value)
+}
+
+// bar draws the slider, e.g. "[██████░░░░░░░░░░░░░░]  30%"
+func (s tuiSlider) bar() string {
+	switch {
+	case s.Err != "" && !s.Loaded:
+		return "unavailable (" + s.Err + ")"
+	case !s.Loaded:
+		return "reading..."
+	}
+
+	const width = 20
+	filled := s.Percent * width / 100
+	bar := fmt.Sprintf("[%s%s] %3d%%", strings.Repeat("█", filled), strings.Repeat("░", width-filled), s.Percent)
+	if s.Writing {
+		bar += " …"
+	}
+	if s.Err != "" {
+		bar += " x " + s.Err
+	}
+	return bar
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/state"
+	"github.com/spf13/cobra"
+)
+
+// tuneCandidates are the settle delays tried, fastest first
+var tuneCandidates = []time.Duration{
+	20 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	400 * time.Millisecond,
+	800 * time.Millisecond,
+	1500 * time.Millisecond,
+}
+
+// tuneMaxAttempts bounds the write attempts tune measures and recommends
+const tuneMaxAttempts = 5
+
+var (
+	tuneMonitor string
+	tuneRounds  int
+	tuneDryRun  bool
+)
+
+var tuneCmd = &cobra.Command{
+	Use:   "tune",
+	Short: "Find the fastest reliable DDC timing for each monitor",
+	Long: `Stress-test each monitor by nudging its brightness up and down and reading it
+back, starting with short settle delays and lengthening them until every round
+succeeds. At that delay it then counts how many attempts writes need to stick,
+keeping one in reserve. The results are written to quirks.json as the monitor's
+write_delay, verify_delay and attempts, keyed by its stable ID, and brightness is
+restored afterwards.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkWritable(); err != nil {
+			return err
+		}
+
+		// Time the backend directly: the session client adds its own settle
+		// delays, and the backend's retries and verification would hide the
+		// failures each trial is looking for
+		raw, err := newDetector().CreateDDCClient()
+		if err != nil {
+			return err
+		}
+		if r, ok := raw.(interface{ SetRetryPolicy(ddc.RetryPolicy) }); ok {
+			r.SetRetryPolicy(ddc.RetryPolicy{Attempts: 1})
+		}
+		client := ddc.NewSerializedClient(raw)
+
+		monitors, err := client.DetectMonitors()
+		if err != nil {
+			return fmt.Errorf("monitor detection failed: %w", err)
+		}
+		targets, err := selectMonitors(monitors, tuneMonitor)
+		if err != nil {
+			return err
+		}
+
+		quirks, err := ddc.LoadQuirks()
+		if err != nil {
+			return err
+		}
+
+		var tuned []ddc.Monitor
+		for _, m := range targets {
+			delay, attempts, err := tuneTiming(client, m)
+			if err != nil {
+				fmt.Printf("x Monitor %s (%s): %v\n", m.ID, m.Name, err)
+				continue
+			}
+			fmt.Printf("✓ Monitor %s (%s): settle delay %s, %d write attempts\n", m.ID, m.Name, delay, attempts)
+			quirks = setTuned(quirks, m, delay, attempts)
+			tuned = append(tuned, m)
+		}
+
+		if len(tuned) == 0 {
+			return fmt.Errorf("no monitor could be tuned")
+		}
+		if tuneDryRun {
+			return nil
+		}
+		if err := ddc.SaveQuirks(quirks); err != nil {
+			return err
+		}
+
+		// Tuned delays replace the ones learned from retries
+		updateState(func(st *state.State) {
+			for _, m := range tuned {
+				st.RecordSettleDelay(ddc.StableKey(m), 0)
+			}
+		})
+		fmt.Println("Saved to quirks.json")
+		return nil
+	},
+}
+
+// tuneTiming returns the shortest candidate delay at which every
+// write-then-read round trip succeeds, and the write attempts to allow at it
+func tuneTiming(client ddc.DDCClient, m ddc.Monitor) (time.Duration, int, error) {
+	original, err := client.GetVCPValue(m.ID, ddc.VCPBrightness)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot read brightness to test with: %w", err)
+	}
+	defer func() {
+		time.Sleep(tuneCandidates[len(tuneCandidates)-1])
+		client.SetVCP(m.ID, ddc.VCPBrightness, original.Current)
+	}()
+
+	// Alternate between two nearby values so the test is barely visible
+	low, high := original.Current, original.Current+1
+	if high > original.MaxOr(100) {
+		low, high = original.Current-1, original.Current
+	}
+
+	for _, delay := range tuneCandidates {
+		if verbose {
+			fmt.Printf("[VERBOSE] Monitor %s: trying %s\n", m.ID, delay)
+		}
+		if tuneRoundsPass(client, m.ID, delay, low, high) {
+			return delay, tuneAttempts(client, m.ID, delay, low, high), nil
+		}
+	}
+	return 0, 0, fmt.Errorf("no delay up to %s was reliable", tuneCandidates[len(tuneCandidates)-1])
+}
+
+// tuneAttempts writes repeatedly at delay, retrying each write until it
+// sticks, and returns the most attempts one needed plus one in reserve
+func tuneAttempts(client ddc.DDCClient, monitorID string, delay time.Duration, low, high uint16) int {
+	needed := 1
+	for i := 0; i < 2*tuneRounds; i++ {
+		want := low
+		if i%2 == 0 {
+			want = high
+		}
+		attempt := 1
+		for ; attempt < tuneMaxAttempts; attempt++ {
+			if client.SetVCP(monitorID, ddc.VCPBrightness, want) == nil {
+				time.Sleep(delay)
+				if got, err := client.GetVCP(monitorID, ddc.VCPBrightness); err == nil && got == want {
+					break
+				}
+			}
+			time.Sleep(delay)
+		}
+		needed = max(needed, attempt)
+		time.Sleep(delay)
+	}
+	if verbose {
+		fmt.Printf("[VERBOSE] Monitor %s: writes needed up to %d attempts at %s\n", monitorID, needed, delay)
+	}
+	return min(needed+1, tuneMaxAttempts)
+}
+
+func tuneRoundsPass(client ddc.DDCClient, monitorID string, delay time.Duration, low, high uint16) bool {
+	for i := 0; i < tuneRounds; i++ {
+		want := low
+		if i%2 == 0 {
+			want = high
+		}
+		if err := client.SetVCP(monitorID, ddc.VCPBrightness, want); err != nil {
+			return false
+		}
+		time.Sleep(delay)
+		got, err := client.GetVCP(monitorID, ddc.VCPBrightness)
+		if err != nil || got != want {
+			return false
+		}
+		time.Sleep(delay)
+	}
+	return true
+}
+
+// setTuned records the timing tuned for m under its stable key, reusing the
+// quirk entry tune made for it before if there is one
+func setTuned(quirks []ddc.Quirk, m ddc.Monitor, delay time.Duration, attempts int) []ddc.Quirk {
+	key := ddc.StableKey(m)
+	for i := range quirks {
+		if strings.EqualFold(quirks[i].StableID, key) {
+			quirks[i].WriteDelay = delay.String()
+			quirks[i].VerifyDelay = delay.String()
+			quirks[i].Attempts = attempts
+			return quirks
+		}
+	}
+	return append(quirks, ddc.Quirk{
+		Model:       m.Name,
+		StableID:    key,
+		Codes:       []string{},
+		Reason:      "tuned by 'monitorswitch tune'",
+		WriteDelay:  delay.String(),
+		VerifyDelay: delay.String(),
+		Attempts:    attempts,
+	})
+}
+
+func init() {
+	tuneCmd.Flags().StringVarP(&tuneMonitor, "monitor", "m", "", "only tune this monitor")
+	tuneCmd.Flags().IntVar(&tuneRounds, "rounds", 6, "write/read round trips each delay must pass")
+	tuneCmd.Flags().BoolVar(&tuneDryRun, "dry-run", false, "report the delays without saving them")
+	rootCmd.AddCommand(tuneCmd)
+}
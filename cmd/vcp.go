@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/mccs"
+	"github.com/sibteali786/monitorswitch/internal/state"
+	"github.com/sibteali786/monitorswitch/pkg/monitorswitch"
+	"github.com/spf13/cobra"
+)
+
+var (
+	vcpMonitor string
+)
+
+var vcpCmd = &cobra.Command{
+	Use:   "vcp",
+	Short: "Read or write raw VCP features",
+	Long:  "Read or write any VCP feature code directly. Codes and values accept decimal or 0x-prefixed hex.",
+}
+
+var vcpGetCmd = &cobra.Command{
+	Use:   "get [code]",
+	Short: "Read a VCP feature",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		code, err := parseVCPCode(args[0])
+		if err != nil {
+			return err
+		}
+
+		client, targets, err := vcpTargets()
+		if err != nil {
+			return err
+		}
+
+		if !mccs.Readable(code) {
+			return fmt.Errorf("VCP 0x%02X (%s) cannot be read with getvcp", code, mccs.Name(code))
+		}
+
+		for _, m := range targets {
+			value, err := client.GetVCPValue(m.ID, code)
+			if err != nil {
+				fmt.Printf("x Monitor %s (%s): VCP 0x%02X: %v\n", m.ID, m.Name, code, err)
+				continue
+			}
+
+			decoded := ddc.DecodeVCPValue(m, code, value.Current)
+			if value.Max > 0 {
+				decoded = fmt.Sprintf("%s of %d", decoded, value.Max)
+			}
+			fmt.Printf("Monitor %s (%s): VCP 0x%02X (%s) = %s [0x%04X]\n", m.ID, m.Name, code, mccs.Name(code), decoded, value.Current)
+		}
+		return nil
+	},
+}
+
+var vcpSetCmd = &cobra.Command{
+	Use:   "set [code] [value]",
+	Short: "Write a VCP feature",
+	Long: `Write a VCP feature. Codes that reset the monitor, vendor-specific codes
+(0xE0-0xFF), codes listed in quirks.json in the config directory and codes the
+monitor doesn't advertise are refused unless --force is given, since some
+monitors misbehave or crash on them.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		code, err := parseVCPCode(args[0])
+		if err != nil {
+			return err
+		}
+		value, err := strconv.ParseUint(args[1], 0, 16)
+		if err != nil {
+			return fmt.Errorf("invalid VCP value %q: %w", args[1], err)
+		}
+
+		if !mccs.Writable(code) && !force {
+			return fmt.Errorf("VCP 0x%02X (%s) is not writable per MCCS; re-run with --force to write it anyway", code, mccs.Name(code))
+		}
+
+		client, targets, err := vcpTargets()
+		if err != nil {
+			return err
+		}
+
+		for _, m := range targets {
+			if reason, unsafe := ddc.UnsafeVCPReason(m, code); unsafe && !force {
+				return fmt.Errorf("monitor %s (%s): %s; re-run with --force to write it anyway", m.ID, m.Name, reason)
+			}
+		}
+
+		if showPlan {
+			s, err := getSession()
+			if err != nil {
+				return err
+			}
+			var settings []monitorswitch.Setting
+			for _, m := range targets {
+				settings = append(settings, monitorswitch.Setting{MonitorID: m.ID, Code: code, Value: uint16(value)})
+			}
+			plan, err := s.PlanSettings(settings)
+			if err != nil {
+				return err
+			}
+			if ok, err := confirmPlan(s, plan); !ok || err != nil {
+				return err
+			}
+			started := time.Now()
+			ops, err := s.Commit(plan)
+			for _, op := range ops {
+				for _, m := range targets {
+					if m.ID == op.MonitorID {
+						vcpSet(m, op.Code, op.Value)
+					}
+				}
+			}
+			return withRecoverHint(started, err)
+		}
+
+		for _, m := range targets {
+			if err := client.SetVCP(m.ID, code, uint16(value)); err != nil {
+				return fmt.Errorf("failed to set VCP 0x%02X on monitor %s: %w", code, m.ID, err)
+			}
+			vcpSet(m, code, uint16(value))
+		}
+		return nil
+	},
+}
+
+// vcpSet records and reports a VCP write
+func vcpSet(m ddc.Monitor, code byte, value uint16) {
+	updateState(func(st *state.State) {
+		st.RecordValue(ddc.StableKey(m), m.Name, code, value, "vcp")
+	})
+	fmt.Printf("✓ Monitor %s (%s): VCP 0x%02X (%s) set to %s\n", m.ID, m.Name, code, mccs.Name(code), ddc.DecodeVCPValue(m, code, value))
+}
+
+func vcpTargets() (ddc.DDCClient, []ddc.Monitor, error) {
+	s, err := getSession()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	monitors, err := s.Monitors()
+	if err != nil {
+		return nil, nil, fmt.Errorf("monitor detection failed: %w", err)
+	}
+	targets, err := selectMonitors(monitors, vcpMonitor)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(targets) == 0 {
+		return nil, nil, fmt.Errorf("no DDC/CI compatible monitors detected")
+	}
+	return s.client, targets, nil
+}
+
+func parseVCPCode(s string) (byte, error) {
+	code, err := strconv.ParseUint(s, 0, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid VCP code %q: %w", s, err)
+	}
+	return byte(code), nil
+}
+
+func init() {
+	vcpCmd.PersistentFlags().StringVarP(&vcpMonitor, "monitor", "m", "", "monitor ID (default: all)")
+	vcpSetCmd.Flags().BoolVar(&showPlan, "plan", false, "show the writes and ask before making them (with -o json, only print them)")
+	vcpCmd.AddCommand(vcpGetCmd, vcpSetCmd)
+	rootCmd.AddCommand(vcpCmd)
+}
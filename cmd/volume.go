@@ -0,0 +1,7 @@
+package cmd
+
+import "github.com/sibteali786/monitorswitch/internal/ddc"
+
+func init() {
+	rootCmd.AddCommand(newLevelCmd("volume", ddc.VCPVolume))
+}
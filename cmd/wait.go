@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/spf13/cobra"
+)
+
+// coexistPollInterval is the slowest we poll while another controller is active
+const coexistPollInterval = 5 * time.Second
+
+var (
+	waitMonitor         string
+	waitInput           string
+	waitConnected       bool
+	waitBrightnessBelow int
+	waitTimeout         time.Duration
+	waitInterval        time.Duration
+)
+
+var waitCmd = &cobra.Command{
+	Use:   "wait",
+	Short: "Block until a monitor condition becomes true",
+	Long: `Poll the monitor until every given condition holds, then exit 0. Exits
+non-zero if --timeout passes first. Useful to sequence scripts after a manual
+or external switch:
+
+  monitorswitch wait --monitor 1 --input DP1 --timeout 30s && ...`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if waitInput == "" && !waitConnected && waitBrightnessBelow < 0 {
+			return fmt.Errorf("nothing to wait for; give --input, --connected or --brightness-below")
+		}
+		if waitMonitor == "" && (waitInput != "" || waitConnected) {
+			return fmt.Errorf("--monitor is required with --input and --connected")
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		deadline := time.Now().Add(waitTimeout)
+		for {
+			ok, reason := checkWaitConditions(client)
+			if ok {
+				fmt.Println("✓ Condition met")
+				return nil
+			}
+			if verbose {
+				fmt.Printf("[VERBOSE] Waiting: %s\n", reason)
+			}
+
+			if waitTimeout > 0 && time.Now().Add(waitInterval).After(deadline) {
+				return fmt.Errorf("timed out after %s: %s", waitTimeout, reason)
+			}
+			time.Sleep(pollInterval(waitInterval))
+		}
+	},
+}
+
+// checkWaitConditions detects monitors afresh and evaluates every condition,
+// returning why it isn't met yet
+func checkWaitConditions(client ddc.DDCClient) (bool, string) {
+	monitors, err := client.DetectMonitors()
+	if err != nil {
+		return false, fmt.Sprintf("detection failed: %v", err)
+	}
+
+	targets, err := selectMonitors(monitors, waitMonitor)
+	if err != nil || len(targets) == 0 {
+		return false, fmt.Sprintf("monitor %q not connected", waitMonitor)
+	}
+
+	for _, m := range targets {
+		if waitInput != "" {
+			input := waitInput
+			if labeled, ok := loadLabels().Resolve(m, input); ok {
+				input = labeled
+			}
+			code, err := ddc.ResolveInput(m, input)
+			if err != nil {
+				return false, err.Error()
+			}
+			current, err := client.GetVCP(m.ID, ddc.VCPInputSource)
+			if err != nil {
+				return false, fmt.Sprintf("cannot read input of monitor %s: %v", m.ID, err)
+			}
+			if byte(current) != code {
+				return false, fmt.Sprintf("monitor %s input is 0x%02X, want 0x%02X", m.ID, byte(current), code)
+			}
+		}
+
+		if waitBrightnessBelow >= 0 {
+			current, err := client.GetVCP(m.ID, ddc.VCPBrightness)
+			if err != nil {
+				return false, fmt.Sprintf("cannot read brightness of monitor %s: %v", m.ID, err)
+			}
+			if int(current) >= waitBrightnessBelow {
+				return false, fmt.Sprintf("monitor %s brightness is %d", m.ID, current)
+			}
+		}
+	}
+	return true, ""
+}
+
+// pollInterval backs polling off while another DDC/CI controller is running,
+// so our reads don't collide with its own polling
+func pollInterval(interval time.Duration) time.Duration {
+	if len(ddc.RunningArbiters()) > 0 && interval < coexistPollInterval {
+		return coexistPollInterval
+	}
+	return interval
+}
+
+func init() {
+	waitCmd.Flags().StringVarP(&waitMonitor, "monitor", "m", "", "monitor ID to watch (all monitors if omitted)")
+	waitCmd.Flags().StringVar(&waitInput, "input", "", "wait until the monitor shows this input")
+	waitCmd.Flags().BoolVar(&waitConnected, "connected", false, "wait until the monitor is connected")
+	waitCmd.Flags().IntVar(&waitBrightnessBelow, "brightness-below", -1, "wait until brightness is below this value")
+	waitCmd.Flags().DurationVar(&waitTimeout, "timeout", 0, "give up after this long (0 waits forever)")
+	waitCmd.Flags().DurationVar(&waitInterval, "interval", time.Second, "how often to poll")
+	rootCmd.AddCommand(waitCmd)
+}
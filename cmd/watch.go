@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/config"
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/service"
+	"github.com/sibteali786/monitorswitch/pkg/monitorswitch"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchExec     string
+	watchSettle   time.Duration
+	watchInterval time.Duration
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "React to monitors being connected, disconnected or changing state",
+	Long: `Wait for display-change notifications from the OS (DRM uevents on Linux,
+CoreGraphics reconfiguration on macOS, WM_DISPLAYCHANGE on Windows), detect
+monitors again and run the actions configured for each event in config.yaml:
+
+  watch:
+    - on: added
+      monitor: dell-left
+      profile: desk
+    - on: removed
+      run: notify-send "monitor gone"
+
+Events are "added", "removed" and "changed" (woke, went to sleep, or switched
+input). --exec runs a command for every event, with MONITORSWITCH_EVENT,
+MONITORSWITCH_MONITOR_ID and MONITORSWITCH_MONITOR_NAME set.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := getSession()
+		if err != nil {
+			return err
+		}
+		// Detection failures are logged at info level, shown with --verbose
+		monitors, _ := s.Monitors()
+
+		events, err := s.Watch(cmd.Context())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Watching %d monitors for changes (Ctrl+C to stop)\n", len(monitors))
+		for event := range events {
+			handleMonitorEvent(event)
+		}
+		return nil
+	},
+}
+
+// handleMonitorEvent reports an event and runs the matching actions. Action
+// failures are reported and never stop the watch.
+func handleMonitorEvent(event monitorswitch.Event) {
+	m := event.Monitor
+	line := fmt.Sprintf("Monitor %s (%s) %s", m.ID, m.Name, event.Kind)
+	if event.Detail != "" {
+		line += ": " + event.Detail
+	}
+	fmt.Printf("%s %s\n", time.Now().Format("15:04:05"), line)
+
+	for _, rule := range config.Get().Watch {
+		if rule.On != "" && !strings.EqualFold(rule.On, string(event.Kind)) {
+			continue
+		}
+		if _, err := service.SelectMonitors([]ddc.Monitor{m}, rule.Monitor, config.Get()); err != nil {
+			continue
+		}
+
+		if rule.Profile != "" {
+			if err := applyProfile(rule.Profile); err != nil {
+				fmt.Printf("x Profile %q: %v\n", rule.Profile, err)
+			}
+		}
+		if rule.Input != "" && event.Kind != monitorswitch.EventRemoved {
+			if err := watchSwitch(m, rule.Input); err != nil {
+				fmt.Printf("x Switch to %s: %v\n", rule.Input, err)
+			}
+		}
+		if rule.Run != "" {
+			runWatchCommand(rule.Run, event)
+		}
+	}
+	if watchExec != "" {
+		runWatchCommand(watchExec, event)
+	}
+}
+
+func watchSwitch(m ddc.Monitor, input string) error {
+	svc, err := newService()
+	if err != nil {
+		return err
+	}
+	result, err := svc.Switch(service.SwitchRequest{MonitorID: m.ID, Input: input})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Monitor %s (%s): switched to %s (0x%02X)\n", result.MonitorID, result.MonitorName, result.Input, result.Code)
+	return nil
+}
+
+// runWatchCommand runs command through the shell with the event in its environment
+func runWatchCommand(command string, event monitorswitch.Event) {
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", command)
+	} else {
+		c = exec.Command("sh", "-c", command)
+	}
+	c.Stdout, c.Stderr = os.Stdout, os.Stderr
+	c.Env = append(os.Environ(),
+		"MONITORSWITCH_EVENT="+string(event.Kind),
+		"MONITORSWITCH_MONITOR_ID="+event.Monitor.ID,
+		"MONITORSWITCH_MONITOR_NAME="+event.Monitor.Name,
+		"MONITORSWITCH_EVENT_DETAIL="+event.Detail,
+	)
+	if err := c.Run(); err != nil {
+		fmt.Printf("x Command %q: %v\n", command, err)
+	}
+}
+
+func init() {
+	watchCmd.Flags().StringVar(&watchExec, "exec", "", "command to run for every event")
+	watchCmd.Flags().DurationVar(&watchSettle, "settle", 2*time.Second, "wait this long after a notification before detecting")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", time.Minute, "also detect this often, for changes the OS doesn't announce")
+	rootCmd.AddCommand(watchCmd)
+}
@@ -0,0 +1,93 @@
+// Package audio switches the local default audio output when a monitor
+// changes input, so sound follows (or leaves) the monitor's speakers.
+package audio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Links maps monitor ID -> input name -> local audio output to select after
+// switching the monitor to that input
+type Links map[string]map[string]string
+
+func linksPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "monitorswitch", "audio.json"), nil
+}
+
+// Load reads the audio links, returning none if the file doesn't exist yet
+func Load() (Links, error) {
+	links := make(Links)
+
+	path, err := linksPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return links, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &links); err != nil {
+		return nil, fmt.Errorf("corrupt audio links file %s: %w", path, err)
+	}
+	return links, nil
+}
+
+// Save writes the audio links file
+func (l Links) Save() error {
+	path, err := linksPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Set links an input of a monitor to an audio output
+func (l Links) Set(monitorID, input, device string) {
+	if l[monitorID] == nil {
+		l[monitorID] = make(map[string]string)
+	}
+	l[monitorID][input] = device
+}
+
+// Remove deletes an input's audio link, reporting whether it existed
+func (l Links) Remove(monitorID, input string) bool {
+	for name := range l[monitorID] {
+		if strings.EqualFold(name, input) {
+			delete(l[monitorID], name)
+			return true
+		}
+	}
+	return false
+}
+
+// Device returns the audio output linked to an input, or "" if there is none
+func (l Links) Device(monitorID, input string) string {
+	for name, device := range l[monitorID] {
+		if strings.EqualFold(name, input) {
+			return device
+		}
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package audio
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// SetDefaultOutput makes device the default output via SwitchAudioSource
+func SetDefaultOutput(device string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := exec.LookPath("SwitchAudioSource"); err != nil {
+		return fmt.Errorf("SwitchAudioSource not found; install it with 'brew install switchaudio-osx'")
+	}
+	if output, err := exec.CommandContext(ctx, "SwitchAudioSource", "-t", "output", "-s", device).CombinedOutput(); err != nil {
+		return fmt.Errorf("SwitchAudioSource -s %s: %v: %s", device, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Outputs lists the available output device names
+func Outputs() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "SwitchAudioSource", "-a", "-t", "output").Output()
+	if err != nil {
+		return nil, fmt.Errorf("SwitchAudioSource -a: %w", err)
+	}
+
+	var devices []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			devices = append(devices, line)
+		}
+	}
+	return devices, nil
+}
@@ -0,0 +1,45 @@
+//go:build !windows && !darwin
+
+package audio
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// SetDefaultOutput makes device (a PulseAudio/PipeWire sink name) the default output
+func SetDefaultOutput(device string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := exec.LookPath("pactl"); err != nil {
+		return fmt.Errorf("pactl not found; install pulseaudio-utils (also works with PipeWire)")
+	}
+	if output, err := exec.CommandContext(ctx, "pactl", "set-default-sink", device).CombinedOutput(); err != nil {
+		return fmt.Errorf("pactl set-default-sink %s: %v: %s", device, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Outputs lists the available sink names
+func Outputs() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "pactl", "list", "short", "sinks").Output()
+	if err != nil {
+		return nil, fmt.Errorf("pactl list short sinks: %w", err)
+	}
+
+	// Columns: index, name, driver, sample spec, state
+	var sinks []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if fields := strings.Fields(line); len(fields) >= 2 {
+			sinks = append(sinks, fields[1])
+		}
+	}
+	return sinks, nil
+}
@@ -0,0 +1,44 @@
+package audio
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// SetDefaultOutput makes device the default output. Windows has no built-in
+// command for this, so it uses the AudioDeviceCmdlets PowerShell module.
+func SetDefaultOutput(device string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	script := fmt.Sprintf(`Get-AudioDevice -List | Where-Object { $_.Type -eq 'Playback' -and $_.Name -like '*%s*' } | Select-Object -First 1 | Set-AudioDevice`,
+		strings.ReplaceAll(device, "'", "''"))
+	output, err := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", script).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("setting audio device (needs the AudioDeviceCmdlets module): %v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Outputs lists the playback device names
+func Outputs() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	script := `Get-AudioDevice -List | Where-Object { $_.Type -eq 'Playback' } | ForEach-Object { $_.Name }`
+	output, err := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", script).Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing audio devices (needs the AudioDeviceCmdlets module): %w", err)
+	}
+
+	var devices []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			devices = append(devices, line)
+		}
+	}
+	return devices, nil
+}
@@ -0,0 +1,44 @@
+// Package cec talks HDMI-CEC through libcec's cec-client, for the few things
+// DDC/CI can't do, such as showing a message on the screen.
+package cec
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// maxOSDLength is the longest string the CEC "Set OSD String" message carries
+const maxOSDLength = 13
+
+// Available reports whether cec-client is installed
+func Available() bool {
+	_, err := exec.LookPath("cec-client")
+	return err == nil
+}
+
+// ShowOSD displays text on the TV (logical address 0). CEC limits OSD strings
+// to 13 characters; longer text is truncated.
+func ShowOSD(text string) error {
+	if !Available() {
+		return fmt.Errorf("cec-client not found; install libcec to show OSD messages")
+	}
+
+	text = strings.ReplaceAll(text, "\n", " ")
+	if runes := []rune(text); len(runes) > maxOSDLength {
+		text = string(runes[:maxOSDLength])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// -s: single command from stdin, -d 1: errors only
+	cmd := exec.CommandContext(ctx, "cec-client", "-s", "-d", "1")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("osd 0 %s\n", text))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("cec-client: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Package compat looks up what is known to work on a monitor model, from an
+// embedded compatibility database and optionally one fetched over HTTP.
+package compat
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//go:embed compat.json
+var embedded []byte
+
+// Entry is what's known about monitors whose name contains Model
+type Entry struct {
+	Model  string            `json:"model"`            // Case-insensitive substring of the monitor name
+	Inputs map[string]string `json:"inputs,omitempty"` // Input name -> VCP 0x60 value known to work, e.g. "USB-C" -> "0x1B"
+	Quirks []string          `json:"quirks,omitempty"` // Workarounds the model needs
+	Broken []string          `json:"broken,omitempty"` // Features known not to work
+	Notes  string            `json:"notes,omitempty"`
+	Source string            `json:"source,omitempty"` // "embedded" or the URL it came from; set on load
+}
+
+// Embedded returns the database compiled into the binary
+func Embedded() ([]Entry, error) {
+	return parse(embedded, "embedded")
+}
+
+// Fetch downloads a database in the same format from url
+func Fetch(ctx context.Context, url string) ([]Entry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch compatibility database: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch compatibility database: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, err
+	}
+	return parse(data, url)
+}
+
+func parse(data []byte, source string) ([]Entry, error) {
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("corrupt compatibility database (%s): %w", source, err)
+	}
+	for i := range entries {
+		entries[i].Source = source
+	}
+	return entries, nil
+}
+
+// Lookup returns the entries matching a monitor name or model, in either
+// direction: "DELL U2720Q" matches an entry for "DELL U", and "ultrafine"
+// matches one for "LG UltraFine"
+func Lookup(entries []Entry, model string) []Entry {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return nil
+	}
+
+	var matches []Entry
+	for _, e := range entries {
+		m := strings.ToLower(e.Model)
+		if strings.Contains(model, m) || strings.Contains(m, model) {
+			matches = append(matches, e)
+		}
+	}
+	return matches
+}
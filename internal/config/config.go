@@ -0,0 +1,80 @@
+// Package config holds the settings from config.yaml, loaded once at startup
+// by the CLI and read by the packages that need them.
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// Config is the contents of config.yaml
+type Config struct {
+	// Aliases name monitors: alias -> monitor ID, UUID or name. Aliases are
+	// accepted wherever a monitor ID is, and are case-insensitive.
+	Aliases map[string]string `mapstructure:"aliases"`
+
+	// Inputs name inputs on every monitor: name -> input name or code,
+	// e.g. "mac" -> "USB-C". Per-monitor labels take precedence.
+	Inputs map[string]string `mapstructure:"inputs"`
+
+	// Tool is the preferred backend when several are available
+	Tool string `mapstructure:"tool"`
+
+	// Watch lists the actions 'monitorswitch watch' takes on hotplug events
+	Watch []WatchRule `mapstructure:"watch"`
+
+	// SwitchAll sets the defaults of 'monitorswitch switch --all'
+	SwitchAll SwitchAll `mapstructure:"switch_all"`
+
+	// Retry tunes how writes are verified and retried
+	Retry Retry `mapstructure:"retry"`
+}
+
+// Retry overrides the default write retry policy; zero values keep the default
+type Retry struct {
+	Attempts int           `mapstructure:"attempts"` // Writes to try, 1 to disable retrying
+	Backoff  time.Duration `mapstructure:"backoff"`  // Wait before the first retry, doubling after
+	Timeout  time.Duration `mapstructure:"timeout"`  // Stop retrying after this long
+	Verify   *bool         `mapstructure:"verify"`   // Read values back after writing
+}
+
+// SwitchAll orders the monitors 'switch --all' switches. Some monitors drop
+// DDC/CI on the other displays for a moment while they change input.
+type SwitchAll struct {
+	Order []string      `mapstructure:"order"` // Monitor IDs or aliases to switch first
+	Delay time.Duration `mapstructure:"delay"` // Pause between monitors, e.g. "1500ms"
+}
+
+// WatchRule runs its actions when a matching monitor event happens. Actions
+// run in order: profile, input, then the command.
+type WatchRule struct {
+	On      string `mapstructure:"on"`      // "added", "removed", "changed"; empty for any
+	Monitor string `mapstructure:"monitor"` // Monitor ID, alias or name; empty for any
+	Profile string `mapstructure:"profile"` // Profile to apply
+	Input   string `mapstructure:"input"`   // Input to switch the monitor to
+	Run     string `mapstructure:"run"`     // Shell command to run
+}
+
+var current Config
+
+// Set makes cfg the active configuration
+func Set(cfg Config) {
+	current = cfg
+}
+
+// Get returns the active configuration
+func Get() Config {
+	return current
+}
+
+// Alias returns what a monitor alias refers to
+func (c Config) Alias(name string) (string, bool) {
+	target, ok := c.Aliases[strings.ToLower(name)]
+	return target, ok
+}
+
+// Input returns the input a global input name refers to
+func (c Config) Input(name string) (string, bool) {
+	input, ok := c.Inputs[strings.ToLower(name)]
+	return input, ok
+}
@@ -0,0 +1,48 @@
+package ddc
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Arbiter is another program that talks DDC/CI to the same monitors
+type Arbiter struct {
+	Name    string // Product name (e.g., "Lunar")
+	Process string // Process name it was found as
+}
+
+// knownArbiters maps lowercase process names to the program they belong to
+var knownArbiters = map[string]string{
+	"lunar":           "Lunar",
+	"monitorcontrol":  "MonitorControl",
+	"betterdisplay":   "BetterDisplay",
+	"monitorian":      "Monitorian",
+	"twinkle tray":    "Twinkle Tray",
+	"twinkletray":     "Twinkle Tray",
+	"clickmonitorddc": "ClickMonitorDDC",
+	"ddcutil-service": "ddcutil-service",
+	"ddcui":           "ddcui",
+}
+
+// RunningArbiters lists known DDC/CI controllers that are currently running.
+// Two programs polling the same monitor interleave their I2C transactions and
+// get corrupt replies, so callers should warn about these.
+func RunningArbiters() []Arbiter {
+	names, err := processNames()
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var arbiters []Arbiter
+	for _, process := range names {
+		key := strings.ToLower(strings.TrimSuffix(filepath.Base(process), ".exe"))
+		name, ok := knownArbiters[key]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		arbiters = append(arbiters, Arbiter{Name: name, Process: process})
+	}
+	return arbiters
+}
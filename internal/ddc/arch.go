@@ -0,0 +1,59 @@
+package ddc
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Architecture is the CPU architecture of the machine and of this binary.
+// They differ when e.g. an amd64 build runs under emulation on Windows on
+// ARM or under Rosetta, which matters for bug reports: emulated binaries
+// load the emulated system DLLs and report the emulated architecture.
+type Architecture struct {
+	Native string `json:"native"`
+	Binary string `json:"binary"`
+}
+
+// DetectArchitecture returns the machine and binary architectures in GOARCH
+// terms (amd64, arm64, 386...)
+func DetectArchitecture() Architecture {
+	a := Architecture{Native: nativeArch(), Binary: runtime.GOARCH}
+	if a.Native == "" {
+		a.Native = a.Binary
+	}
+	return a
+}
+
+// Emulated reports whether this binary runs under emulation
+func (a Architecture) Emulated() bool {
+	return a.Native != a.Binary
+}
+
+// String returns e.g. "arm64" or "arm64 (amd64 binary under emulation)"
+func (a Architecture) String() string {
+	if a.Emulated() {
+		return fmt.Sprintf("%s (%s binary under emulation)", a.Native, a.Binary)
+	}
+	return a.Native
+}
+
+// goArch maps the machine names of uname and the OS to GOARCH names
+func goArch(machine string) string {
+	switch machine {
+	case "x86_64", "AMD64", "x64":
+		return "amd64"
+	case "aarch64", "arm64", "ARM64", "aarch64_be":
+		return "arm64"
+	case "i386", "i486", "i586", "i686", "x86", "X86":
+		return "386"
+	case "armv6l", "armv7l", "armv8l", "ARM":
+		return "arm"
+	case "riscv64":
+		return "riscv64"
+	case "ppc64le":
+		return "ppc64le"
+	case "s390x":
+		return "s390x"
+	}
+	return machine
+}
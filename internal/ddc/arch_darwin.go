@@ -0,0 +1,16 @@
+package ddc
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// nativeArch returns the Mac's architecture. Under Rosetta uname reports
+// x86_64, so ask whether this process is translated instead.
+func nativeArch() string {
+	if translated, err := unix.SysctlUint32("sysctl.proc_translated"); err == nil && translated == 1 {
+		return "arm64"
+	}
+	return runtime.GOARCH
+}
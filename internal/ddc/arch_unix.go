@@ -0,0 +1,14 @@
+//go:build !windows && !darwin
+
+package ddc
+
+import "golang.org/x/sys/unix"
+
+// nativeArch returns the kernel's machine architecture
+func nativeArch() string {
+	var utsname unix.Utsname
+	if err := unix.Uname(&utsname); err != nil {
+		return ""
+	}
+	return goArch(unix.ByteSliceToString(utsname.Machine[:]))
+}
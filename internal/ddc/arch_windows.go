@@ -0,0 +1,41 @@
+package ddc
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// Machine types IsWow64Process2 reports (IMAGE_FILE_MACHINE_*)
+const (
+	imageFileMachineI386  = 0x014C
+	imageFileMachineARMNT = 0x01C4
+	imageFileMachineAMD64 = 0x8664
+	imageFileMachineARM64 = 0xAA64
+)
+
+// nativeArch returns the machine architecture even when this process is
+// emulated, e.g. an amd64 build on Windows on ARM. PROCESSOR_ARCHITECTURE
+// reports the emulated architecture there.
+func nativeArch() string {
+	var process, native uint16
+	// IsWow64Process2 exists since Windows 10 1511
+	if err := windows.IsWow64Process2(windows.CurrentProcess(), &process, &native); err == nil {
+		switch native {
+		case imageFileMachineAMD64:
+			return "amd64"
+		case imageFileMachineARM64:
+			return "arm64"
+		case imageFileMachineI386:
+			return "386"
+		case imageFileMachineARMNT:
+			return "arm"
+		}
+	}
+
+	// Set for 32-bit processes on 64-bit Windows
+	if arch := os.Getenv("PROCESSOR_ARCHITEW6432"); arch != "" {
+		return goArch(arch)
+	}
+	return goArch(os.Getenv("PROCESSOR_ARCHITECTURE"))
+}
@@ -0,0 +1,111 @@
+package ddc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// backlightPrefix marks monitor IDs of the backlight backend
+const backlightPrefix = "backlight-"
+
+// backlightDevice is a panel backlight the kernel exposes
+type backlightDevice struct {
+	Name      string // e.g. "intel_backlight"
+	Connector string // DRM connector of the panel, e.g. "eDP-1", if known
+	Dir       string
+}
+
+// BacklightClient controls the brightness of built-in panels, which don't
+// speak DDC/CI, through the kernel backlight interface. It only supports
+// brightness; route it alongside a DDC/CI backend with RouterClient.
+type BacklightClient struct {
+	devices []backlightDevice
+}
+
+// NewBacklightClient returns a client for the built-in panels, or nil if
+// this machine has none the OS lets us control
+func NewBacklightClient() *BacklightClient {
+	devices := backlightDevices()
+	if len(devices) == 0 {
+		return nil
+	}
+	return &BacklightClient{devices: devices}
+}
+
+func (c *BacklightClient) Backend() string {
+	return "backlight"
+}
+
+func (c *BacklightClient) DetectMonitors() ([]Monitor, error) {
+	var monitors []Monitor
+	for _, d := range c.devices {
+		monitors = append(monitors, Monitor{
+			ID:        backlightPrefix + d.Name,
+			Name:      "Built-in display",
+			Connector: d.Connector,
+			Inputs:    map[string]byte{},
+			DDCTool:   "backlight",
+		})
+	}
+	return monitors, nil
+}
+
+// Features is brightness only
+func (c *BacklightClient) Features(m Monitor) FeatureSet {
+	if _, err := c.device(m.ID); err != nil {
+		return FeatureSet{}
+	}
+	return FeatureSet{FeatureBrightness: true}
+}
+
+func (c *BacklightClient) GetCapabilities(monitorID string) (*Capabilities, error) {
+	if _, err := c.device(monitorID); err != nil {
+		return nil, err
+	}
+	return &Capabilities{
+		SupportedInputs:     map[string]byte{},
+		SupportedBrightness: true,
+		Features:            []byte{VCPBrightness},
+	}, nil
+}
+
+func (c *BacklightClient) SetVCP(monitorID string, code byte, value uint16) error {
+	d, err := c.device(monitorID)
+	if err != nil {
+		return err
+	}
+	if code != VCPBrightness {
+		return fmt.Errorf("the built-in display only supports brightness, not VCP 0x%02X", code)
+	}
+	return d.setBrightness(value)
+}
+
+func (c *BacklightClient) GetVCP(monitorID string, code byte) (uint16, error) {
+	v, err := c.GetVCPValue(monitorID, code)
+	return v.Current, err
+}
+
+// GetVCPValue reports the raw backlight level with the device's maximum,
+// which is often far from 100
+func (c *BacklightClient) GetVCPValue(monitorID string, code byte) (VCPValue, error) {
+	d, err := c.device(monitorID)
+	if err != nil {
+		return VCPValue{}, err
+	}
+	if code != VCPBrightness {
+		return VCPValue{}, fmt.Errorf("the built-in display only supports brightness, not VCP 0x%02X", code)
+	}
+	return d.brightness()
+}
+
+func (c *BacklightClient) device(monitorID string) (backlightDevice, error) {
+	name, ok := strings.CutPrefix(monitorID, backlightPrefix)
+	if ok {
+		for _, d := range c.devices {
+			if d.Name == name {
+				return d, nil
+			}
+		}
+	}
+	return backlightDevice{}, fmt.Errorf("monitor %s is not a built-in display", monitorID)
+}
@@ -0,0 +1,19 @@
+//go:build windows || darwin
+
+package ddc
+
+import "fmt"
+
+// backlightDevices returns nil: built-in panels are controlled through the
+// OS brightness APIs there, which aren't implemented yet
+func backlightDevices() []backlightDevice {
+	return nil
+}
+
+func (d backlightDevice) brightness() (VCPValue, error) {
+	return VCPValue{}, fmt.Errorf("backlight control is not supported on this OS")
+}
+
+func (d backlightDevice) setBrightness(value uint16) error {
+	return fmt.Errorf("backlight control is not supported on this OS")
+}
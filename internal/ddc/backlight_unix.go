@@ -0,0 +1,81 @@
+//go:build !windows && !darwin
+
+package ddc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// backlightDevices lists /sys/class/backlight. Firmware and platform
+// interfaces (acpi_video0) often duplicate the GPU driver's one and do
+// nothing, so raw interfaces are preferred when there are both.
+func backlightDevices() []backlightDevice {
+	dirs, _ := filepath.Glob("/sys/class/backlight/*")
+
+	var raw, other []backlightDevice
+	for _, dir := range dirs {
+		d := backlightDevice{Name: filepath.Base(dir), Dir: dir, Connector: backlightConnector(dir)}
+		if kind, _ := os.ReadFile(filepath.Join(dir, "type")); strings.TrimSpace(string(kind)) == "raw" {
+			raw = append(raw, d)
+		} else {
+			other = append(other, d)
+		}
+	}
+	if len(raw) > 0 {
+		return raw
+	}
+	return other
+}
+
+// backlightConnector finds the panel's DRM connector: the device link of
+// i915's backlight points at it, other drivers link the GPU, in which case
+// the first connected eDP or LVDS connector is the panel
+func backlightConnector(dir string) string {
+	if target, err := filepath.EvalSymlinks(filepath.Join(dir, "device")); err == nil {
+		if _, connector, ok := strings.Cut(filepath.Base(target), "-"); ok && strings.HasPrefix(filepath.Base(target), "card") {
+			return connector
+		}
+	}
+	for _, pattern := range []string{"card*-eDP-*", "card*-LVDS-*"} {
+		for _, path := range drmGlob(pattern) {
+			status, _ := os.ReadFile(filepath.Join(path, "status"))
+			if strings.TrimSpace(string(status)) == "connected" {
+				_, connector, _ := strings.Cut(filepath.Base(path), "-")
+				return connector
+			}
+		}
+	}
+	return ""
+}
+
+func (d backlightDevice) brightness() (VCPValue, error) {
+	current, err := readBacklightFile(d.Dir, "brightness")
+	if err != nil {
+		return VCPValue{}, err
+	}
+	maximum, err := readBacklightFile(d.Dir, "max_brightness")
+	if err != nil {
+		return VCPValue{}, err
+	}
+	return VCPValue{Current: uint16(min(current, 0xFFFF)), Max: uint16(min(maximum, 0xFFFF))}, nil
+}
+
+func (d backlightDevice) setBrightness(value uint16) error {
+	err := os.WriteFile(filepath.Join(d.Dir, "brightness"), []byte(strconv.Itoa(int(value))), 0)
+	if os.IsPermission(err) {
+		return fmt.Errorf("no permission to change the backlight: add a udev rule for %s or join the video group", d.Dir)
+	}
+	return err
+}
+
+func readBacklightFile(dir, name string) (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
@@ -0,0 +1,135 @@
+package ddc
+
+import (
+	"fmt"
+	"time"
+)
+
+// interWriteDelay is the pause DDC/CI requires between consecutive writes
+const interWriteDelay = 50 * time.Millisecond
+
+// VCPWrite is one feature write in a batch
+type VCPWrite struct {
+	Code  byte
+	Value uint16
+}
+
+// BatchSetter is implemented by clients that can apply several writes to one
+// monitor in a single backend session
+type BatchSetter interface {
+	SetVCPBatch(monitorID string, writes []VCPWrite) error
+}
+
+// SetVCPs applies writes to one monitor, in a single backend session when the
+// client supports it and otherwise one by one with the required delay
+func SetVCPs(client DDCClient, monitorID string, writes []VCPWrite) error {
+	if b, ok := client.(BatchSetter); ok {
+		return b.SetVCPBatch(monitorID, writes)
+	}
+	return setVCPsSequentially(client, monitorID, writes)
+}
+
+func setVCPsSequentially(client DDCClient, monitorID string, writes []VCPWrite) error {
+	for i, w := range writes {
+		if i > 0 {
+			time.Sleep(interWriteDelay)
+		}
+		if err := client.SetVCP(monitorID, w.Code, w.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetVCPBatch applies writes in one ddcutil invocation on Linux and the BSDs,
+// which handles the inter-write delays itself. Other backends write one by
+// one. The batch goes through the same verification as SetVCP: each feature
+// is read back afterwards and the ones the monitor dropped are written again
+// with SetVCP's retries.
+func (c *DDCClientImpl) SetVCPBatch(monitorID string, writes []VCPWrite) error {
+	switch c.osType {
+	case OSLinux, OSFreeBSD, OSOpenBSD:
+		if len(writes) > 1 {
+			break
+		}
+		fallthrough
+	default:
+		return setVCPsSequentially(c, monitorID, writes)
+	}
+
+	if err := c.setLinuxVCPBatch(monitorID, writes); err != nil {
+		c.logger.Debug("batched VCP write failed; writing one by one", "monitor", monitorID, "error", err)
+		return setVCPsSequentially(c, monitorID, writes)
+	}
+	if !c.retry.Verify {
+		return nil
+	}
+	wait := time.Duration(0)
+	for _, w := range writes {
+		wait = max(wait, c.postWriteDelay(monitorID, w.Code))
+	}
+	time.Sleep(wait)
+	for _, w := range writes {
+		if unverifiableCodes[w.Code] {
+			continue
+		}
+		got, err := c.GetVCPValue(monitorID, w.Code)
+		if err != nil || writeApplied(w.Code, w.Value, got.Current) {
+			// As in setVCPVerified, an unanswered read doesn't undo an
+			// acknowledged write
+			continue
+		}
+		c.logger.Debug("batched VCP write not applied; retrying", "monitor", monitorID,
+			"code", fmt.Sprintf("0x%02X", w.Code), "want", w.Value, "got", got.Current)
+		if err := c.SetVCP(monitorID, w.Code, w.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *SerializedClient) SetVCPBatch(monitorID string, writes []VCPWrite) error {
+	unlock, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return SetVCPs(c.DDCClient, monitorID, writes)
+}
+
+func (c *StandbyClient) SetVCPBatch(monitorID string, writes []VCPWrite) error {
+	return SetVCPs(c.DDCClient, monitorID, writes)
+}
+
+func (c *ReadOnlyClient) SetVCPBatch(monitorID string, writes []VCPWrite) error {
+	return ErrReadOnly
+}
+
+func (c *ValidatingClient) SetVCPBatch(monitorID string, writes []VCPWrite) error {
+	if !c.Force {
+		for _, w := range writes {
+			if err := c.validate(monitorID, w.Code, w.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return SetVCPs(c.DDCClient, monitorID, writes)
+}
+
+// SetVCPBatch sends brightness through the delegate and batches the rest
+func (c *CoexistClient) SetVCPBatch(monitorID string, writes []VCPWrite) error {
+	var rest []VCPWrite
+	for _, w := range writes {
+		if w.Code == VCPBrightness {
+			if err := c.SetVCP(monitorID, w.Code, w.Value); err != nil {
+				return err
+			}
+			continue
+		}
+		rest = append(rest, w)
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+	return SetVCPs(c.DDCClient, monitorID, rest)
+}
@@ -0,0 +1,108 @@
+package ddc
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long CachingClient trusts a VCP reading
+const DefaultCacheTTL = 2 * time.Second
+
+// CachingClient remembers VCP readings for a short time so repeated reads of
+// the same feature don't each cost a bus transaction. Writes made through it
+// update the cache, so it never serves a value older than our own write.
+type CachingClient struct {
+	DDCClient
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+type cacheKey struct {
+	monitorID string
+	code      byte
+}
+
+type cacheEntry struct {
+	value VCPValue
+	at    time.Time
+}
+
+// NewCachingClient wraps client with a read cache of the given TTL
+func NewCachingClient(client DDCClient, ttl time.Duration) *CachingClient {
+	return &CachingClient{DDCClient: client, ttl: ttl, entries: make(map[cacheKey]cacheEntry)}
+}
+
+func (c *CachingClient) GetVCP(monitorID string, code byte) (uint16, error) {
+	value, err := c.GetVCPValue(monitorID, code)
+	return value.Current, err
+}
+
+func (c *CachingClient) GetVCPValue(monitorID string, code byte) (VCPValue, error) {
+	key := cacheKey{monitorID, code}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Since(entry.at) < c.ttl {
+		return entry.value, nil
+	}
+
+	value, err := c.DDCClient.GetVCPValue(monitorID, code)
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = cacheEntry{value: value, at: time.Now()}
+	c.mu.Unlock()
+	return value, nil
+}
+
+func (c *CachingClient) SetVCP(monitorID string, code byte, value uint16) error {
+	err := c.DDCClient.SetVCP(monitorID, code, value)
+	c.remember(monitorID, code, value, err)
+	return err
+}
+
+func (c *CachingClient) SetVCPBatch(monitorID string, writes []VCPWrite) error {
+	err := SetVCPs(c.DDCClient, monitorID, writes)
+	for _, w := range writes {
+		c.remember(monitorID, w.Code, w.Value, err)
+	}
+	return err
+}
+
+// remember records a write, or forgets the feature if the write failed and
+// its state is therefore unknown
+func (c *CachingClient) remember(monitorID string, code byte, value uint16, err error) {
+	key := cacheKey{monitorID, code}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if err != nil || !ok {
+		delete(c.entries, key)
+		return
+	}
+	entry.value.Current = value
+	entry.at = time.Now()
+	c.entries[key] = entry
+}
+
+// Invalidate forgets every cached reading of a monitor, e.g. after hotplug
+func (c *CachingClient) Invalidate(monitorID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if key.monitorID == monitorID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Unwrap returns the wrapped client
+func (c *CachingClient) Unwrap() DDCClient {
+	return c.DDCClient
+}
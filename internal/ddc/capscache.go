@@ -0,0 +1,186 @@
+package ddc
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultCapabilitiesTTL is how long a cached capabilities string is trusted.
+// It only changes with a firmware update, which the TTL eventually catches.
+const DefaultCapabilitiesTTL = 7 * 24 * time.Hour
+
+// CapabilitiesCache keeps what monitors reported across runs, keyed by the
+// stable ID from their EDID, since reading a capabilities string takes
+// seconds on most monitors. Monitors without a serial number aren't cached.
+type CapabilitiesCache struct {
+	path string
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]capabilitiesEntry
+}
+
+type capabilitiesEntry struct {
+	Capabilities *Capabilities `json:"capabilities"`
+	At           time.Time     `json:"at"`
+}
+
+// CapabilitiesCacheDir is where the cache lives, ~/.cache/monitorswitch on
+// Linux (honouring XDG_CACHE_HOME)
+func CapabilitiesCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "monitorswitch"), nil
+}
+
+func capabilitiesCachePath() (string, error) {
+	dir, err := CapabilitiesCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "capabilities.json"), nil
+}
+
+// LoadCapabilitiesCache reads the cache; a missing or corrupt file is an
+// empty cache, since everything in it can be read again
+func LoadCapabilitiesCache(ttl time.Duration) (*CapabilitiesCache, error) {
+	path, err := capabilitiesCachePath()
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = DefaultCapabilitiesTTL
+	}
+
+	c := &CapabilitiesCache{path: path, ttl: ttl, entries: make(map[string]capabilitiesEntry)}
+	if data, err := os.ReadFile(path); err == nil {
+		if json.Unmarshal(data, &c.entries) != nil || c.entries == nil {
+			c.entries = make(map[string]capabilitiesEntry)
+		}
+	}
+	return c, nil
+}
+
+// ClearCapabilitiesCache deletes the cache file
+func ClearCapabilitiesCache() error {
+	path, err := capabilitiesCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Get returns the capabilities cached for a stable ID, unless they expired
+func (c *CapabilitiesCache) Get(stableID string) (*Capabilities, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[stableID]
+	if !ok || entry.Capabilities == nil || time.Since(entry.At) > c.ttl {
+		return nil, false
+	}
+	return entry.Capabilities, true
+}
+
+// Put caches capabilities and saves the cache
+func (c *CapabilitiesCache) Put(stableID string, caps *Capabilities) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[stableID] = capabilitiesEntry{Capabilities: caps, At: time.Now()}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// Delete drops the capabilities cached for a stable ID. The file keeps them
+// until the next Put.
+func (c *CapabilitiesCache) Delete(stableID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, stableID)
+}
+
+// SetCapabilitiesCache makes detection and GetCapabilities reuse what
+// monitors reported in earlier runs; nil turns the cache off
+func (c *DDCClientImpl) SetCapabilitiesCache(cache *CapabilitiesCache) {
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+	c.capsCache = cache
+}
+
+// InvalidateCapabilities drops the cached capabilities of a monitor, so the
+// next GetCapabilities reads them from the monitor and caches them again
+func (c *DDCClientImpl) InvalidateCapabilities(monitorID string) {
+	c.capsMu.Lock()
+	cache, stableID := c.capsCache, c.stableIDs[monitorID]
+	c.capsMu.Unlock()
+	if cache != nil && stableID != "" {
+		cache.Delete(stableID)
+	}
+}
+
+// FreshCapabilities reads a monitor's capabilities from the monitor rather
+// than from a cache, for callers that look for changes such as a firmware
+// update, and refreshes the caches with the result. It invalidates the
+// monitor in every wrapper that caches capabilities before asking client.
+func FreshCapabilities(client DDCClient, monitorID string) (*Capabilities, error) {
+	for w := client; w != nil; {
+		if i, ok := w.(interface{ InvalidateCapabilities(string) }); ok {
+			i.InvalidateCapabilities(monitorID)
+		}
+		u, ok := w.(interface{ Unwrap() DDCClient })
+		if !ok {
+			break
+		}
+		w = u.Unwrap()
+	}
+	return client.GetCapabilities(monitorID)
+}
+
+// cachedCapabilities returns the cached capabilities of m, or fetches and
+// caches them. It identifies m from its EDID first, so m needs its
+// connector.
+func (c *DDCClientImpl) cachedCapabilities(m *Monitor, fetch func() (*Capabilities, error)) (*Capabilities, error) {
+	identifyMonitor(m)
+
+	c.capsMu.Lock()
+	cache := c.capsCache
+	if m.StableID != "" {
+		c.stableIDs[m.ID] = m.StableID
+	}
+	c.capsMu.Unlock()
+	if cache == nil || m.StableID == "" {
+		return fetch()
+	}
+
+	if caps, ok := cache.Get(m.StableID); ok {
+		c.logger.Debug("using cached capabilities", "monitor", m.ID, "stable_id", m.StableID)
+		return caps, nil
+	}
+	caps, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if err := cache.Put(m.StableID, caps); err != nil {
+		c.logger.Debug("couldn't save the capabilities cache", "error", err)
+	}
+	return caps, nil
+}
@@ -3,17 +3,46 @@ package ddc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os/exec"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/linux"
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/macos"
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/windows"
 )
 
 // DDCClientImpl implements the DDCClient interface for real DDC communication
 type DDCClientImpl struct {
 	osType OSType
+
+	i2cMu      sync.Mutex
+	i2cScanned bool
+	i2cBuses   []linux.Bus // DDC buses reachable through i2c-dev (Linux)
+
+	preferredTool string // See PreferTool
+
+	virtualOnce sync.Once
+	virtualErr  error // See checkPhysicalAccess
+
+	retry    RetryPolicy   // See SetRetryPolicy
+	logger   *slog.Logger  // See SetLogger
+	commands CommandRunner // See SetCommandRunner
+
+	capsMu    sync.Mutex
+	capsCache *CapabilitiesCache // See SetCapabilitiesCache
+	stableIDs map[string]string  // Monitor ID -> stable ID, for the cache
+
+	settleMu sync.Mutex
+	settle   map[string]SettleDelays // Monitor ID -> delays, see postWriteDelay
+	attempts map[string]int          // Monitor ID -> write attempts from quirks.json
 }
 
 var M1DDCInputSources = map[string]int{
@@ -37,45 +66,106 @@ var DDCCTLInputSources = map[string]int{
 	"USB-C":       27, // Not sure if ddcctl supports this, but we can try
 }
 
-type EnhancedMonitor struct {
-	Monitor
-	DDCSupported    bool            // Whether DDC commands work
-	SupportedInputs map[string]byte // Detected input sources
-	InputNames      []string        // Human-readable input names
-	DDCTool         string          // "ddcctl", "m1ddc", or ""
+// DDCValidationResult is the outcome of checking a monitor really applies
+// DDC/CI writes. ValidationError is set unless CanWriteValues is.
+type DDCValidationResult struct {
+	ToolAvailable     bool   `json:"tool_available"`
+	CanReadValues     bool   `json:"can_read"`
+	CanWriteValues    bool   `json:"can_write"`
+	ValidationError   error  `json:"-"`
+	RecommendedAction string `json:"recommended_action,omitempty"`
 }
 
-type DDCValidationResult struct {
-	ToolAvailable     bool
-	CanReadValues     bool
-	CanWriteValues    bool
-	ValidationError   error
-	RecommendedAction string
+// MarshalJSON includes ValidationError as a string
+func (r DDCValidationResult) MarshalJSON() ([]byte, error) {
+	type plain DDCValidationResult
+	out := struct {
+		plain
+		Error string `json:"error,omitempty"`
+	}{plain: plain(r)}
+	if r.ValidationError != nil {
+		out.Error = r.ValidationError.Error()
+	}
+	return json.Marshal(out)
 }
 
 func NewDDCClientImpl(osType OSType) *DDCClientImpl {
 	return &DDCClientImpl{
-		osType: osType,
+		osType:   osType,
+		retry:    DefaultRetryPolicy,
+		logger:   discardLogger,
+		commands: ExecRunner{},
+
+		stableIDs: make(map[string]string),
 	}
 }
 
 // Detect all DDC-compatible monitors
 func (c *DDCClientImpl) DetectMonitors() ([]Monitor, error) {
+	if err := c.checkPhysicalAccess(); err != nil {
+		c.logger.Debug("skipping monitor detection", "error", err)
+		return nil, err
+	}
+
+	var monitors []Monitor
+	var err error
 	switch c.osType {
-	case OSLinux:
-		return c.detectLinuxMonitors()
+	case OSLinux, OSFreeBSD, OSOpenBSD:
+		monitors, err = c.detectLinuxMonitors()
 	case OSMacOS:
-		return c.detectMacOSMonitors()
+		monitors, err = c.detectMacOSMonitors()
 	case OSWindows:
-		return c.detectWindowsMonitors()
+		monitors, err = c.detectWindowsMonitors()
 	default:
 		return nil, fmt.Errorf("unsupported OS: %s", c.osType)
 	}
+
+	identifyMonitors(monitors)
+	stableIDs := make(map[string]string)
+	for _, m := range monitors {
+		if m.StableID != "" {
+			stableIDs[m.ID] = m.StableID
+		}
+	}
+	c.capsMu.Lock()
+	c.stableIDs = stableIDs
+	c.capsMu.Unlock()
+
+	settle := make(map[string]SettleDelays)
+	attempts := make(map[string]int)
+	for _, m := range monitors {
+		settle[m.ID] = SettleDelaysFor(m)
+		if n := QuirkAttempts(m); n > 0 {
+			attempts[m.ID] = n
+		}
+	}
+	c.settleMu.Lock()
+	c.settle, c.attempts = settle, attempts
+	c.settleMu.Unlock()
+
+	if err != nil {
+		c.logger.Debug("monitor detection failed", "os", c.osType, "error", err)
+	} else {
+		c.logger.Debug("detected monitors", "os", c.osType, "count", len(monitors))
+	}
+	return monitors, err
 }
 
+// GetCapabilities reports what the monitor supports, from the capabilities
+// cache when the monitor was identified during detection
 func (c *DDCClientImpl) GetCapabilities(monitorID string) (*Capabilities, error) {
+	c.capsMu.Lock()
+	m := Monitor{ID: monitorID, StableID: c.stableIDs[monitorID]}
+	c.capsMu.Unlock()
+	if m.StableID == "" {
+		return c.getCapabilities(monitorID)
+	}
+	return c.cachedCapabilities(&m, func() (*Capabilities, error) { return c.getCapabilities(monitorID) })
+}
+
+func (c *DDCClientImpl) getCapabilities(monitorID string) (*Capabilities, error) {
 	switch c.osType {
-	case OSLinux:
+	case OSLinux, OSFreeBSD, OSOpenBSD:
 		return c.getLinuxCapabilities(monitorID)
 	case OSMacOS:
 		return c.getMacOSCapabilities(monitorID)
@@ -86,10 +176,18 @@ func (c *DDCClientImpl) GetCapabilities(monitorID string) (*Capabilities, error)
 	}
 }
 
-// SetVCP sets a VCP feature value (e.g., switch input, set brightness)
+// SetVCP sets a VCP feature value (e.g., switch input, set brightness),
+// reading it back and retrying per the retry policy. It returns an error
+// wrapping ErrVerificationFailed if the monitor never takes the value.
 func (c *DDCClientImpl) SetVCP(monitorID string, code byte, value uint16) error {
+	return c.setVCPVerified(monitorID, code, value, func() error {
+		return c.setVCP(monitorID, code, value)
+	})
+}
+
+func (c *DDCClientImpl) setVCP(monitorID string, code byte, value uint16) error {
 	switch c.osType {
-	case OSLinux:
+	case OSLinux, OSFreeBSD, OSOpenBSD:
 		return c.setLinuxVCP(monitorID, code, value)
 	case OSMacOS:
 		return c.setMacOSVCP(monitorID, code, value)
@@ -100,26 +198,98 @@ func (c *DDCClientImpl) SetVCP(monitorID string, code byte, value uint16) error
 	}
 }
 
+// GetVCP reads the current value of a VCP feature
 func (c *DDCClientImpl) GetVCP(monitorID string, code byte) (uint16, error) {
+	value, err := c.GetVCPValue(monitorID, code)
+	return value.Current, err
+}
+
+// GetVCPValue reads the current and maximum value of a VCP feature
+func (c *DDCClientImpl) GetVCPValue(monitorID string, code byte) (VCPValue, error) {
 	switch c.osType {
-	case OSLinux:
+	case OSLinux, OSFreeBSD, OSOpenBSD:
 		return c.getLinuxVCP(monitorID, code)
 	case OSMacOS:
 		return c.getMacOSVCP(monitorID, code)
 	case OSWindows:
 		return c.getWindowsVCP(monitorID, code)
 	default:
-		return 0, fmt.Errorf("unsupported OS: %s", c.osType)
+		return VCPValue{}, fmt.Errorf("unsupported OS: %s", c.osType)
+	}
+}
+
+// PreferTool selects the backend when several are available: "i2c-dev" or
+// "ddcutil" on Linux, "iokit", "m1ddc" or "ddcctl" on macOS. Empty or
+// unavailable tools leave the automatic choice.
+func (c *DDCClientImpl) PreferTool(tool string) {
+	c.preferredTool = strings.ToLower(tool)
+}
+
+// nativeMacOS reports whether to use the IOKit bridge rather than a CLI tool
+func (c *DDCClientImpl) nativeMacOS() bool {
+	switch c.preferredTool {
+	case "m1ddc", "ddcctl":
+		if _, err := c.commands.LookPath(c.preferredTool); err == nil {
+			return false
+		}
 	}
+	return macos.Available()
+}
+
+// Backend returns the name of the tool or API used to talk to monitors
+func (c *DDCClientImpl) Backend() string {
+	switch c.osType {
+	case OSLinux, OSFreeBSD, OSOpenBSD:
+		if len(c.linuxBuses()) > 0 {
+			return "i2c-dev"
+		}
+		return c.detectAvailableDDCToolsLinux()
+	case OSMacOS:
+		if c.nativeMacOS() {
+			return "IOKit"
+		}
+		return c.detectAvailableDDCTool()
+	case OSWindows:
+		return "dxva2"
+	default:
+		return ""
+	}
+}
+
+// BackendName returns the backend of client, looking through wrappers
+func BackendName(client DDCClient) string {
+	for client != nil {
+		if b, ok := client.(interface{ Backend() string }); ok {
+			return b.Backend()
+		}
+		w, ok := client.(interface{ Unwrap() DDCClient })
+		if !ok {
+			break
+		}
+		client = w.Unwrap()
+	}
+	return ""
 }
 
 // ============ LINUX IMPLEMENTATION ============
+// FreeBSD and OpenBSD use it too. i2c-dev doesn't exist there, so monitors
+// are reached through ddcutil when installed and otherwise only listed.
 
 func (c *DDCClientImpl) detectLinuxMonitors() ([]Monitor, error) {
+	// Talking DDC/CI over i2c-dev directly is much faster than ddcutil.
+	// Every detection rescans, so hotplugged monitors show up.
+	if buses := c.scanI2C(); len(buses) > 0 {
+		c.logger.Debug("detecting monitors over i2c-dev", "buses", len(buses))
+		return c.detectWithI2C(buses), nil
+	}
+
 	if monitors := c.detectWithCLITools(); len(monitors) > 0 {
+		c.logger.Debug("detected monitors with ddcutil")
+		c.annotateWithCompositorOutputs(monitors)
 		return monitors, nil
 	}
 
+	c.logger.Info("no DDC/CI backend found monitors; listing outputs without DDC/CI")
 	return c.detectWithCoreSystem()
 }
 
@@ -138,19 +308,18 @@ func (c *DDCClientImpl) detectWithCLITools() []Monitor {
 }
 
 func (c *DDCClientImpl) detectAvailableDDCToolsLinux() string {
-	if _, err := exec.LookPath("ddcutil"); err != nil {
+	if _, err := c.commands.LookPath("ddcutil"); err == nil {
 		return "ddcutil"
 	}
 
-	if _, err := exec.LookPath("ddccontrol"); err != nil {
+	if _, err := c.commands.LookPath("ddccontrol"); err == nil {
 		return "ddccontrol"
 	}
 	return ""
 }
 
 func (c *DDCClientImpl) detectWithDdcutil() []Monitor {
-	cmd := exec.Command("ddcutil", "detect")
-	output, err := cmd.Output()
+	output, err := c.commands.Output(context.Background(), "ddcutil", "detect")
 	if err != nil {
 		return nil
 	}
@@ -167,30 +336,47 @@ func (c *DDCClientImpl) parseDdcutilDetectOutput(output string) []Monitor {
 	for _, line := range lines {
 		line := strings.TrimSpace(line)
 
-		if strings.HasPrefix(line, "Display ") {
+		// "Invalid display" and "Phantom display" blocks (ddcutil 1.2+) describe
+		// connectors without working DDC; their fields must not leak into the
+		// previous display
+		if strings.HasPrefix(line, "Display ") || strings.HasSuffix(line, " display") {
 			if currentMonitor != nil {
 				monitors = append(monitors, *currentMonitor)
 			}
+			currentMonitor = nil
 
-			re := regexp.MustCompile(`Display (\d+)`)
-			if matches := re.FindStringSubmatch(line); len(matches) > 1 {
+			if matches := ddcutilDisplayPattern.FindStringSubmatch(line); len(matches) > 1 {
+				// ddcutil only numbers displays that answered DDC/CI
 				currentMonitor = &Monitor{
-					ID:     matches[1],
-					Inputs: make(map[string]byte),
+					ID:           matches[1],
+					Inputs:       make(map[string]byte),
+					DDCSupported: true,
+					DDCTool:      "ddcutil",
 				}
 			}
 		}
 
-		if strings.Contains(line, "Mfg id:") && currentMonitor != nil {
-			if mfg := extractField(line, "Mfg id:"); mfg != "" {
+		// Line looks like: "DRM connector:        card1-DP-3" ("DRM_connector:"
+		// since ddcutil 2.0)
+		if strings.HasPrefix(line, "DRM connector:") || strings.HasPrefix(line, "DRM_connector:") {
+			if connector := extractField(line, "connector:"); connector != "" && currentMonitor != nil {
+				currentMonitor.Connector = trimCardPrefix(connector)
+			}
+		}
+
+		// ddcutil 2.0 follows the PNP ID with the vendor: "DEL - Dell Inc."
+		if strings.HasPrefix(line, "Mfg id:") && currentMonitor != nil {
+			if mfg, _, _ := strings.Cut(extractField(line, "Mfg id:"), " - "); mfg != "" {
 				currentMonitor.Name = mfg
 			}
 		}
 
-		if strings.Contains(line, "Model:") && currentMonitor != nil {
+		if strings.HasPrefix(line, "Model:") && currentMonitor != nil {
 			if model := extractField(line, "Model:"); model != "" {
 				if currentMonitor.Name != "" {
 					currentMonitor.Name += " " + model
+				} else {
+					currentMonitor.Name = model
 				}
 			}
 		}
@@ -208,23 +394,37 @@ func (c *DDCClientImpl) parseDdcutilDetectOutput(output string) []Monitor {
 	return monitors
 }
 
+// ddcutilDisplayPattern matches a display header such as "Display 1"
+var ddcutilDisplayPattern = regexp.MustCompile(`^Display (\d+)`)
+
 func extractField(line, fieldName string) string {
-	parts := strings.Split(line, fieldName)
-	if len(parts) < 2 {
+	_, value, found := strings.Cut(line, fieldName)
+	if !found {
 		return ""
 	}
 
-	return strings.TrimSpace(parts[1])
+	return strings.TrimSpace(value)
+}
+
+// trimCardPrefix turns a DRM connector like "card1-DP-3" into "DP-3"
+func trimCardPrefix(connector string) string {
+	if strings.HasPrefix(connector, "card") {
+		if idx := strings.Index(connector, "-"); idx != -1 {
+			return connector[idx+1:]
+		}
+	}
+	return connector
 }
 
 func (c *DDCClientImpl) enhanceLinuxMonitorWithCapabilities(monitor *Monitor) {
-	cmd := exec.Command("ddcutil", "--display", monitor.ID, "capabilities")
-	output, err := cmd.Output()
+	caps, err := c.cachedCapabilities(monitor, func() (*Capabilities, error) {
+		return c.getLinuxCapabilities(monitor.ID)
+	})
 	if err != nil {
 		return
 	}
 
-	monitor.Inputs = c.parseLinuxInputSources(string(output))
+	monitor.Inputs = caps.SupportedInputs
 
 	if currentInput := c.getLinuxCurrentInput(monitor.ID); currentInput != "" {
 		monitor.CurrentInput = currentInput
@@ -244,19 +444,28 @@ func (c *DDCClientImpl) parseLinuxInputSources(capabilities string) map[string]b
 			continue
 		}
 
-		if inInputSection && strings.HasPrefix(line, "Values:") {
-			values := strings.Fields(strings.TrimPrefix(line, "Values: "))
-			for _, hexVal := range values {
-				if code, err := strconv.ParseUint(hexVal, 16, 8); err == nil {
-					inputName := c.linuxInputCodeToName(byte(code))
-					inputs[inputName] = byte(code)
-				}
-			}
+		if !inInputSection {
+			continue
+		}
+		if strings.HasPrefix(line, "Feature:") {
 			break
 		}
 
-		if inInputSection && strings.HasPrefix(line, "Feature:") {
-			break
+		// Values are listed inline ("Values: 0f 11 12") or one per line
+		// below the "Values:" line ("0f: DisplayPort-1")
+		line = strings.TrimSpace(strings.TrimPrefix(line, "Values:"))
+		if code, _, found := strings.Cut(line, ":"); found {
+			if value, err := strconv.ParseUint(code, 16, 8); err == nil {
+				inputs[c.linuxInputCodeToName(byte(value))] = byte(value)
+			}
+			continue
+		}
+		for _, field := range strings.Fields(line) {
+			value, err := strconv.ParseUint(field, 16, 8)
+			if err != nil {
+				break // The rest is a description, e.g. "(interpretation unavailable)"
+			}
+			inputs[c.linuxInputCodeToName(byte(value))] = byte(value)
 		}
 	}
 	return inputs
@@ -285,36 +494,88 @@ func (c *DDCClientImpl) linuxInputCodeToName(code byte) string {
 	}
 }
 
+var (
+	ddcutilContinuousPattern = regexp.MustCompile(`current value\s*=\s*(\d+)\s*,\s*max value\s*=\s*(\d+)`)
+	ddcutilSLPattern         = regexp.MustCompile(`\bsl=0x([0-9A-Fa-f]{2})\b`)
+	ddcutilSHPattern         = regexp.MustCompile(`\bsh=0x([0-9A-Fa-f]{2})\b`)
+	ddcutilMHPattern         = regexp.MustCompile(`\bmh=0x([0-9A-Fa-f]{2})\b`)
+	ddcutilMLPattern         = regexp.MustCompile(`\bml=0x([0-9A-Fa-f]{2})\b`)
+)
+
 func (c *DDCClientImpl) getLinuxCurrentInput(monitorID string) string {
-	// Get current input source value
-	cmd := exec.Command("ddcutil", "--display", monitorID, "getvcp", "60")
-	output, err := cmd.Output()
+	// Older ddcutil versions print the input as a continuous value
+	// ("current value = 17, max value = 255"), newer ones as "(sl=0x11)"
+	value, err := c.getLinuxVCP(monitorID, VCPInputSource)
 	if err != nil {
 		return ""
 	}
+	return c.linuxInputCodeToName(byte(value.Current))
+}
+func (c *DDCClientImpl) detectWithCoreSystem() ([]Monitor, error) {
+	// Wayland compositors with an output IPC (sway, Hyprland, or
+	// wlr-output-management through wlr-randr) know their outputs without X11
+	if monitors, err := c.detectWithCompositorIPC(); err == nil && len(monitors) > 0 {
+		return monitors, nil
+	}
 
-	// Parse output like: "VCP code 0x60 (Input Source): current value = 17, max value = 255"
-	re := regexp.MustCompile(`current value = (\d+)`)
-	if matches := re.FindStringSubmatch(string(output)); len(matches) > 1 {
-		if code, err := strconv.Atoi(matches[1]); err == nil {
-			return c.linuxInputCodeToName(byte(code))
+	// xrandr only lists real outputs in a local X11 session; under Wayland
+	// it sees XWayland's virtual screens, and under VNC/RDP the remote one
+	if DetectSession().CanUseXrandr() {
+		if monitors, err := c.detectWithXrandr(); err == nil && len(monitors) > 0 {
+			return monitors, nil
 		}
 	}
 
-	return ""
-}
-func (c *DDCClientImpl) detectWithCoreSystem() ([]Monitor, error) {
-	// First try xrandr to list monitors
-	if monitors, err := c.detectWithXrandr(); err == nil && len(monitors) > 0 {
+	if monitors, err := c.detectWithDRM(); err == nil && len(monitors) > 0 {
 		return monitors, nil
 	}
 	return []Monitor{}, fmt.Errorf("no monitors detected with core system methods")
 }
 
+// drmClassDirs are where DRM devices show up: sysfs on Linux, and linsysfs
+// on FreeBSD with drm-kmod
+var drmClassDirs = []string{"/sys/class/drm", "/compat/linux/sys/class/drm"}
+
+// drmGlob matches pattern in the first DRM class directory that has matches
+func drmGlob(pattern string) []string {
+	for _, dir := range drmClassDirs {
+		if paths, _ := filepath.Glob(filepath.Join(dir, pattern)); len(paths) > 0 {
+			return paths
+		}
+	}
+	return nil
+}
+
+// detectWithDRM lists the connected DRM connectors from sysfs. It works in
+// any session, including Wayland, remote desktops and a bare console.
+func (c *DDCClientImpl) detectWithDRM() ([]Monitor, error) {
+	paths := drmGlob("card*-*/status")
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no DRM connectors found")
+	}
+
+	var monitors []Monitor
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil || strings.TrimSpace(string(data)) != "connected" {
+			continue
+		}
+
+		// card0-DP-1 -> DP-1
+		_, connector, _ := strings.Cut(filepath.Base(filepath.Dir(path)), "-")
+		monitors = append(monitors, Monitor{
+			ID:        fmt.Sprintf("%d", len(monitors)+1),
+			Name:      connector,
+			Inputs:    make(map[string]byte),
+			Connector: connector,
+		})
+	}
+	return monitors, nil
+}
+
 // Fallback method using xrandr
 func (c *DDCClientImpl) detectWithXrandr() ([]Monitor, error) {
-	cmd := exec.Command("xrandr", "--listmonitors")
-	output, err := cmd.Output()
+	output, err := c.commands.Output(context.Background(), "xrandr", "--listmonitors")
 	if err != nil {
 		return nil, fmt.Errorf("xrandr command failed: %w", err)
 	}
@@ -339,6 +600,7 @@ func (c *DDCClientImpl) parseXrandrOutput(output string) ([]Monitor, error) {
 					Name:         connectionName,
 					Inputs:       make(map[string]byte),
 					CurrentInput: "", // xrandr doesn't provide DDC info
+					Connector:    connectionName,
 				}
 
 				monitors = append(monitors, monitor)
@@ -350,23 +612,128 @@ func (c *DDCClientImpl) parseXrandrOutput(output string) ([]Monitor, error) {
 }
 
 func (c *DDCClientImpl) getLinuxCapabilities(monitorID string) (*Capabilities, error) {
-	// TODO: Implement using ddcutil capabilities
-	// Command: ddcutil --display <id> capabilities
-	return &Capabilities{}, nil
+	if bus, ok := c.i2cBus(monitorID); ok {
+		return c.getI2CCapabilities(bus)
+	}
+
+	output, err := c.commands.Output(context.Background(), "ddcutil", "--display", monitorID, "capabilities")
+	if err != nil {
+		return nil, fmt.Errorf("ddcutil capabilities failed: %w", err)
+	}
+
+	// The raw string has everything; ddcutil's interpretation below is only
+	// used when it couldn't read one
+	if m := regexp.MustCompile(`Unparsed capabilities string:\s*(.+)`).FindStringSubmatch(string(output)); len(m) > 1 {
+		if caps := c.parseMCCSCapabilities(strings.TrimSpace(m[1])); len(caps.Features) > 0 {
+			return caps, nil
+		}
+	}
+
+	caps := &Capabilities{
+		SupportedInputs: c.parseLinuxInputSources(string(output)),
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if model, ok := strings.CutPrefix(line, "Model:"); ok {
+			caps.Model = strings.TrimSpace(model)
+		} else if version, ok := strings.CutPrefix(line, "MCCS version:"); ok {
+			caps.MCCSVersion = strings.TrimSpace(version)
+		}
+	}
+
+	// Feature lines look like: "Feature: 10 (Brightness)"
+	re := regexp.MustCompile(`Feature:\s+([0-9A-Fa-f]{2})\b`)
+	for _, matches := range re.FindAllStringSubmatch(string(output), -1) {
+		code, err := strconv.ParseUint(matches[1], 16, 8)
+		if err != nil {
+			continue
+		}
+		caps.Features = append(caps.Features, byte(code))
+		switch byte(code) {
+		case VCPBrightness:
+			caps.SupportedBrightness = true
+		case VCPContrast:
+			caps.SupportedContrast = true
+		case VCPVolume:
+			caps.SupportedVolume = true
+		case VCPPowerMode:
+			caps.SupportedPower = true
+		}
+	}
+
+	return caps, nil
 }
 
 func (c *DDCClientImpl) setLinuxVCP(monitorID string, code byte, value uint16) error {
-	// TODO: Implement using ddcutil setvcp
-	// Command: ddcutil --display <id> setvcp <code> <value>
-	cmdArgs := []string{"--display", monitorID, "setvcp", fmt.Sprintf("%d", code), fmt.Sprintf("%d", value)}
-	cmd := exec.Command("ddcutil", cmdArgs...)
-	return cmd.Run()
+	if bus, ok := c.i2cBus(monitorID); ok {
+		return c.setI2CVCPs(bus, []VCPWrite{{Code: code, Value: value}})
+	}
+
+	// ddcutil reads feature codes as hex, so "16" would be 0x16
+	cmdArgs := []string{"--display", monitorID, "setvcp", fmt.Sprintf("0x%02x", code), fmt.Sprintf("%d", value)}
+	_, err := c.commands.Output(context.Background(), "ddcutil", cmdArgs...)
+	return err
 }
 
-func (c *DDCClientImpl) getLinuxVCP(monitorID string, code byte) (uint16, error) {
-	// TODO: Implement using ddcutil getvcp
-	// Command: ddcutil --display <id> getvcp <code>
-	return 0, fmt.Errorf("not implemented")
+// setLinuxVCPBatch writes over one i2c-dev session, or passes every
+// feature/value pair to one "ddcutil setvcp"
+func (c *DDCClientImpl) setLinuxVCPBatch(monitorID string, writes []VCPWrite) error {
+	if bus, ok := c.i2cBus(monitorID); ok {
+		return c.setI2CVCPs(bus, writes)
+	}
+
+	cmdArgs := []string{"--display", monitorID, "setvcp"}
+	for _, w := range writes {
+		cmdArgs = append(cmdArgs, fmt.Sprintf("0x%02x", w.Code), fmt.Sprintf("%d", w.Value))
+	}
+	if output, err := c.commands.CombinedOutput(context.Background(), "ddcutil", cmdArgs...); err != nil {
+		return fmt.Errorf("ddcutil setvcp: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *DDCClientImpl) getLinuxVCP(monitorID string, code byte) (VCPValue, error) {
+	if bus, ok := c.i2cBus(monitorID); ok {
+		return c.getI2CVCP(bus, code)
+	}
+
+	output, err := c.commands.CombinedOutput(context.Background(), "ddcutil", "--display", monitorID, "getvcp", fmt.Sprintf("0x%02x", code))
+	if err != nil {
+		return VCPValue{}, fmt.Errorf("ddcutil getvcp: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return parseDdcutilVCP(string(output))
+}
+
+// parseDdcutilVCP reads a "ddcutil getvcp" reply. Continuous features print
+// "current value = 50, max value = 100"; non-continuous ones print the value
+// bytes, "DisplayPort-1 (sl=0x0f)" or "(mh=0x00, ml=0x04, sh=0x00, sl=0x0f)".
+func parseDdcutilVCP(output string) (VCPValue, error) {
+	if m := ddcutilContinuousPattern.FindStringSubmatch(output); m != nil {
+		current, err1 := strconv.ParseUint(m[1], 10, 16)
+		maximum, err2 := strconv.ParseUint(m[2], 10, 16)
+		if err1 == nil && err2 == nil {
+			return VCPValue{Current: uint16(current), Max: uint16(maximum)}, nil
+		}
+	}
+
+	if m := ddcutilSLPattern.FindStringSubmatch(output); m != nil {
+		sl, _ := strconv.ParseUint(m[1], 16, 8)
+		value := VCPValue{Current: uint16(sl)}
+		if sh := ddcutilSHPattern.FindStringSubmatch(output); sh != nil {
+			high, _ := strconv.ParseUint(sh[1], 16, 8)
+			value.Current |= uint16(high) << 8
+		}
+		if mh, ml := ddcutilMHPattern.FindStringSubmatch(output), ddcutilMLPattern.FindStringSubmatch(output); mh != nil && ml != nil {
+			high, _ := strconv.ParseUint(mh[1], 16, 8)
+			low, _ := strconv.ParseUint(ml[1], 16, 8)
+			value.Max = uint16(high)<<8 | uint16(low)
+		}
+		return value, nil
+	}
+
+	if strings.Contains(output, "nsupported") {
+		return VCPValue{}, fmt.Errorf("feature not supported: %s", strings.TrimSpace(output))
+	}
+	return VCPValue{}, fmt.Errorf("could not parse ddcutil output: %q", strings.TrimSpace(output))
 }
 
 // ============ macOS IMPLEMENTATION ============
@@ -374,9 +741,20 @@ func (c *DDCClientImpl) getLinuxVCP(monitorID string, code byte) (uint16, error)
 func (c *DDCClientImpl) detectMacOSMonitors() ([]Monitor, error) {
 	// Try m1ddc first, then ddcctl
 	// the ddcctl and m1ddc are not reliable in detecting monitors on macOS
-	// so we are gonna go with old ways of system_profiler SPDisplaysDataType and
+	// so we are gonna go with CoreGraphics, and the old ways of system_profiler
+	// SPDisplaysDataType when the native bridge isn't compiled in
+	if monitors, err := c.getCoreGraphicsDisplays(); err == nil {
+		return monitors, nil
+	}
 	baseDisplays, err := c.getSystemProfilerDisplays()
 	if err == nil {
+		// Without the native bridge writes go through the command-line
+		// tool; these displays weren't probed, so trust that it's there
+		tool := c.detectAvailableDDCTool()
+		for i := range baseDisplays {
+			baseDisplays[i].DDCTool = tool
+			baseDisplays[i].DDCSupported = tool != ""
+		}
 		return baseDisplays, nil
 	}
 
@@ -388,38 +766,73 @@ func (c *DDCClientImpl) detectMacOSMonitors() ([]Monitor, error) {
 	availableTool := c.detectAvailableDDCTool()
 	for i, display := range baseDisplays {
 		displayNum := i + 1
-		enhancedDisplay := c.enhancedDisplayWithValidation(display, displayNum, availableTool)
-		monitors = append(monitors, enhancedDisplay)
+		monitors = append(monitors, c.enhancedDisplayWithValidation(display, displayNum, availableTool))
 	}
 
 	return monitors, nil
 }
 
-func (c *DDCClientImpl) enhancedDisplayWithValidation(baseDisplay Monitor, displayNum int, tool string) Monitor {
-	enhanced := baseDisplay
-
-	if tool == "" {
-		fmt.Printf("⚠ Display %d (%s): No DDC tools installed\n", displayNum, baseDisplay.Name)
-		return enhanced
+// getCoreGraphicsDisplays enumerates external displays via CGGetOnlineDisplayList.
+// Monitor IDs are CGDirectDisplayIDs, which the native bridge resolves to the
+// matching IOKit service by vendor, model and serial number.
+func (c *DDCClientImpl) getCoreGraphicsDisplays() ([]Monitor, error) {
+	displays, err := macos.Displays()
+	if err != nil {
+		return nil, err
 	}
 
-	validation := c.validateDDCSupport(displayNum, tool)
-	switch {
-	case !validation.CanReadValues:
-		fmt.Printf("x Display %d (%s): %s\n", displayNum, baseDisplay.Name, validation.ValidationError)
-		if validation.RecommendedAction != "" {
-			fmt.Printf("  💡 Suggestion: %s\n", validation.RecommendedAction)
+	var monitors []Monitor
+	for _, display := range displays {
+		monitor := Monitor{
+			ID:     strconv.FormatUint(uint64(display.ID), 10),
+			Name:   display.Name,
+			Inputs: map[string]byte{},
+			UUID:   display.UUID,
+			Main:   display.Main,
+		}
+		if e, err := ParseEDID(display.EDID); err == nil {
+			applyEDID(&monitor, e)
+		} else if display.Serial != 0 {
+			// Apple Silicon doesn't expose the EDID; CoreGraphics has its numbers
+			monitor.Manufacturer = pnpID(uint16(display.VendorID))
+			monitor.Serial = strconv.FormatUint(uint64(display.Serial), 10)
+			monitor.StableID = stableID(monitor.Manufacturer, uint16(display.ModelID), monitor.Serial)
 		}
+		if current, _, err := macos.GetVCP(display.ID, VCPInputSource); err == nil {
+			monitor.CurrentInput = c.linuxInputCodeToName(byte(current))
+			monitor.DDCSupported, monitor.DDCTool = true, "IOKit"
+		}
+		monitors = append(monitors, monitor)
+	}
+	if len(monitors) == 0 {
+		return nil, fmt.Errorf("no external monitors found via CoreGraphics")
+	}
+	return monitors, nil
+}
 
-	case !validation.CanWriteValues:
-		fmt.Printf("⚠ Display %d (%s): Limited DDC support - %s\n", displayNum, baseDisplay.Name, validation.ValidationError)
-		fmt.Printf("  💡 Suggestion: %s\n", validation.RecommendedAction)
+// enhancedDisplayWithValidation probes the display with tool and fills in
+// what it supports. The validation is returned with the monitor rather than
+// printed, so callers decide how to report it.
+func (c *DDCClientImpl) enhancedDisplayWithValidation(baseDisplay Monitor, displayNum int, tool string) Monitor {
+	enhanced := baseDisplay
+	enhanced.DDCTool = tool
+
+	enhanced.Validation = c.validateDDCSupport(displayNum, tool, SettleDelaysFor(baseDisplay).Verify)
+	log := c.logger.With("display", displayNum, "name", baseDisplay.Name, "tool", tool)
+	switch {
+	case !enhanced.Validation.CanReadValues:
+		log.Warn("display doesn't answer DDC/CI", "error", enhanced.Validation.ValidationError,
+			"suggestion", enhanced.Validation.RecommendedAction)
+	case !enhanced.Validation.CanWriteValues:
+		log.Warn("limited DDC/CI support", "error", enhanced.Validation.ValidationError,
+			"suggestion", enhanced.Validation.RecommendedAction)
 		// Still try to get current values for info
 		enhanced = c.addReadOnlyInfo(enhanced, displayNum, tool)
 	default:
-		fmt.Printf("✓ Display %d (%s): Full DDC/CI support\n", displayNum, baseDisplay.Name)
+		log.Debug("full DDC/CI support")
 		// Full enhancement with input detection
 		enhanced = c.addFullDDCInfo(enhanced, displayNum, tool)
+		enhanced.DDCSupported = true
 	}
 	return enhanced
 }
@@ -448,16 +861,16 @@ func (c *DDCClientImpl) getCurrentInputSafe(displayNum int, tool string) (uint16
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	var cmd *exec.Cmd
+	var command []string
 
 	switch tool {
 	case "m1ddc":
-		cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "get", "input")
+		command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "get", "input"}
 	case "ddcctl":
-		cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-i", "?")
+		command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-i", "?"}
 	}
 
-	output, err := cmd.Output()
+	output, err := c.runTool(ctx, command)
 	if err != nil {
 		return 0, err
 	}
@@ -483,24 +896,31 @@ func (c *DDCClientImpl) detectAvailableInputs(displayNum int, tool string) map[s
 }
 
 func (c *DDCClientImpl) detectAvailableDDCTool() string {
-	if _, err := exec.LookPath("m1ddc"); err == nil {
+	if c.preferredTool == "m1ddc" || c.preferredTool == "ddcctl" {
+		if _, err := c.commands.LookPath(c.preferredTool); err == nil {
+			return c.preferredTool
+		}
+	}
+	if _, err := c.commands.LookPath("m1ddc"); err == nil {
 		return "m1ddc"
 	}
-	if _, err := exec.LookPath("ddcctl"); err == nil {
+	if _, err := c.commands.LookPath("ddcctl"); err == nil {
 		return "ddcctl"
 	}
 
-	if _, err := exec.LookPath("ddcutil"); err != nil {
+	if _, err := c.commands.LookPath("ddcutil"); err == nil {
 		return "ddcutil"
 	}
 
-	if _, err := exec.LookPath("ddccontrol"); err != nil {
+	if _, err := c.commands.LookPath("ddccontrol"); err == nil {
 		return "ddccontrol"
 	}
 	return ""
 }
 
-func (c *DDCClientImpl) validateDDCSupport(displayNum int, tool string) *DDCValidationResult {
+// validateDDCSupport checks brightness can be read and written, waiting
+// settle between the test write and reading it back
+func (c *DDCClientImpl) validateDDCSupport(displayNum int, tool string, settle time.Duration) *DDCValidationResult {
 	result := &DDCValidationResult{
 		ToolAvailable: tool != "",
 	}
@@ -520,7 +940,7 @@ func (c *DDCClientImpl) validateDDCSupport(displayNum int, tool string) *DDCVali
 	result.CanReadValues = true
 
 	// Test 2: Can we write brightness and does it actually change?
-	if !c.testWriteBrightness(displayNum, tool, currentBrightness) {
+	if !c.testWriteBrightness(displayNum, tool, currentBrightness, settle) {
 		result.CanWriteValues = false
 		result.ValidationError = fmt.Errorf("DDC commands execute but have no effect")
 		result.RecommendedAction = "VGA/older connections often don't support DDC control. Try HDMI/DisplayPort"
@@ -536,16 +956,16 @@ func (c *DDCClientImpl) testReadBrightness(displayNum int, tool string) (uint16,
 
 	defer cancel()
 
-	var cmd *exec.Cmd
+	var command []string
 
 	switch tool {
 	case "m1ddc":
-		cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "get", "luminance")
+		command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "get", "luminance"}
 	case "ddcctl":
-		cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-b", "?")
+		command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-b", "?"}
 	}
 
-	output, err := cmd.Output()
+	output, err := c.runTool(ctx, command)
 	if err != nil {
 		return 0, err
 	}
@@ -553,7 +973,7 @@ func (c *DDCClientImpl) testReadBrightness(displayNum int, tool string) (uint16,
 	return c.parseVCPValue(string(output), tool, 0x10)
 }
 
-func (c *DDCClientImpl) testWriteBrightness(displayNum int, tool string, originalBrightness uint16) bool {
+func (c *DDCClientImpl) testWriteBrightness(displayNum int, tool string, originalBrightness uint16, settle time.Duration) bool {
 	testValue := originalBrightness + 10
 
 	if testValue > 100 {
@@ -568,7 +988,7 @@ func (c *DDCClientImpl) testWriteBrightness(displayNum int, tool string, origina
 		return false
 	}
 
-	time.Sleep(500 * time.Millisecond)
+	time.Sleep(settle)
 
 	newBrightness, err := c.testReadBrightness(displayNum, tool)
 	if err != nil {
@@ -584,37 +1004,34 @@ func (c *DDCClientImpl) setBrightnessValue(displayNum int, tool string, value ui
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	var cmd *exec.Cmd
+	var command []string
 
 	switch tool {
 	case "m1ddc":
-		cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "set", "luminance", strconv.Itoa(int(value)))
+		command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "set", "luminance", strconv.Itoa(int(value))}
 	case "ddcctl":
-		cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-b", strconv.Itoa(int(value)))
+		command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-b", strconv.Itoa(int(value))}
 	}
 
-	return cmd.Run()
+	_, err := c.runTool(ctx, command)
+	return err
 }
 
 func (c *DDCClientImpl) testInputAvailable(displayNum int, inputCode int, tool string) bool {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 
-	var cmd *exec.Cmd
+	var command []string
 	switch tool {
 	case "ddcctl":
 		// Try to set this input
-		cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-i", strconv.Itoa(inputCode))
+		command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-i", strconv.Itoa(inputCode)}
 	case "m1ddc":
 		// Try to set this input
-		cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "set", "input", strconv.Itoa(inputCode))
+		command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "set", "input", strconv.Itoa(inputCode)}
 	}
 
-	// Suppress output to avoid noise during testing
-	cmd.Stdout = nil
-	cmd.Stderr = nil
-
-	err := cmd.Run()
+	_, err := c.runTool(ctx, command)
 	return err == nil
 }
 
@@ -678,21 +1095,28 @@ type SystemProfilerOutput struct {
 }
 
 func (c *DDCClientImpl) getSystemProfilerDisplays() ([]Monitor, error) {
-	cmd := exec.Command("system_profiler", "SPDisplaysDataType", "-json")
-	output, err := cmd.Output()
+	output, err := c.commands.Output(context.Background(), "system_profiler", "SPDisplaysDataType", "-json")
 	if err != nil {
 		return nil, fmt.Errorf("system_profiler command failed: %v", err)
 	}
+	return c.parseSystemProfilerDisplays(output)
+}
 
+// parseSystemProfilerDisplays reads the external displays from
+// "system_profiler SPDisplaysDataType -json"
+func (c *DDCClientImpl) parseSystemProfilerDisplays(output []byte) ([]Monitor, error) {
+	// Field types vary between macOS releases. The decoder fills in every
+	// field it can before reporting a type mismatch, so only syntax errors
+	// are fatal.
 	var spOutput SystemProfilerOutput
-	err = json.Unmarshal(output, &spOutput)
-	if err != nil {
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal(output, &spOutput); err != nil && !errors.As(err, &typeErr) {
 		return nil, fmt.Errorf("failed to parse system_profiler output: %v", err)
 	}
 	var monitors []Monitor
 	for _, display := range spOutput.SPDisplaysDataType {
 		for _, ndrv := range display.Ndrvs {
-			if ndrv.ConnectionType == "spdisplays_internal" {
+			if ndrv.ConnectionType == "spdisplays_internal" || ndrv.DisplayID == "" {
 				continue
 			} else {
 				monitor := Monitor{
@@ -767,13 +1191,35 @@ func (c *DDCClientImpl) getDisplayName(ndrv struct {
 	return fmt.Sprintf("External Display %s", ndrv.DisplayID)
 }
 
+// getMacOSCapabilities reads the capabilities string over the native bridge.
+// m1ddc and ddcctl can't read it, so nothing is known without the bridge.
 func (c *DDCClientImpl) getMacOSCapabilities(monitorID string) (*Capabilities, error) {
-	// TODO: Implement capabilities detection for macOS
-	return &Capabilities{}, nil
+	if !c.nativeMacOS() {
+		return &Capabilities{}, nil
+	}
+
+	id, err := strconv.ParseUint(monitorID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid monitor ID: %s", monitorID)
+	}
+	raw, err := macos.Capabilities(uint32(id))
+	if err != nil {
+		return nil, err
+	}
+	return c.parseMCCSCapabilities(raw), nil
 }
 
-// SetVCP for macOS with correct command syntax
+// SetVCP for macOS, natively when the IOKit bridge is compiled in (monitor IDs
+// are then CGDirectDisplayIDs) and otherwise through ddcctl or m1ddc
 func (c *DDCClientImpl) setMacOSVCP(monitorID string, code byte, value uint16) error {
+	if c.nativeMacOS() {
+		id, err := strconv.ParseUint(monitorID, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid monitor ID: %s", monitorID)
+		}
+		return macos.SetVCP(uint32(id), code, value)
+	}
+
 	displayNum, err := strconv.Atoi(monitorID)
 	if err != nil {
 		return fmt.Errorf("invalid monitor ID: %s", monitorID)
@@ -787,99 +1233,143 @@ func (c *DDCClientImpl) setMacOSVCP(monitorID string, code byte, value uint16) e
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	var cmd *exec.Cmd
+	var command []string
 	switch tool {
 	case "ddcctl":
 		switch code {
 		case 0x10: // Brightness
-			cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-b", strconv.Itoa(int(value)))
+			command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-b", strconv.Itoa(int(value))}
 		case 0x12: // Contrast
-			cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-c", strconv.Itoa(int(value)))
+			command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-c", strconv.Itoa(int(value))}
 		case 0x60: // Input Source
-			cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-i", strconv.Itoa(int(value)))
+			command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-i", strconv.Itoa(int(value))}
 		case 0x62: // Volume
-			cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-v", strconv.Itoa(int(value)))
+			command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-v", strconv.Itoa(int(value))}
 		default:
 			return fmt.Errorf("unsupported VCP code for ddcctl: 0x%02X", code)
 		}
 	case "m1ddc":
 		switch code {
 		case 0x10: // Brightness (luminance in m1ddc)
-			cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "set", "luminance", strconv.Itoa(int(value)))
+			command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "set", "luminance", strconv.Itoa(int(value))}
 		case 0x12: // Contrast
-			cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "set", "contrast", strconv.Itoa(int(value)))
+			command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "set", "contrast", strconv.Itoa(int(value))}
 		case 0x60: // Input Source
-			cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "set", "input", strconv.Itoa(int(value)))
+			command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "set", "input", strconv.Itoa(int(value))}
 		case 0x62: // Volume
-			cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "set", "volume", strconv.Itoa(int(value)))
+			command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "set", "volume", strconv.Itoa(int(value))}
 		default:
 			return fmt.Errorf("unsupported VCP code for m1ddc: 0x%02X", code)
 		}
 	}
 
-	if err := cmd.Run(); err != nil {
+	if _, err := c.runTool(ctx, command); err != nil {
 		return fmt.Errorf("failed to set VCP 0x%02X to %d: %w", code, value, err)
 	}
 
 	return nil
 }
 
-// GetVCP for macOS with correct command syntax
-func (c *DDCClientImpl) getMacOSVCP(monitorID string, code byte) (uint16, error) {
+// GetVCP for macOS, natively when the IOKit bridge is compiled in and
+// otherwise through ddcctl or m1ddc
+func (c *DDCClientImpl) getMacOSVCP(monitorID string, code byte) (VCPValue, error) {
+	if c.nativeMacOS() {
+		id, err := strconv.ParseUint(monitorID, 10, 32)
+		if err != nil {
+			return VCPValue{}, fmt.Errorf("invalid monitor ID: %s", monitorID)
+		}
+		current, maximum, err := macos.GetVCP(uint32(id), code)
+		if err != nil {
+			return VCPValue{}, err
+		}
+		return VCPValue{Current: current, Max: maximum}, nil
+	}
+
 	displayNum, err := strconv.Atoi(monitorID)
 	if err != nil {
-		return 0, fmt.Errorf("invalid monitor ID: %s", monitorID)
+		return VCPValue{}, fmt.Errorf("invalid monitor ID: %s", monitorID)
 	}
 
 	tool := c.detectAvailableDDCTool()
 	if tool == "" {
-		return 0, fmt.Errorf("no DDC tools available")
+		return VCPValue{}, fmt.Errorf("no DDC tools available")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	var cmd *exec.Cmd
+	var command []string
 	switch tool {
 	case "ddcctl":
 		switch code {
 		case 0x10: // Brightness
-			cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-b", "?")
+			command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-b", "?"}
 		case 0x12: // Contrast
-			cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-c", "?")
+			command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-c", "?"}
 		case 0x60: // Input Source
-			cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-i", "?")
+			command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-i", "?"}
 		case 0x62: // Volume
-			cmd = exec.CommandContext(ctx, "ddcctl", "-d", strconv.Itoa(displayNum), "-v", "?")
+			command = []string{"ddcctl", "-d", strconv.Itoa(displayNum), "-v", "?"}
 		default:
-			return 0, fmt.Errorf("unsupported VCP code for ddcctl: 0x%02X", code)
+			return VCPValue{}, fmt.Errorf("unsupported VCP code for ddcctl: 0x%02X", code)
 		}
 	case "m1ddc":
 		switch code {
 		case 0x10: // Brightness
-			cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "get", "luminance")
+			command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "get", "luminance"}
 		case 0x12: // Contrast
-			cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "get", "contrast")
+			command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "get", "contrast"}
 		case 0x60: // Input Source
-			cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "get", "input")
+			command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "get", "input"}
 		case 0x62: // Volume
-			cmd = exec.CommandContext(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "get", "volume")
+			command = []string{"m1ddc", "display", strconv.Itoa(displayNum), "get", "volume"}
 		}
 	}
 
-	output, err := cmd.Output()
+	output, err := c.runTool(ctx, command)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get VCP 0x%02X: %w", code, err)
+		return VCPValue{}, fmt.Errorf("failed to get VCP 0x%02X: %w", code, err)
 	}
 
 	// Parse the output to extract the value
 	value, err := c.parseVCPValue(string(output), tool, code)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse VCP value from '%s': %w", strings.TrimSpace(string(output)), err)
+		return VCPValue{}, fmt.Errorf("failed to parse VCP value from '%s': %w", strings.TrimSpace(string(output)), err)
 	}
 
-	return value, nil
+	return VCPValue{Current: value, Max: c.macOSVCPMax(displayNum, tool, code, string(output))}, nil
+}
+
+// macOSVCPMax finds the maximum of a continuous feature: ddcctl prints it with
+// the current value, m1ddc needs a separate "max" query. Returns 0 if unknown.
+func (c *DDCClientImpl) macOSVCPMax(displayNum int, tool string, code byte, output string) uint16 {
+	switch tool {
+	case "ddcctl":
+		if m := regexp.MustCompile(`max:\s*(\d+)`).FindStringSubmatch(output); m != nil {
+			if n, err := strconv.ParseUint(m[1], 10, 16); err == nil {
+				return uint16(n)
+			}
+		}
+	case "m1ddc":
+		features := map[byte]string{VCPBrightness: "luminance", VCPContrast: "contrast", VCPVolume: "volume"}
+		feature, ok := features[code]
+		if !ok {
+			return 0
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		out, err := c.commands.Output(ctx, "m1ddc", "display", strconv.Itoa(displayNum), "max", feature)
+		if err != nil {
+			return 0
+		}
+		if n, err := strconv.ParseUint(strings.TrimSpace(string(out)), 10, 16); err == nil {
+			return uint16(n)
+		}
+	}
+	return 0
 }
+
 func (c *DDCClientImpl) parseVCPValue(output, tool string, code byte) (uint16, error) {
 	// Clean up the output
 	output = strings.TrimSpace(output)
@@ -887,21 +1377,26 @@ func (c *DDCClientImpl) parseVCPValue(output, tool string, code byte) (uint16, e
 	switch tool {
 	case "ddcctl":
 		// ddcctl output examples:
+		// "I: VCP control #16 (0x10) = current: 75, max: 100"
 		// "control #16 = 75" (brightness)
 		// "Display 2: brightness = 75"
+		if strings.Contains(output, "failed") || strings.Contains(output, "E: ") {
+			break
+		}
 		patterns := []string{
+			`=\s*current:\s*(\d+)`,
 			`control\s+#\d+\s+=\s+(\d+)`,
 			`brightness\s*=\s*(\d+)`,
 			`contrast\s*=\s*(\d+)`,
 			`volume\s*=\s*(\d+)`,
 			`input\s*=\s*(\d+)`,
-			`(\d+)`, // Fallback: just find any number
+			`^\s*(\d+)\s*$`, // Just a number by itself
 		}
 
 		for _, pattern := range patterns {
-			re := regexp.MustCompile(pattern)
+			re := regexp.MustCompile(`(?m)` + pattern)
 			if matches := re.FindStringSubmatch(output); len(matches) > 1 {
-				value, err := strconv.Atoi(matches[1])
+				value, err := strconv.ParseUint(matches[1], 10, 16)
 				if err == nil {
 					return uint16(value), nil
 				}
@@ -921,9 +1416,9 @@ func (c *DDCClientImpl) parseVCPValue(output, tool string, code byte) (uint16, e
 		}
 
 		for _, pattern := range patterns {
-			re := regexp.MustCompile(pattern)
+			re := regexp.MustCompile(`(?m)` + pattern)
 			if matches := re.FindStringSubmatch(output); len(matches) > 1 {
-				value, err := strconv.Atoi(matches[1])
+				value, err := strconv.ParseUint(matches[1], 10, 16)
 				if err == nil {
 					return uint16(value), nil
 				}
@@ -937,18 +1432,72 @@ func (c *DDCClientImpl) parseVCPValue(output, tool string, code byte) (uint16, e
 // ============ WINDOWS IMPLEMENTATION ============
 
 func (c *DDCClientImpl) detectWindowsMonitors() ([]Monitor, error) {
-	// TODO: Implement Windows monitor detection
-	return []Monitor{}, fmt.Errorf("Windows DDC not implemented yet")
+	physical, err := windows.Monitors()
+	if err != nil {
+		return []Monitor{}, fmt.Errorf("dxva2 monitor enumeration failed: %w", err)
+	}
+
+	var monitors []Monitor
+	for _, pm := range physical {
+		monitor := Monitor{
+			ID:        strconv.Itoa(pm.Index),
+			Name:      pm.Description,
+			Connector: pm.Device,
+			Inputs:    make(map[string]byte),
+		}
+		caps, err := c.cachedCapabilities(&monitor, func() (*Capabilities, error) {
+			return c.getWindowsCapabilities(monitor.ID)
+		})
+		if err == nil {
+			monitor.Inputs = caps.SupportedInputs
+			monitor.DDCSupported, monitor.DDCTool = true, "dxva2"
+		}
+		if value, err := c.getWindowsVCP(monitor.ID, VCPInputSource); err == nil {
+			monitor.CurrentInput = c.linuxInputCodeToName(byte(value.Current))
+			monitor.DDCSupported, monitor.DDCTool = true, "dxva2"
+		}
+		monitors = append(monitors, monitor)
+	}
+	return monitors, nil
+}
+
+// windowsIndex turns a monitor ID into its dxva2 enumeration index
+func windowsIndex(monitorID string) (int, error) {
+	index, err := strconv.Atoi(monitorID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid monitor ID %q", monitorID)
+	}
+	return index, nil
 }
 
 func (c *DDCClientImpl) getWindowsCapabilities(monitorID string) (*Capabilities, error) {
-	return &Capabilities{}, fmt.Errorf("Windows capabilities not implemented yet")
+	index, err := windowsIndex(monitorID)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := windows.Capabilities(index)
+	if err != nil {
+		return nil, err
+	}
+	return c.parseMCCSCapabilities(raw), nil
 }
 
 func (c *DDCClientImpl) setWindowsVCP(monitorID string, code byte, value uint16) error {
-	return fmt.Errorf("Windows VCP setting not implemented yet")
+	index, err := windowsIndex(monitorID)
+	if err != nil {
+		return err
+	}
+	return windows.SetVCP(index, code, uint32(value))
 }
 
-func (c *DDCClientImpl) getWindowsVCP(monitorID string, code byte) (uint16, error) {
-	return 0, fmt.Errorf("Windows VCP getting not implemented yet")
+func (c *DDCClientImpl) getWindowsVCP(monitorID string, code byte) (VCPValue, error) {
+	index, err := windowsIndex(monitorID)
+	if err != nil {
+		return VCPValue{}, err
+	}
+	current, maximum, err := windows.GetVCP(index, code)
+	if err != nil {
+		return VCPValue{}, err
+	}
+	return VCPValue{Current: uint16(current), Max: uint16(maximum)}, nil
 }
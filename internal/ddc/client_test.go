@@ -0,0 +1,312 @@
+package ddc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fastRetries keeps verification failures from sleeping through the default
+// backoff
+var fastRetries = RetryPolicy{Attempts: 3, Backoff: time.Millisecond, Verify: true}
+
+func TestLinuxGetVCPValue(t *testing.T) {
+	tests := []struct {
+		sample  string
+		code    byte
+		want    VCPValue
+		wantErr string
+	}{
+		{sample: "ddcutil-1.4-brightness.txt", code: VCPBrightness, want: VCPValue{Current: 75, Max: 100}},
+		{sample: "ddcutil-1.4-input-continuous.txt", code: VCPInputSource, want: VCPValue{Current: 0x11, Max: 255}},
+		{sample: "ddcutil-2.0-input.txt", code: VCPInputSource, want: VCPValue{Current: 0x0F}},
+		{sample: "ddcutil-2.1-invalid-value.txt", code: VCPInputSource, want: VCPValue{Current: 0x11, Max: 0x12}},
+		{sample: "ddcutil-2.1-unsupported.txt", code: VCPVolume, wantErr: "feature not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sample, func(t *testing.T) {
+			command := fmt.Sprintf("ddcutil --display 1 getvcp 0x%02x", tt.code)
+			c, runner := newReplayClient(OSLinux, map[string]Recording{
+				command: {Output: corpusSample(t, "ddcutil-getvcp/"+tt.sample)},
+			})
+
+			got, err := c.GetVCPValue("1", tt.code)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got %+v, %v; want error %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(runner.Calls, []string{command}) {
+				t.Errorf("ran %q", runner.Calls)
+			}
+		})
+	}
+}
+
+// TestLinuxSetVCP checks the exact ddcutil command lines: ddcutil reads the
+// feature code of setvcp as hex, so a decimal code writes another feature
+func TestLinuxSetVCP(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      byte
+		value     uint16
+		readBack  string
+		wantErr   error
+		wantCalls []string
+	}{
+		{
+			name: "applied", code: VCPBrightness, value: 40,
+			readBack:  "VCP code 0x10 (Brightness): current value =    40, max value =   100",
+			wantCalls: []string{"ddcutil --display 1 setvcp 0x10 40", "ddcutil --display 1 getvcp 0x10"},
+		},
+		{
+			name: "ignored", code: VCPBrightness, value: 40,
+			readBack: "VCP code 0x10 (Brightness): current value =    75, max value =   100",
+			wantErr:  ErrVerificationFailed,
+			wantCalls: []string{
+				"ddcutil --display 1 setvcp 0x10 40", "ddcutil --display 1 getvcp 0x10",
+				"ddcutil --display 1 setvcp 0x10 40", "ddcutil --display 1 getvcp 0x10",
+				"ddcutil --display 1 setvcp 0x10 40", "ddcutil --display 1 getvcp 0x10",
+			},
+		},
+		{
+			name: "unreadable", code: VCPBrightness, value: 40,
+			readBack:  "Display not found",
+			wantCalls: []string{"ddcutil --display 1 setvcp 0x10 40", "ddcutil --display 1 getvcp 0x10"},
+		},
+		{
+			name: "input switch", code: VCPInputSource, value: 0x0F,
+			readBack:  "VCP code 0x60 (Input Source                  ): DisplayPort-1 (sl=0x0f)",
+			wantCalls: []string{"ddcutil --display 1 setvcp 0x60 15", "ddcutil --display 1 getvcp 0x60"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, runner := newReplayClient(OSLinux, map[string]Recording{
+				fmt.Sprintf("ddcutil --display 1 setvcp 0x%02x %d", tt.code, tt.value): {},
+				fmt.Sprintf("ddcutil --display 1 getvcp 0x%02x", tt.code):              {Output: tt.readBack},
+			})
+			c.SetRetryPolicy(fastRetries)
+
+			err := c.SetVCP("1", tt.code, tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(runner.Calls, tt.wantCalls) {
+				t.Errorf("ran %q, want %q", runner.Calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestSetVCPBatchVerifies(t *testing.T) {
+	const (
+		brightness40 = "VCP code 0x10 (Brightness): current value =    40, max value =   100"
+		contrast50   = "VCP code 0x12 (Contrast): current value =    50, max value =   100"
+		contrast75   = "VCP code 0x12 (Contrast): current value =    75, max value =   100"
+	)
+	tests := []struct {
+		name       string
+		osType     OSType
+		recordings map[string]Recording
+		wantErr    error
+		wantCalls  []string
+	}{
+		{
+			name:   "applied",
+			osType: OSLinux,
+			recordings: map[string]Recording{
+				"ddcutil --display 1 setvcp 0x10 40 0x12 50": {},
+				"ddcutil --display 1 getvcp 0x10":            {Output: brightness40},
+				"ddcutil --display 1 getvcp 0x12":            {Output: contrast50},
+			},
+			wantCalls: []string{
+				"ddcutil --display 1 setvcp 0x10 40 0x12 50",
+				"ddcutil --display 1 getvcp 0x10",
+				"ddcutil --display 1 getvcp 0x12",
+			},
+		},
+		{
+			name:   "dropped write retried",
+			osType: OSFreeBSD,
+			recordings: map[string]Recording{
+				"ddcutil --display 1 setvcp 0x10 40 0x12 50": {},
+				"ddcutil --display 1 setvcp 0x12 50":         {},
+				"ddcutil --display 1 getvcp 0x10":            {Output: brightness40},
+				"ddcutil --display 1 getvcp 0x12":            {Output: contrast75},
+			},
+			wantErr: ErrVerificationFailed,
+			wantCalls: []string{
+				"ddcutil --display 1 setvcp 0x10 40 0x12 50",
+				"ddcutil --display 1 getvcp 0x10",
+				"ddcutil --display 1 getvcp 0x12",
+				"ddcutil --display 1 setvcp 0x12 50",
+				"ddcutil --display 1 getvcp 0x12",
+				"ddcutil --display 1 setvcp 0x12 50",
+				"ddcutil --display 1 getvcp 0x12",
+				"ddcutil --display 1 setvcp 0x12 50",
+				"ddcutil --display 1 getvcp 0x12",
+			},
+		},
+		{
+			name:   "batch rejected",
+			osType: OSOpenBSD,
+			recordings: map[string]Recording{
+				"ddcutil --display 1 setvcp 0x10 40": {},
+				"ddcutil --display 1 setvcp 0x12 50": {},
+				"ddcutil --display 1 getvcp 0x10":    {Output: brightness40},
+				"ddcutil --display 1 getvcp 0x12":    {Output: contrast50},
+			},
+			wantCalls: []string{
+				"ddcutil --display 1 setvcp 0x10 40 0x12 50",
+				"ddcutil --display 1 setvcp 0x10 40",
+				"ddcutil --display 1 getvcp 0x10",
+				"ddcutil --display 1 setvcp 0x12 50",
+				"ddcutil --display 1 getvcp 0x12",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, runner := newReplayClient(tt.osType, tt.recordings)
+			c.SetRetryPolicy(fastRetries)
+
+			err := c.SetVCPBatch("1", []VCPWrite{{Code: VCPBrightness, Value: 40}, {Code: 0x12, Value: 50}})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(runner.Calls, tt.wantCalls) {
+				t.Errorf("ran %q, want %q", runner.Calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestLinuxCapabilitiesFromDdcutil(t *testing.T) {
+	c, _ := newReplayClient(OSLinux, map[string]Recording{
+		"ddcutil --display 1 capabilities": {Output: corpusSample(t, "ddcutil-capabilities/ddcutil-1.4-dell-u2720q.txt")},
+	})
+
+	caps, err := c.GetCapabilities("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]byte{"DisplayPort": 0x0F, "HDMI-1": 0x11, "HDMI-2": 0x12}
+	if !reflect.DeepEqual(caps.SupportedInputs, want) {
+		t.Errorf("inputs %v, want %v", caps.SupportedInputs, want)
+	}
+	if !caps.SupportedBrightness || !caps.SupportedPower || caps.SupportedVolume {
+		t.Errorf("unexpected feature support %+v", caps)
+	}
+}
+
+func TestMacOSToolGetSetVCP(t *testing.T) {
+	tests := []struct {
+		tool       string
+		recordings map[string]Recording
+		want       VCPValue
+	}{
+		{
+			tool: "ddcctl",
+			recordings: map[string]Recording{
+				"ddcctl -d 1 -b ?":  {Output: corpusSample(t, "ddcctl/ddcctl-brightness.txt")},
+				"ddcctl -d 1 -b 50": {},
+			},
+			want: VCPValue{Current: 50, Max: 100},
+		},
+		{
+			tool: "m1ddc",
+			recordings: map[string]Recording{
+				"m1ddc display 1 get luminance":    {Output: "50\n"},
+				"m1ddc display 1 max luminance":    {Output: "100\n"},
+				"m1ddc display 1 set luminance 50": {},
+			},
+			want: VCPValue{Current: 50, Max: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			c, runner := newReplayClient(OSMacOS, tt.recordings)
+			c.PreferTool(tt.tool)
+			c.SetRetryPolicy(fastRetries)
+
+			got, err := c.GetVCPValue("1", VCPBrightness)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+
+			runner.Calls = nil
+			if err := c.SetVCP("1", VCPBrightness, 50); err != nil {
+				t.Fatal(err)
+			}
+			if len(runner.Calls) == 0 || !strings.Contains(runner.Calls[0], " 50") {
+				t.Errorf("ran %q", runner.Calls)
+			}
+		})
+	}
+}
+
+func TestMacOSToolReadFailure(t *testing.T) {
+	c, _ := newReplayClient(OSMacOS, map[string]Recording{
+		"ddcctl -d 1 -b ?": {Output: corpusSample(t, "ddcctl/ddcctl-read-failed.txt")},
+	})
+	c.PreferTool("ddcctl")
+
+	if got, err := c.GetVCPValue("1", VCPBrightness); err == nil {
+		t.Fatalf("got %+v from a failed read", got)
+	}
+}
+
+func TestFreshCapabilitiesBypassesCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	cache, err := LoadCapabilitiesCache(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := &Capabilities{Model: "stale", SupportedInputs: map[string]byte{"VGA-1": 0x01}}
+	if err := cache.Put("DEL-41E6-REDACTED123", stale); err != nil {
+		t.Fatal(err)
+	}
+
+	c, runner := newReplayClient(OSLinux, map[string]Recording{
+		"ddcutil --display 1 capabilities": {Output: corpusSample(t, "ddcutil-capabilities/ddcutil-1.4-dell-u2720q.txt")},
+	})
+	c.SetCapabilitiesCache(cache)
+	c.stableIDs["1"] = "DEL-41E6-REDACTED123"
+	client := NewValidatingClient(NewStandbyClient(c))
+
+	if caps, err := client.GetCapabilities("1"); err != nil || caps != stale {
+		t.Fatalf("GetCapabilities = %+v, %v; want the cached capabilities", caps, err)
+	}
+	if len(runner.Calls) != 0 {
+		t.Fatalf("cached read ran %q", runner.Calls)
+	}
+
+	caps, err := FreshCapabilities(client, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps == stale || len(runner.Calls) != 1 {
+		t.Fatalf("FreshCapabilities served the cache (ran %q)", runner.Calls)
+	}
+	if cached, ok := cache.Get("DEL-41E6-REDACTED123"); !ok || cached != caps {
+		t.Errorf("cache holds %+v, want the fresh capabilities", cached)
+	}
+}
@@ -0,0 +1,56 @@
+//go:build !windows && !darwin
+
+package ddc
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLinuxDetectWithDdcutil(t *testing.T) {
+	// Monitors are identified from the EDIDs under testdata/drm, and no
+	// compositor is asked about outputs
+	saved := drmClassDirs
+	drmClassDirs = []string{filepath.Join("testdata", "drm")}
+	t.Cleanup(func() { drmClassDirs = saved })
+	for _, env := range []string{"SWAYSOCK", "HYPRLAND_INSTANCE_SIGNATURE", "WAYLAND_DISPLAY"} {
+		t.Setenv(env, "")
+	}
+
+	c, _ := newReplayClient(OSLinux, map[string]Recording{
+		"ddcutil detect":                   {Output: corpusSample(t, "ddcutil-detect/ddcutil-2.1-two-monitors-phantom.txt")},
+		"ddcutil --display 1 capabilities": {Output: corpusSample(t, "ddcutil-capabilities/ddcutil-1.4-dell-u2720q.txt")},
+		"ddcutil --display 1 getvcp 0x60":  {Output: corpusSample(t, "ddcutil-getvcp/ddcutil-2.0-input.txt")},
+	})
+	c.PreferTool("ddcutil")
+
+	monitors, err := c.DetectMonitors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(monitors) != 2 {
+		t.Fatalf("detected %d monitors, want 2 (the phantom one skipped): %+v", len(monitors), monitors)
+	}
+
+	dell := monitors[0]
+	if dell.ID != "1" || dell.Connector != "DP-1" || dell.Name != "DEL DELL U2723QE" || dell.DDCTool != "ddcutil" {
+		t.Errorf("monitor 1 is %+v", dell)
+	}
+	if want := map[string]byte{"DisplayPort": 0x0F, "HDMI-1": 0x11, "HDMI-2": 0x12}; !reflect.DeepEqual(dell.Inputs, want) {
+		t.Errorf("monitor 1 inputs %v, want %v", dell.Inputs, want)
+	}
+	if dell.CurrentInput != "DisplayPort" {
+		t.Errorf("monitor 1 input %q", dell.CurrentInput)
+	}
+	if dell.StableID != "DEL-41E6-REDACTED123" {
+		t.Errorf("monitor 1 stable ID %q", dell.StableID)
+	}
+
+	// Monitor 2 didn't answer the capabilities request, so it's listed
+	// without inputs rather than dropped
+	samsung := monitors[1]
+	if samsung.ID != "2" || samsung.Connector != "DP-2" || len(samsung.Inputs) != 0 || samsung.StableID != "" {
+		t.Errorf("monitor 2 is %+v", samsung)
+	}
+}
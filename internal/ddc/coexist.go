@@ -0,0 +1,46 @@
+package ddc
+
+import "fmt"
+
+// BrightnessDelegate sets brightness through another running controller
+type BrightnessDelegate interface {
+	Name() string
+	SetBrightness(monitorID string, value uint16) error
+}
+
+// CoexistClient sends brightness writes through another running brightness
+// tool instead of fighting it over DDC/CI. Everything else passes through.
+type CoexistClient struct {
+	DDCClient
+	delegate BrightnessDelegate
+}
+
+// NewCoexistClient wraps client if a cooperating brightness tool is running,
+// and returns client unchanged otherwise
+func NewCoexistClient(client DDCClient) DDCClient {
+	delegate := brightnessDelegate()
+	if delegate == nil {
+		return client
+	}
+	return &CoexistClient{DDCClient: client, delegate: delegate}
+}
+
+// Delegate returns the tool brightness writes are routed through
+func (c *CoexistClient) Delegate() BrightnessDelegate {
+	return c.delegate
+}
+
+func (c *CoexistClient) SetVCP(monitorID string, code byte, value uint16) error {
+	if code != VCPBrightness {
+		return c.DDCClient.SetVCP(monitorID, code, value)
+	}
+	if err := c.delegate.SetBrightness(monitorID, value); err != nil {
+		return fmt.Errorf("%s: %w", c.delegate.Name(), err)
+	}
+	return nil
+}
+
+// Unwrap returns the wrapped client
+func (c *CoexistClient) Unwrap() DDCClient {
+	return c.DDCClient
+}
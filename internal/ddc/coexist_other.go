@@ -0,0 +1,8 @@
+//go:build !windows
+
+package ddc
+
+// brightnessDelegate returns nil: cooperation is only implemented for Windows tools
+func brightnessDelegate() BrightnessDelegate {
+	return nil
+}
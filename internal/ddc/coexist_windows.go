@@ -0,0 +1,63 @@
+//go:build windows
+
+package ddc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// brightnessDelegate returns Twinkle Tray or Monitorian if one is running
+// and its command line interface can be found
+func brightnessDelegate() BrightnessDelegate {
+	for _, a := range RunningArbiters() {
+		switch a.Name {
+		case "Twinkle Tray":
+			path := filepath.Join(os.Getenv("LOCALAPPDATA"), "Programs", "twinkle-tray", "Twinkle Tray.exe")
+			if _, err := os.Stat(path); err == nil {
+				return &cliDelegate{name: a.Name, path: path, args: twinkleTrayArgs}
+			}
+		case "Monitorian":
+			if path, err := exec.LookPath("Monitorian.exe"); err == nil {
+				return &cliDelegate{name: a.Name, path: path, args: monitorianArgs}
+			}
+		}
+	}
+	return nil
+}
+
+// cliDelegate drives a brightness tool through its command line
+type cliDelegate struct {
+	name string
+	path string
+	args func(monitorID string, value uint16) []string
+}
+
+func (d *cliDelegate) Name() string {
+	return d.name
+}
+
+func (d *cliDelegate) SetBrightness(monitorID string, value uint16) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, d.path, d.args(monitorID, value)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, output)
+	}
+	return nil
+}
+
+// twinkleTrayArgs addresses monitors by their 1-based number
+func twinkleTrayArgs(monitorID string, value uint16) []string {
+	return []string{fmt.Sprintf("--MonitorNum=%s", monitorID), fmt.Sprintf("--Set=%d", value)}
+}
+
+// monitorianArgs addresses monitors by device instance ID
+func monitorianArgs(monitorID string, value uint16) []string {
+	return []string{"/set", monitorID, fmt.Sprintf("%d", value)}
+}
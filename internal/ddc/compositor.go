@@ -0,0 +1,253 @@
+package ddc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Compositor identifies a wlroots-style compositor we can talk to over IPC
+type Compositor string
+
+const (
+	CompositorNone     Compositor = ""
+	CompositorSway     Compositor = "sway"
+	CompositorHyprland Compositor = "hyprland"
+	// CompositorWlroots is any other compositor implementing the
+	// wlr-output-management protocol (river, labwc, Wayfire...), queried
+	// with wlr-randr
+	CompositorWlroots Compositor = "wlroots"
+)
+
+// CompositorOutput is an output as reported by the compositor's IPC
+type CompositorOutput struct {
+	Name    string // Output name (e.g., "DP-3")
+	Make    string // Manufacturer (e.g., "Dell Inc.")
+	Model   string // Model (e.g., "DELL U2720Q")
+	Serial  string // Serial number, if the compositor exposes it
+	Active  bool   // Whether the output is enabled
+	Focused bool   // Whether the output currently has focus
+}
+
+// DetectCompositor returns the running compositor based on its IPC environment
+func DetectCompositor() Compositor {
+	if os.Getenv("SWAYSOCK") != "" {
+		return CompositorSway
+	}
+	if os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "" {
+		return CompositorHyprland
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" && !mutterOrKWin() {
+		if _, err := exec.LookPath("wlr-randr"); err == nil {
+			return CompositorWlroots
+		}
+	}
+	return CompositorNone
+}
+
+// mutterOrKWin reports whether the desktop is GNOME or KDE, whose
+// compositors don't implement wlr-output-management
+func mutterOrKWin() bool {
+	switch desktopName(os.Getenv("XDG_CURRENT_DESKTOP"), os.Getenv("DESKTOP_SESSION")) {
+	case "GNOME", "KDE":
+		return true
+	}
+	return false
+}
+
+// CompositorOutputs lists outputs using swaymsg or hyprctl
+func CompositorOutputs() ([]CompositorOutput, error) {
+	switch DetectCompositor() {
+	case CompositorSway:
+		return getSwayOutputs()
+	case CompositorHyprland:
+		return getHyprlandOutputs()
+	case CompositorWlroots:
+		return getWlrOutputs()
+	default:
+		return nil, fmt.Errorf("no supported compositor IPC found")
+	}
+}
+
+func getSwayOutputs() ([]CompositorOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "swaymsg", "-t", "get_outputs", "-r").Output()
+	if err != nil {
+		return nil, fmt.Errorf("swaymsg command failed: %w", err)
+	}
+
+	return parseSwayOutputs(output)
+}
+
+// Example swaymsg -t get_outputs -r entry:
+//
+//	{ "name": "DP-3", "make": "Dell Inc.", "model": "DELL U2720Q",
+//	  "serial": "ABC1234", "active": true, "focused": false, ... }
+func parseSwayOutputs(data []byte) ([]CompositorOutput, error) {
+	var raw []struct {
+		Name    string `json:"name"`
+		Make    string `json:"make"`
+		Model   string `json:"model"`
+		Serial  string `json:"serial"`
+		Active  bool   `json:"active"`
+		Focused bool   `json:"focused"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse swaymsg output: %w", err)
+	}
+
+	outputs := make([]CompositorOutput, 0, len(raw))
+	for _, o := range raw {
+		outputs = append(outputs, CompositorOutput{
+			Name:    o.Name,
+			Make:    o.Make,
+			Model:   o.Model,
+			Serial:  o.Serial,
+			Active:  o.Active,
+			Focused: o.Focused,
+		})
+	}
+	return outputs, nil
+}
+
+func getHyprlandOutputs() ([]CompositorOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "hyprctl", "monitors", "-j").Output()
+	if err != nil {
+		return nil, fmt.Errorf("hyprctl command failed: %w", err)
+	}
+
+	return parseHyprlandOutputs(output)
+}
+
+// Example hyprctl monitors -j entry:
+//
+//	{ "id": 1, "name": "DP-3", "make": "Dell Inc.", "model": "DELL U2720Q",
+//	  "serial": "ABC1234", "focused": true, "disabled": false, ... }
+func parseHyprlandOutputs(data []byte) ([]CompositorOutput, error) {
+	var raw []struct {
+		Name     string `json:"name"`
+		Make     string `json:"make"`
+		Model    string `json:"model"`
+		Serial   string `json:"serial"`
+		Focused  bool   `json:"focused"`
+		Disabled bool   `json:"disabled"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse hyprctl output: %w", err)
+	}
+
+	outputs := make([]CompositorOutput, 0, len(raw))
+	for _, o := range raw {
+		outputs = append(outputs, CompositorOutput{
+			Name:    o.Name,
+			Make:    o.Make,
+			Model:   o.Model,
+			Serial:  o.Serial,
+			Active:  !o.Disabled,
+			Focused: o.Focused,
+		})
+	}
+	return outputs, nil
+}
+
+func getWlrOutputs() ([]CompositorOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "wlr-randr", "--json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("wlr-randr command failed: %w", err)
+	}
+
+	return parseWlrOutputs(output)
+}
+
+// Example wlr-randr --json entry (wlr-randr 0.3+):
+//
+//	{ "name": "DP-3", "description": "Dell Inc. DELL U2720Q ABC1234 (DP-3)",
+//	  "make": "Dell Inc.", "model": "DELL U2720Q", "serial": "ABC1234",
+//	  "enabled": true, ... }
+func parseWlrOutputs(data []byte) ([]CompositorOutput, error) {
+	var raw []struct {
+		Name    string `json:"name"`
+		Make    string `json:"make"`
+		Model   string `json:"model"`
+		Serial  string `json:"serial"`
+		Enabled bool   `json:"enabled"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse wlr-randr output: %w", err)
+	}
+
+	outputs := make([]CompositorOutput, 0, len(raw))
+	for _, o := range raw {
+		outputs = append(outputs, CompositorOutput{
+			Name:   o.Name,
+			Make:   o.Make,
+			Model:  o.Model,
+			Serial: o.Serial,
+			Active: o.Enabled,
+		})
+	}
+	return outputs, nil
+}
+
+// annotateWithCompositorOutputs fills in connector-derived names from the compositor
+func (c *DDCClientImpl) annotateWithCompositorOutputs(monitors []Monitor) {
+	outputs, err := CompositorOutputs()
+	if err != nil {
+		return
+	}
+
+	for i := range monitors {
+		for _, o := range outputs {
+			if monitors[i].Connector == "" || !strings.EqualFold(monitors[i].Connector, o.Name) {
+				continue
+			}
+			if monitors[i].Name == "" {
+				monitors[i].Name = strings.TrimSpace(o.Make + " " + o.Model)
+			}
+		}
+	}
+}
+
+// detectWithCompositorIPC enumerates outputs via sway/Hyprland when ddcutil finds nothing
+func (c *DDCClientImpl) detectWithCompositorIPC() ([]Monitor, error) {
+	outputs, err := CompositorOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	var monitors []Monitor
+	for _, o := range outputs {
+		if !o.Active {
+			continue
+		}
+
+		name := strings.TrimSpace(o.Make + " " + o.Model)
+		if name == "" {
+			name = o.Name
+		}
+
+		monitors = append(monitors, Monitor{
+			ID:        fmt.Sprintf("%d", len(monitors)+1),
+			Name:      name,
+			Inputs:    make(map[string]byte),
+			Connector: o.Name,
+		})
+	}
+
+	if len(monitors) == 0 {
+		return nil, fmt.Errorf("compositor reported no active outputs")
+	}
+	return monitors, nil
+}
@@ -0,0 +1,140 @@
+package ddc
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files from the parsers' current output")
+
+// corpusParsers parse the samples in each testdata/corpus directory. Samples
+// are real tool outputs with serial numbers and names removed; each has a
+// .golden file with what the parser made of it.
+var corpusParsers = map[string]func(data []byte) (any, error){
+	"ddcutil-detect": func(data []byte) (any, error) {
+		c, _ := newReplayClient(OSLinux, nil)
+		monitors := c.parseDdcutilDetectOutput(string(data))
+		for i := range monitors {
+			// The identity comes from the host's sysfs, not from ddcutil
+			m := &monitors[i]
+			m.Manufacturer, m.Model, m.Serial, m.StableID = "", "", "", ""
+		}
+		return monitors, nil
+	},
+	"ddcutil-capabilities": func(data []byte) (any, error) {
+		c, _ := newReplayClient(OSLinux, map[string]Recording{
+			"ddcutil --display 1 capabilities": {Output: string(data)},
+		})
+		caps, err := c.getLinuxCapabilities("1")
+		return goldenCapabilities(caps), err
+	},
+	"ddcutil-getvcp": func(data []byte) (any, error) {
+		return parseDdcutilVCP(string(data))
+	},
+	"m1ddc": func(data []byte) (any, error) {
+		return NewDDCClientImpl(OSMacOS).parseVCPValue(string(data), "m1ddc", 0)
+	},
+	"ddcctl": func(data []byte) (any, error) {
+		return NewDDCClientImpl(OSMacOS).parseVCPValue(string(data), "ddcctl", 0)
+	},
+	"mccs-capabilities": func(data []byte) (any, error) {
+		return goldenCapabilities(NewDDCClientImpl(OSLinux).parseMCCSCapabilities(strings.TrimSpace(string(data)))), nil
+	},
+	"system_profiler": func(data []byte) (any, error) {
+		return NewDDCClientImpl(OSMacOS).parseSystemProfilerDisplays(data)
+	},
+	"edid": func(data []byte) (any, error) {
+		e, err := ParseEDID(data)
+		if err != nil {
+			return nil, err
+		}
+		return struct {
+			*EDID
+			StableID string
+		}{e, e.StableID()}, nil
+	},
+}
+
+// capabilitiesView shows VCP codes in hex rather than as base64 and decimal
+type capabilitiesView struct {
+	*Capabilities
+	Features []string
+	Values   map[string][]uint16
+}
+
+func goldenCapabilities(caps *Capabilities) any {
+	if caps == nil {
+		return nil
+	}
+	view := capabilitiesView{Capabilities: caps, Values: make(map[string][]uint16)}
+	for _, code := range caps.Features {
+		view.Features = append(view.Features, fmt.Sprintf("0x%02X", code))
+	}
+	for code, values := range caps.Values {
+		view.Values[fmt.Sprintf("0x%02X", code)] = values
+	}
+	return view
+}
+
+func TestCorpus(t *testing.T) {
+	var dirs []string
+	for dir := range corpusParsers {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		samples, err := filepath.Glob(filepath.Join("testdata", "corpus", dir, "*"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(samples) == 0 {
+			t.Errorf("no samples in testdata/corpus/%s", dir)
+		}
+		for _, sample := range samples {
+			if filepath.Ext(sample) == ".golden" {
+				continue
+			}
+			golden := strings.TrimSuffix(sample, filepath.Ext(sample)) + ".golden"
+			t.Run(dir+"/"+filepath.Base(sample), func(t *testing.T) {
+				data, err := os.ReadFile(sample)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := renderGolden(corpusParsers[dir](data))
+
+				if *update {
+					if err := os.WriteFile(golden, got, 0o644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("%v (run go test -update to create it)", err)
+				}
+				if string(got) != string(want) {
+					t.Errorf("%s changed:\n--- got\n%s--- want\n%s", golden, got, want)
+				}
+			})
+		}
+	}
+}
+
+// renderGolden is a parser's result as indented JSON, or its error
+func renderGolden(result any, err error) []byte {
+	if err != nil {
+		return []byte("error: " + err.Error() + "\n")
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return []byte("marshal: " + err.Error() + "\n")
+	}
+	return append(data, '\n')
+}
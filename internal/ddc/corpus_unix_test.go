@@ -0,0 +1,25 @@
+//go:build !windows
+
+package ddc
+
+import (
+	"bytes"
+)
+
+func init() {
+	corpusParsers["os-release"] = func(data []byte) (any, error) {
+		var info LinuxInfo
+		err := readOSRelease(bytes.NewReader(data), &info)
+		return info, err
+	}
+	corpusParsers["sw_vers"] = func(data []byte) (any, error) {
+		var info MacOSInfo
+		err := parseSWVersOutput(string(data), &info)
+		return info, err
+	}
+	corpusParsers["system-version-plist"] = func(data []byte) (any, error) {
+		var info MacOSInfo
+		err := readSystemVersionPlist(bytes.NewReader(data), &info)
+		return info, err
+	}
+}
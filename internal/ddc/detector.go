@@ -7,37 +7,52 @@ import (
 	"bufio"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
 
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/linux"
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/macos"
 	"golang.org/x/sys/unix"
 )
 
-func (d *Detector) GetOSInfo() string {
-	// TODO: Return a formatted string with OS info
-	// Example: "Operating System: linux (Ubuntu 22.04)"
+// OSInfo returns the operating system and its details. Failing to read the
+// details is reported in Error rather than failing the whole call.
+func (d *Detector) OSInfo() OSInfo {
+	info := OSInfo{Type: d.osType}
 	switch d.osType {
 	case OSLinux:
-		info, err := d.DetectLinuxInfo()
+		linux, err := d.DetectLinuxInfo()
 		if err != nil {
-			return fmt.Sprintf("Operating System: %s (Error: %v)", d.osType, err)
+			info.Error = err.Error()
+			break
 		}
-		return fmt.Sprintf("Operating System: %s (%s %s)", d.osType, info.Name, info.Version)
+		info.Name, info.Version, info.Linux = linux.Name, linux.Version, linux
 	case OSMacOS:
-		info, err := d.DetectMacOSInfo()
+		macOS, err := d.DetectMacOSInfo()
+		if err != nil {
+			info.Error = err.Error()
+			break
+		}
+		info.Name, info.Version, info.MacOS = macOS.ProductName, macOS.ProductVersion, macOS
+	case OSFreeBSD, OSOpenBSD:
+		bsd, err := d.DetectBSDInfo()
 		if err != nil {
-			return fmt.Sprintf("Operating System: %s (Error: %v)", d.osType, err)
+			info.Error = err.Error()
+			break
 		}
-		return fmt.Sprintf("Operating System: %s (%s %s)", d.osType, info.ProductName, info.ProductVersion)
+		info.Name, info.Version, info.BSD = bsd.Name, bsd.Version, bsd
 	}
-	return ""
+	return info
 }
 
 // CreateDDCClient creates the appropriate DDC client for the current OS
 func (d *Detector) CreateDDCClient() (DDCClient, error) {
-	// TODO: Based on OS type, return appropriate client
-	// For now, return nil and an error saying "not implemented"
+	switch d.osType {
+	case OSLinux, OSMacOS, OSFreeBSD, OSOpenBSD:
+		return d.newClient(), nil
+	}
 	return nil, fmt.Errorf("DDC client not implemented for OS: %s", d.osType)
 }
 
@@ -50,17 +65,28 @@ func (d *Detector) CheckDDCSupport() (bool, string) {
 	// Return (supported, message)
 	switch d.osType {
 	case OSLinux:
+		if linux.Available() {
+			return true, "DDC/CI support detected via i2c-dev"
+		}
 		if _, err := exec.LookPath("ddcutil"); err == nil {
 			return true, "DDC/CI support detected via ddcutil"
 		} else {
 			return false, "ddcutil not found, DDC/CI support may not be available"
 		}
 	case OSMacOS:
+		if macos.Available() {
+			return true, "DDC/CI support detected via IOKit"
+		}
 		if _, err := exec.LookPath("m1ddc"); err == nil {
 			return true, "DDC/CI support detected via m1ddc or ddcctl"
 		} else if _, err := exec.LookPath("ddcctl"); err == nil {
 			return true, "DDC/CI support detected via m1ddc or ddcctl"
 		}
+	case OSFreeBSD, OSOpenBSD:
+		if _, err := exec.LookPath("ddcutil"); err == nil {
+			return true, "DDC/CI support detected via ddcutil"
+		}
+		return false, fmt.Sprintf("ddcutil not found; monitors can be listed but not controlled on %s", d.osType)
 	}
 	return false, "DDC support check not implemented"
 }
@@ -73,7 +99,7 @@ func (d *Detector) DetectLinuxInfo() (*LinuxInfo, error) {
 	info := &LinuxInfo{}
 
 	if err := d.getKernelInfo(info); err != nil {
-		fmt.Printf("Warning: could not get kernel info: %v\n", err)
+		d.logger.Warn("could not get kernel info", "error", err)
 	}
 
 	// Try to get distribution info from various sources
@@ -84,6 +110,42 @@ func (d *Detector) DetectLinuxInfo() (*LinuxInfo, error) {
 	return info, nil
 }
 
+// DetectBSDInfo reports the FreeBSD or OpenBSD release. FreeBSD 13+ has
+// os-release; otherwise the release comes from freebsd-version or uname.
+func (d *Detector) DetectBSDInfo() (*BSDInfo, error) {
+	if !d.osType.IsBSD() {
+		return nil, fmt.Errorf("not running on a BSD")
+	}
+
+	var utsname unix.Utsname
+	if err := unix.Uname(&utsname); err != nil {
+		return nil, err
+	}
+	info := &BSDInfo{
+		Name:          unix.ByteSliceToString(utsname.Sysname[:]),
+		KernelRelease: unix.ByteSliceToString(utsname.Release[:]),
+		Machine:       unix.ByteSliceToString(utsname.Machine[:]),
+	}
+
+	var release LinuxInfo
+	if err := d.parseOSRelease(&release); err == nil {
+		if release.Name != "" {
+			info.Name = release.Name
+		}
+		info.Version = release.Version
+	}
+	if info.Version == "" && d.osType == OSFreeBSD {
+		// The userland can be newer than the kernel after freebsd-update
+		if out, err := exec.Command("freebsd-version", "-u").Output(); err == nil {
+			info.Version = strings.TrimSpace(string(out))
+		}
+	}
+	if info.Version == "" {
+		info.Version = info.KernelRelease
+	}
+	return info, nil
+}
+
 // getKernelInfo uses syscall to get kernel information
 func (d *Detector) getKernelInfo(info *LinuxInfo) error {
 	var utsname unix.Utsname
@@ -129,7 +191,12 @@ func (d *Detector) parseOSRelease(info *LinuxInfo) error {
 
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	return readOSRelease(file, info)
+}
+
+// readOSRelease reads os-release(5) KEY=value lines
+func readOSRelease(r io.Reader, info *LinuxInfo) error {
+	scanner := bufio.NewScanner(r)
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -143,8 +210,7 @@ func (d *Detector) parseOSRelease(info *LinuxInfo) error {
 		}
 
 		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-		value = strings.Trim(value, `"'`)
+		value := unquoteShell(strings.TrimSpace(parts[1]))
 
 		switch key {
 		case "NAME":
@@ -178,6 +244,29 @@ func (d *Detector) parseOSRelease(info *LinuxInfo) error {
 	return nil
 }
 
+// unquoteShell decodes an os-release style value: single quotes are literal,
+// double quotes allow backslash escapes of $"\` and the backslash itself
+func unquoteShell(value string) string {
+	if len(value) < 2 {
+		return value
+	}
+	switch {
+	case value[0] == '\'' && value[len(value)-1] == '\'':
+		return value[1 : len(value)-1]
+	case value[0] == '"' && value[len(value)-1] == '"':
+		var b strings.Builder
+		inner := value[1 : len(value)-1]
+		for i := 0; i < len(inner); i++ {
+			if inner[i] == '\\' && i+1 < len(inner) && strings.IndexByte("$\"\\`", inner[i+1]) >= 0 {
+				i++
+			}
+			b.WriteByte(inner[i])
+		}
+		return b.String()
+	}
+	return value
+}
+
 func (d *Detector) parseLSBRelease(info *LinuxInfo) error {
 	file, err := os.Open("/etc/lsb-release")
 
@@ -187,7 +276,12 @@ func (d *Detector) parseLSBRelease(info *LinuxInfo) error {
 
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	return readLSBRelease(file, info)
+}
+
+// readLSBRelease reads /etc/lsb-release DISTRIB_* lines
+func readLSBRelease(r io.Reader, info *LinuxInfo) error {
+	scanner := bufio.NewScanner(r)
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -201,8 +295,7 @@ func (d *Detector) parseLSBRelease(info *LinuxInfo) error {
 		}
 
 		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-		value = strings.Trim(value, `"'`)
+		value := unquoteShell(strings.TrimSpace(parts[1]))
 		switch key {
 		case "DISTRIB_ID":
 			info.ID = strings.ToLower(value)
@@ -315,7 +408,7 @@ func (d *Detector) DetectMacOSInfo() (*MacOSInfo, error) {
 
 	// Get system information using sysctl
 	if err := d.getMacOSSystemInfo(info); err != nil {
-		fmt.Printf("Warning: could not get kernel info: %v\n", err)
+		d.logger.Warn("could not get kernel info", "error", err)
 	}
 
 	if err := d.getMacOSSystemInfo(info); err != nil {
@@ -359,7 +452,12 @@ func (d *Detector) parseSWVers(info *MacOSInfo) error {
 		return fmt.Errorf("failed")
 	}
 
-	lines := strings.Split(string(output), "\n")
+	return parseSWVersOutput(string(output), info)
+}
+
+// parseSWVersOutput reads "ProductName: macOS" lines
+func parseSWVersOutput(output string, info *MacOSInfo) error {
+	lines := strings.Split(output, "\n")
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 		if line == "" {
@@ -420,6 +518,39 @@ type Dict struct {
 	Values []string `xml:"string"`
 }
 
+// UnmarshalXML keeps Keys and Values aligned when a dict holds non-string
+// values (<true/>, <integer>, nested dicts), which get an empty value
+func (d *Dict) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var text string
+			if t.Name.Local == "key" || t.Name.Local == "string" {
+				if err := dec.DecodeElement(&text, &t); err != nil {
+					return err
+				}
+			} else if err := dec.Skip(); err != nil {
+				return err
+			}
+
+			if t.Name.Local == "key" {
+				d.Keys = append(d.Keys, text)
+			} else if len(d.Values) < len(d.Keys) {
+				d.Values = append(d.Values, text)
+			}
+		case xml.EndElement:
+			for len(d.Values) < len(d.Keys) {
+				d.Values = append(d.Values, "")
+			}
+			return nil
+		}
+	}
+}
+
 // its called xml unmarshaling or XML deserialization
 
 // parseSystemVersionPlist parses /System/Library/CoreServices/SystemVersion.plist
@@ -432,8 +563,13 @@ func (d *Detector) parseSystemVersionPlist(info *MacOSInfo) error {
 
 	defer file.Close()
 
+	return readSystemVersionPlist(file, info)
+}
+
+// readSystemVersionPlist decodes a SystemVersion.plist
+func readSystemVersionPlist(r io.Reader, info *MacOSInfo) error {
 	var plist SystemVersionPlist
-	decoder := xml.NewDecoder(file)
+	decoder := xml.NewDecoder(r)
 	if err := decoder.Decode(&plist); err != nil {
 		return fmt.Errorf("failed to parse SystemVersion.plist: %w", err)
 	}
@@ -483,7 +619,13 @@ func (d *Detector) parseSystemProfiler(info *MacOSInfo) error {
 		return fmt.Errorf("system_profiler command failed: %w", err)
 	}
 
-	lines := strings.Split(string(output), "\n")
+	parseHardwareOverview(string(output), info)
+	return nil
+}
+
+// parseHardwareOverview reads the model from system_profiler SPHardwareDataType
+func parseHardwareOverview(output string, info *MacOSInfo) {
+	lines := strings.Split(output, "\n")
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 
@@ -503,7 +645,6 @@ func (d *Detector) parseSystemProfiler(info *MacOSInfo) error {
 			}
 		}
 	}
-	return nil
 }
 
 // parseSystemctl uses sysctl to get model information
@@ -517,7 +658,7 @@ func (d *Detector) parseSystemctl(info *MacOSInfo) {
 
 // Monitor detection methods
 func (d *Detector) DetectMonitors() ([]Monitor, error) {
-	client := NewDDCClientImpl(d.osType)
+	client := d.newClient()
 
 	return client.DetectMonitors()
 }
@@ -0,0 +1,87 @@
+//go:build !windows
+
+package ddc
+
+import (
+	"strings"
+	"testing"
+)
+
+func FuzzReadOSRelease(f *testing.F) {
+	f.Add("NAME=\"Ubuntu\"\nVERSION=\"24.04.1 LTS (Noble Numbat)\"\nID=ubuntu\nVERSION_ID=\"24.04\"\nPRETTY_NAME=\"Ubuntu 24.04.1 LTS\"\nUBUNTU_CODENAME=noble\n")
+	f.Add("NAME='Fedora Linux'\nID=fedora\n# comment\nVERSION_CODENAME=\"\"\n")
+	f.Add("PRETTY_NAME=\"a \\\"quoted\\\" \\$name\\\\\"\n")
+	f.Add("NAME=\"\nID='\n=\n")
+	f.Add(strings.Repeat("A", 70000) + "=x\n")
+	f.Fuzz(func(t *testing.T, content string) {
+		var info LinuxInfo
+		if err := readOSRelease(strings.NewReader(content), &info); err == nil && info.Name == "" && info.ID == "" && info.PrettyName == "" {
+			t.Fatal("success without information")
+		}
+	})
+}
+
+func FuzzReadLSBRelease(f *testing.F) {
+	f.Add("DISTRIB_ID=Ubuntu\nDISTRIB_RELEASE=24.04\nDISTRIB_CODENAME=noble\nDISTRIB_DESCRIPTION=\"Ubuntu 24.04.1 LTS\"\n")
+	f.Add("DISTRIB_ID=\nDISTRIB_DESCRIPTION='\n")
+	f.Fuzz(func(t *testing.T, content string) {
+		var info LinuxInfo
+		readLSBRelease(strings.NewReader(content), &info)
+	})
+}
+
+func FuzzUnquoteShell(f *testing.F) {
+	f.Add(`"Ubuntu 24.04"`)
+	f.Add(`'single $quoted'`)
+	f.Add(`"escaped \" \$ \\ \` + "`" + ` and \n kept"`)
+	f.Add(`"\"`)
+	f.Add(`"`)
+	f.Fuzz(func(t *testing.T, value string) {
+		unquoted := unquoteShell(value)
+		if len(unquoted) > len(value) {
+			t.Fatalf("unquoting %q grew it to %q", value, unquoted)
+		}
+	})
+}
+
+func FuzzParseSWVersOutput(f *testing.F) {
+	f.Add("ProductName:\t\tmacOS\nProductVersion:\t\t14.4.1\nBuildVersion:\t\t23E224\n")
+	f.Add("ProductName:\tMac OS X\nProductVersion:\t10.15.7\n")
+	f.Add(":\n::\n")
+	f.Fuzz(func(t *testing.T, output string) {
+		var info MacOSInfo
+		parseSWVersOutput(output, &info)
+	})
+}
+
+func FuzzReadSystemVersionPlist(f *testing.F) {
+	f.Add(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>ProductBuildVersion</key>
+	<string>23E224</string>
+	<key>ProductName</key>
+	<string>macOS</string>
+	<key>ProductVersion</key>
+	<string>14.4.1</string>
+</dict>
+</plist>`)
+	f.Add(`<plist><dict><key>ProductName</key></dict></plist>`)
+	f.Add(`<plist><dict><key>`)
+	f.Fuzz(func(t *testing.T, content string) {
+		var info MacOSInfo
+		if err := readSystemVersionPlist(strings.NewReader(content), &info); err == nil && info.ProductName == "" && info.ProductVersion == "" {
+			t.Fatal("success without information")
+		}
+	})
+}
+
+func FuzzParseHardwareOverview(f *testing.F) {
+	f.Add("Hardware:\n\n    Hardware Overview:\n\n      Model Name: MacBook Pro\n      Model Identifier: Mac14,9\n      Chip: Apple M2 Pro\n")
+	f.Add("Model Name:\nModel Identifier")
+	f.Fuzz(func(t *testing.T, output string) {
+		var info MacOSInfo
+		parseHardwareOverview(output, &info)
+	})
+}
@@ -1,35 +1,36 @@
 package ddc
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"os/exec"
 	"regexp"
-	"runtime"
 	"strings"
+	"time"
 
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/windows"
 	"golang.org/x/sys/windows/registry"
 )
 
-func (d *Detector) GetOSInfo() string {
-	// TODO: Return a formatted string with OS info
-	// Example: "Operating System: linux (Ubuntu 22.04)"
-	switch d.osType {
-	case OSWindows:
-		info, err := d.DetectWindowsInfo()
-		if err != nil {
-			return fmt.Sprintf("Operating System: %s (Error: %v)", d.osType, err)
-		}
-
-		return fmt.Sprintf("Operating System: %s (%s %s)", d.osType, info.ProductName, info.Version)
+// OSInfo returns the operating system and its details. Failing to read the
+// details is reported in Error rather than failing the whole call.
+func (d *Detector) OSInfo() OSInfo {
+	info := OSInfo{Type: d.osType}
+	windows, err := d.DetectWindowsInfo()
+	if err != nil {
+		info.Error = err.Error()
+		return info
 	}
-	return ""
+	info.Name, info.Version, info.Windows = windows.ProductName, windows.Version, windows
+	return info
 }
 
 // CreateDDCClient creates the appropriate DDC client for the current OS
 func (d *Detector) CreateDDCClient() (DDCClient, error) {
-	// TODO: Based on OS type, return appropriate client
-	// For now, return nil and an error saying "not implemented"
+	switch d.osType {
+	case OSWindows:
+		return d.newClient(), nil
+	}
 	return nil, fmt.Errorf("DDC client not implemented for OS: %s", d.osType)
 }
 func (d *Detector) CheckDDCSupport() (bool, string) {
@@ -41,6 +42,10 @@ func (d *Detector) CheckDDCSupport() (bool, string) {
 }
 
 func (d *Detector) checkWindowsDDCSupport() (bool, string) {
+	if monitors, err := windows.Monitors(); err == nil && len(monitors) > 0 {
+		return true, "DDC/CI support detected via dxva2"
+	}
+
 	if _, err := exec.LookPath("ddccci"); err == nil {
 		return false, "DDC/CI support detected via ddccci"
 	}
@@ -57,13 +62,7 @@ func (d *Detector) DetectMonitors() ([]Monitor, error) {
 	if d.osType != OSWindows {
 		return []Monitor{}, fmt.Errorf("not running on Windows")
 	}
-	// Check if any DDC tool is available
-	if supported, _ := d.checkWindowsDDCSupport(); !supported {
-		return []Monitor{}, fmt.Errorf("no DDC/CI tools available")
-	}
-
-	// Placeholder - will implement real detection later
-	return []Monitor{}, nil
+	return d.newClient().DetectMonitors()
 }
 
 func (d *Detector) DetectWindowsInfo() (*WindowsInfo, error) {
@@ -77,10 +76,15 @@ func (d *Detector) DetectWindowsInfo() (*WindowsInfo, error) {
 		return info, nil
 	}
 
+	if err := d.parseCIM(info); err == nil {
+		return info, nil
+	}
+
 	if err := d.parseSystemInfo(info); err == nil {
 		return info, nil
 	}
 
+	// wmic is deprecated and missing from recent Windows 11 builds
 	if err := d.parseWMI(info); err == nil {
 		return info, nil
 	}
@@ -152,58 +156,47 @@ func (d *Detector) parseWindowsRegistry(info *WindowsInfo) error {
 
 }
 
+// parseSystemInfo reads systeminfo's CSV output, which is in the OEM code page
 func (d *Detector) parseSystemInfo(info *WindowsInfo) error {
-	cmd := exec.Command("systeminfo")
+	cmd := exec.Command("systeminfo", "/fo", "csv", "/nh")
 	output, err := cmd.Output()
 
 	if err != nil {
 		return fmt.Errorf("systeminfo command failed: %w", err)
 	}
+	return parseSystemInfoCSV(decodeCodePage(consoleCodePage(), output), info)
+}
 
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		parts := strings.SplitN(line, ":", 2)
-		if len(parts) != 2 {
-			continue
-		}
-
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-
-		switch key {
-		case "OS Name":
-			info.ProductName = value
-		case "OS Version":
-			// Parse version like "10.0.22000 N/A Build 22000"
-			if matches := regexp.MustCompile(`(\d+\.\d+\.\d+).*Build (\d+)`).FindStringSubmatch(value); len(matches) >= 3 {
-				info.Version = matches[1]
-				info.Build = matches[2]
-			}
-		case "System Type":
-			info.Architecture = value
-		case "Original Install Date":
-			info.InstallDate = value
-		case "Registered Owner":
-			info.RegisteredOwner = value
-		case "Windows Directory":
-			info.SystemRoot = value
-		}
-	}
-
-	// Verify we got at least some information
-	if info.ProductName == "" && info.Version == "" {
-		return fmt.Errorf("no useful information from systeminfo")
+// cimOSScript selects the Win32_OperatingSystem fields as JSON. InstallDate
+// is formatted in PowerShell, since ConvertTo-Json renders dates differently
+// across versions, and the output is forced to UTF-8 so translated captions
+// survive the OEM code page.
+const cimOSScript = `[Console]::OutputEncoding = [Text.Encoding]::UTF8
+$os = Get-CimInstance -ClassName Win32_OperatingSystem
+[pscustomobject]@{
+  Caption = $os.Caption
+  Version = $os.Version
+  BuildNumber = $os.BuildNumber
+  OSArchitecture = $os.OSArchitecture
+  InstallDate = $os.InstallDate.ToString('o', [Globalization.CultureInfo]::InvariantCulture)
+  RegisteredUser = $os.RegisteredUser
+  WindowsDirectory = $os.WindowsDirectory
+} | ConvertTo-Json -Compress`
+
+// parseCIM queries Win32_OperatingSystem through PowerShell's Get-CimInstance
+func (d *Detector) parseCIM(info *WindowsInfo) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", cimOSScript).Output()
+	if err != nil {
+		return fmt.Errorf("Get-CimInstance failed: %w", err)
 	}
 
-	return nil
+	return parseCIMOutput(output, info)
 }
 
-// parseWMI runs a WMI query and parses its output
+// parseWMI runs a WMI query through the legacy wmic tool and parses its output
 func (d *Detector) parseWMI(info *WindowsInfo) error {
 	cmd := exec.Command("wmic", "os", "get", "Caption,Version,BuildNumber,OSArchitecture", "/format:list")
 	output, err := cmd.Output()
@@ -212,40 +205,7 @@ func (d *Detector) parseWMI(info *WindowsInfo) error {
 		return fmt.Errorf("wmic command failed: %w", err)
 	}
 
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		// Lines look like: "Caption=Microsoft Windows 11 Pro"
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
-			continue
-		}
-
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-
-		switch key {
-		case "Caption":
-			info.ProductName = value
-		case "Version":
-			info.Version = value
-		case "BuildNumber":
-			info.Build = value
-		case "OSArchitecture":
-			info.Architecture = value
-		}
-	}
-
-	// Verify we got at least some information
-	if info.ProductName == "" && info.Version == "" {
-		return fmt.Errorf("no useful information from WMI")
-	}
-
-	return nil
+	return parseWMIOutput(decodeCodePage(consoleCodePage(), output), info)
 }
 
 // parseVerCommand runs the "ver" command and parses its output
@@ -256,8 +216,10 @@ func (d *Detector) parseVerCommand(info *WindowsInfo) error {
 		return fmt.Errorf("ver command failed: %w", err)
 	}
 
+	// "Microsoft Windows [Version 10.0.22631.2861]"; the word "Version" is
+	// translated on some languages, so only the bracketed number is matched
 	line := strings.TrimSpace(string(output))
-	if matches := regexp.MustCompile(`Microsoft Windows \[Version ([^\]]+)\]`).FindStringSubmatch(line); len(matches) >= 2 {
+	if matches := regexp.MustCompile(`\[[^\]\d]*(\d+(?:\.\d+)+)\]`).FindStringSubmatch(line); len(matches) >= 2 {
 		info.Version = matches[1]
 		info.ProductName = "Microsoft Windows"
 
@@ -275,15 +237,7 @@ func (d *Detector) parseVerCommand(info *WindowsInfo) error {
 }
 
 func (d *Detector) getWindowsArchitecture() string {
-	if arch := os.Getenv("PROCESSOR_ARCHITECTURE"); arch != "" {
-		return arch
-	}
-
-	if arch := os.Getenv("PROCESSOR_ARCHITEW6432"); arch != "" {
-		return arch
-	}
-
-	switch runtime.GOARCH {
+	switch arch := DetectArchitecture().Native; arch {
 	case "amd64":
 		return "AMD64"
 	case "386":
@@ -293,6 +247,6 @@ func (d *Detector) getWindowsArchitecture() string {
 	case "arm":
 		return "ARM"
 	default:
-		return runtime.GOARCH
+		return arch
 	}
 }
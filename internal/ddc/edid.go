@@ -0,0 +1,147 @@
+package ddc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// edidHeader starts every EDID base block
+var edidHeader = []byte{0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00}
+
+// EDID is the identity part of a monitor's EDID base block
+type EDID struct {
+	Manufacturer string // Three-letter PNP ID, e.g. "DEL"
+	ProductCode  uint16 // Manufacturer's product code
+	SerialNumber uint32 // Numeric serial, 0 if unset
+	Serial       string // Serial number descriptor (0xFF), often the one on the label
+	Model        string // Monitor name descriptor (0xFC)
+	Week, Year   int    // Manufacture date; Week is 0 if unknown
+}
+
+// ParseEDID reads the identity fields from a raw EDID. Only the 128-byte base
+// block is used; extension blocks are ignored.
+func ParseEDID(data []byte) (*EDID, error) {
+	if len(data) < 128 {
+		return nil, fmt.Errorf("EDID too short (%d bytes)", len(data))
+	}
+	if !bytes.Equal(data[:8], edidHeader) {
+		return nil, errors.New("not an EDID: bad header")
+	}
+	var sum byte
+	for _, b := range data[:128] {
+		sum += b
+	}
+	if sum != 0 {
+		return nil, errors.New("EDID checksum mismatch")
+	}
+
+	e := &EDID{
+		Manufacturer: pnpID(binary.BigEndian.Uint16(data[8:10])),
+		ProductCode:  binary.LittleEndian.Uint16(data[10:12]),
+		SerialNumber: binary.LittleEndian.Uint32(data[12:16]),
+		Year:         1990 + int(data[17]),
+	}
+	if data[16] <= 54 {
+		e.Week = int(data[16])
+	}
+
+	// Four 18-byte descriptors; display descriptors start with three zero bytes
+	for slot := 54; slot <= 108; slot += 18 {
+		d := data[slot : slot+18]
+		if d[0] != 0 || d[1] != 0 || d[2] != 0 {
+			continue
+		}
+		switch d[3] {
+		case 0xFC:
+			e.Model = descriptorText(d[5:])
+		case 0xFF:
+			e.Serial = descriptorText(d[5:])
+		}
+	}
+	return e, nil
+}
+
+// pnpID decodes a manufacturer ID: three 5-bit letters, "A" being 1
+func pnpID(id uint16) string {
+	return string([]byte{
+		byte('A' - 1 + (id>>10)&0x1F),
+		byte('A' - 1 + (id>>5)&0x1F),
+		byte('A' - 1 + id&0x1F),
+	})
+}
+
+// descriptorText decodes a 13-byte descriptor string, terminated by a newline
+// and padded with spaces
+func descriptorText(b []byte) string {
+	text, _, _ := strings.Cut(string(b), "\n")
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7E {
+			return -1
+		}
+		return r
+	}, text))
+}
+
+// SerialString is the serial number descriptor, or the numeric serial when
+// the monitor has no descriptor. Empty if the monitor reports neither.
+func (e *EDID) SerialString() string {
+	if e.Serial != "" {
+		return e.Serial
+	}
+	if e.SerialNumber != 0 {
+		return fmt.Sprintf("%d", e.SerialNumber)
+	}
+	return ""
+}
+
+// StableID identifies one physical monitor across reboots, ports and
+// backends, e.g. "DEL-A0B1-8M2TZ13". It is empty for monitors without a
+// serial number, since two units of the same model can't be told apart.
+func (e *EDID) StableID() string {
+	return stableID(e.Manufacturer, e.ProductCode, e.SerialString())
+}
+
+func stableID(manufacturer string, product uint16, serial string) string {
+	if serial == "" || manufacturer == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%04X-%s", manufacturer, product, strings.ReplaceAll(serial, " ", "_"))
+}
+
+// applyEDID fills in the monitor's identity from its EDID. A backend's name
+// is kept unless it is a placeholder like "Generic PnP Monitor".
+func applyEDID(m *Monitor, e *EDID) {
+	m.Manufacturer = e.Manufacturer
+	m.Model = e.Model
+	m.Serial = e.SerialString()
+	m.StableID = e.StableID()
+	if e.Model != "" && genericMonitorName(m.Name) {
+		m.Name = e.Model
+	}
+}
+
+func genericMonitorName(name string) bool {
+	lower := strings.ToLower(name)
+	return name == "" || strings.Contains(lower, "generic") || strings.HasPrefix(lower, "display ") ||
+		strings.HasPrefix(lower, "external display")
+}
+
+// identifyMonitors reads and applies each monitor's EDID where the OS exposes it
+func identifyMonitors(monitors []Monitor) {
+	for i := range monitors {
+		identifyMonitor(&monitors[i])
+	}
+}
+
+// identifyMonitor fills in one monitor's identity, unless it has one
+func identifyMonitor(m *Monitor) {
+	if m.StableID != "" {
+		return
+	}
+	if e, err := ParseEDID(monitorEDID(*m)); err == nil {
+		applyEDID(m, e)
+	}
+}
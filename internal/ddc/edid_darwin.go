@@ -0,0 +1,26 @@
+package ddc
+
+import (
+	"strconv"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/macos"
+)
+
+// monitorEDID returns the EDID IOKit reports for the display, which Intel
+// Macs expose and Apple Silicon doesn't
+func monitorEDID(m Monitor) []byte {
+	id, err := strconv.ParseUint(m.ID, 10, 32)
+	if err != nil {
+		return nil
+	}
+	displays, err := macos.Displays()
+	if err != nil {
+		return nil
+	}
+	for _, d := range displays {
+		if d.ID == uint32(id) {
+			return d.EDID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build !windows && !darwin
+
+package ddc
+
+import "os"
+
+// monitorEDID reads the raw EDID of the monitor's DRM connector, or nil if
+// the connector isn't known
+func monitorEDID(m Monitor) []byte {
+	if m.Connector == "" {
+		return nil
+	}
+
+	for _, path := range drmGlob("card*-" + m.Connector + "/edid") {
+		if data, err := os.ReadFile(path); err == nil && len(data) > 0 {
+			return data
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package ddc
+
+import (
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+var procEnumDisplayDevicesW = windows.NewLazySystemDLL("user32.dll").NewProc("EnumDisplayDevicesW")
+
+// eddGetDeviceInterfaceName makes EnumDisplayDevices return the monitor's
+// device interface path instead of its hardware ID
+const eddGetDeviceInterfaceName = 0x00000001
+
+// displayDevice mirrors the Win32 DISPLAY_DEVICEW structure
+type displayDevice struct {
+	Cb           uint32
+	DeviceName   [32]uint16
+	DeviceString [128]uint16
+	StateFlags   uint32
+	DeviceID     [128]uint16
+	DeviceKey    [128]uint16
+}
+
+// monitorEDID reads the EDID Windows caches in the registry for the first
+// monitor on the monitor's display device (Connector, e.g. \\.\DISPLAY1)
+func monitorEDID(m Monitor) []byte {
+	if m.Connector == "" {
+		return nil
+	}
+	adapter, err := windows.UTF16PtrFromString(m.Connector)
+	if err != nil {
+		return nil
+	}
+
+	var dd displayDevice
+	dd.Cb = uint32(unsafe.Sizeof(dd))
+	ret, _, _ := procEnumDisplayDevicesW.Call(uintptr(unsafe.Pointer(adapter)), 0, uintptr(unsafe.Pointer(&dd)), eddGetDeviceInterfaceName)
+	if ret == 0 {
+		return nil
+	}
+
+	// \\?\DISPLAY#DEL4109#5&2a8b6b0&0&UID4353#{e6f07b5f-...} names the key
+	// Enum\DISPLAY\DEL4109\5&2a8b6b0&0&UID4353
+	parts := strings.Split(strings.TrimPrefix(windows.UTF16ToString(dd.DeviceID[:]), `\\?\`), "#")
+	if len(parts) < 3 {
+		return nil
+	}
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE,
+		`SYSTEM\CurrentControlSet\Enum\`+parts[0]+`\`+parts[1]+`\`+parts[2]+`\Device Parameters`, registry.QUERY_VALUE)
+	if err != nil {
+		return nil
+	}
+	defer key.Close()
+
+	edid, _, err := key.GetBinaryValue("EDID")
+	if err != nil {
+		return nil
+	}
+	return edid
+}
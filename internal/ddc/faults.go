@@ -0,0 +1,116 @@
+package ddc
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInjectedFault is returned by a FaultyClient in place of a backend call
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultyClient wraps a DDCClient and makes a share of calls fail or stall, to
+// soak-test retries and transactional applies. Only reads are affected unless
+// Writes is set, so it is safe against real hardware by default.
+type FaultyClient struct {
+	DDCClient
+	Rate    float64       // Probability that a call fails, 0-1
+	Latency time.Duration // Calls are delayed by a random duration up to this
+	Writes  bool          // Also inject into writes
+}
+
+// ParseFaults builds a FaultyClient around client from a spec such as
+// "rate=0.2,latency=300ms,writes". A bare number is taken as the rate.
+func ParseFaults(client DDCClient, spec string) (*FaultyClient, error) {
+	c := &FaultyClient{DDCClient: client}
+	for _, field := range strings.Split(spec, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		var err error
+		switch key {
+		case "":
+		case "rate":
+			c.Rate, err = strconv.ParseFloat(value, 64)
+		case "latency":
+			c.Latency, err = time.ParseDuration(value)
+		case "writes":
+			c.Writes = value == "" || value == "1" || value == "true"
+		default:
+			if c.Rate, err = strconv.ParseFloat(key, 64); err != nil {
+				return nil, fmt.Errorf("unknown fault option %q", key)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid fault option %q: %w", field, err)
+		}
+	}
+	if c.Rate < 0 || c.Rate > 1 {
+		return nil, fmt.Errorf("fault rate %v is outside 0-1", c.Rate)
+	}
+	return c, nil
+}
+
+// inject delays the call and reports whether it should fail
+func (c *FaultyClient) inject(op string) error {
+	if c.Latency > 0 {
+		time.Sleep(rand.N(c.Latency))
+	}
+	if rand.Float64() < c.Rate {
+		return fmt.Errorf("%s: %w", op, ErrInjectedFault)
+	}
+	return nil
+}
+
+func (c *FaultyClient) DetectMonitors() ([]Monitor, error) {
+	if err := c.inject("detect"); err != nil {
+		return nil, err
+	}
+	return c.DDCClient.DetectMonitors()
+}
+
+func (c *FaultyClient) GetCapabilities(monitorID string) (*Capabilities, error) {
+	if err := c.inject("capabilities"); err != nil {
+		return nil, err
+	}
+	return c.DDCClient.GetCapabilities(monitorID)
+}
+
+func (c *FaultyClient) GetVCP(monitorID string, code byte) (uint16, error) {
+	if err := c.inject(fmt.Sprintf("getvcp 0x%02X", code)); err != nil {
+		return 0, err
+	}
+	return c.DDCClient.GetVCP(monitorID, code)
+}
+
+func (c *FaultyClient) GetVCPValue(monitorID string, code byte) (VCPValue, error) {
+	if err := c.inject(fmt.Sprintf("getvcp 0x%02X", code)); err != nil {
+		return VCPValue{}, err
+	}
+	return c.DDCClient.GetVCPValue(monitorID, code)
+}
+
+func (c *FaultyClient) SetVCP(monitorID string, code byte, value uint16) error {
+	if c.Writes {
+		if err := c.inject(fmt.Sprintf("setvcp 0x%02X", code)); err != nil {
+			return err
+		}
+	}
+	return c.DDCClient.SetVCP(monitorID, code, value)
+}
+
+// SetVCPBatch fails the batch as a whole, before any write is made
+func (c *FaultyClient) SetVCPBatch(monitorID string, writes []VCPWrite) error {
+	if c.Writes {
+		if err := c.inject("setvcp batch"); err != nil {
+			return err
+		}
+	}
+	return SetVCPs(c.DDCClient, monitorID, writes)
+}
+
+// Unwrap returns the wrapped client
+func (c *FaultyClient) Unwrap() DDCClient {
+	return c.DDCClient
+}
@@ -0,0 +1,97 @@
+package ddc
+
+import "sort"
+
+// Feature is something a backend can control on a monitor
+type Feature string
+
+const (
+	FeatureInput        Feature = "input"
+	FeatureBrightness   Feature = "brightness"
+	FeatureContrast     Feature = "contrast"
+	FeatureVolume       Feature = "volume"
+	FeaturePower        Feature = "power"
+	FeatureCapabilities Feature = "capabilities" // Reports an MCCS capabilities string
+	FeatureVCP          Feature = "vcp"          // Reads and writes any VCP code
+)
+
+// codeFeatures maps the VCP codes with a named feature
+var codeFeatures = map[byte]Feature{
+	VCPInputSource: FeatureInput,
+	VCPBrightness:  FeatureBrightness,
+	VCPContrast:    FeatureContrast,
+	VCPVolume:      FeatureVolume,
+	VCPPowerMode:   FeaturePower,
+}
+
+// FeatureSet is what a backend supports for one monitor
+type FeatureSet map[Feature]bool
+
+// allFeatures is what a backend speaking raw DDC/CI can do
+var allFeatures = FeatureSet{
+	FeatureInput:        true,
+	FeatureBrightness:   true,
+	FeatureContrast:     true,
+	FeatureVolume:       true,
+	FeaturePower:        true,
+	FeatureCapabilities: true,
+	FeatureVCP:          true,
+}
+
+// Supports reports whether the set covers a VCP code: through its named
+// feature for the common codes, and FeatureVCP for the rest
+func (s FeatureSet) Supports(code byte) bool {
+	if f, ok := codeFeatures[code]; ok && s[f] {
+		return true
+	}
+	return s[FeatureVCP]
+}
+
+// List returns the features in the set, sorted
+func (s FeatureSet) List() []Feature {
+	var list []Feature
+	for f, ok := range s {
+		if ok {
+			list = append(list, f)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
+// FeatureBackend is a backend that declares what it supports on each of the
+// monitors it detects, so a RouterClient can send each request to a backend
+// that handles it
+type FeatureBackend interface {
+	DDCClient
+	Features(m Monitor) FeatureSet
+}
+
+// Features reports what the client can do on m. Monitors that didn't
+// answer DDC/CI get nothing; the macOS tools only know four features.
+func (c *DDCClientImpl) Features(m Monitor) FeatureSet {
+	if !m.DDCSupported {
+		return FeatureSet{}
+	}
+	switch m.DDCTool {
+	case "m1ddc", "ddcctl":
+		return FeatureSet{FeatureInput: true, FeatureBrightness: true, FeatureContrast: true, FeatureVolume: true}
+	}
+	return allFeatures
+}
+
+// MonitorFeatures returns what client can do on m, looking through wrappers.
+// Clients that don't declare their features are assumed to support every one.
+func MonitorFeatures(client DDCClient, m Monitor) FeatureSet {
+	for client != nil {
+		if f, ok := client.(interface{ Features(Monitor) FeatureSet }); ok {
+			return f.Features(m)
+		}
+		w, ok := client.(interface{ Unwrap() DDCClient })
+		if !ok {
+			break
+		}
+		client = w.Unwrap()
+	}
+	return allFeatures
+}
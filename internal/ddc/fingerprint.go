@@ -0,0 +1,38 @@
+package ddc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+// Fingerprint identifies a monitor's firmware and feature set by hashing its
+// capabilities string and EDID. It changes after a firmware update or when a
+// different revision of the model is connected, so the capabilities are read
+// from the monitor rather than the cache.
+func Fingerprint(client DDCClient, m Monitor) (string, error) {
+	caps, err := FreshCapabilities(client, m.ID)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	if caps.Raw != "" {
+		fmt.Fprintf(h, "caps:%s\n", caps.Raw)
+	} else {
+		// Without the raw string, hash what was parsed from it
+		features := append([]byte(nil), caps.Features...)
+		sort.Slice(features, func(i, j int) bool { return features[i] < features[j] })
+		inputs := make([]int, 0, len(caps.SupportedInputs))
+		for _, code := range caps.SupportedInputs {
+			inputs = append(inputs, int(code))
+		}
+		sort.Ints(inputs)
+		fmt.Fprintf(h, "features:% X\ninputs:%v\n", features, inputs)
+	}
+	if edid := monitorEDID(m); len(edid) > 0 {
+		fmt.Fprintf(h, "edid:%X\n", edid)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
@@ -0,0 +1,45 @@
+package ddc
+
+import "strings"
+
+// GPU is a graphics adapter and the driver bound to it. DDC/CI behaviour
+// depends heavily on both, e.g. NVIDIA's proprietary driver on Linux needs a
+// module option before i2c works at all.
+type GPU struct {
+	Vendor        string `json:"vendor"`
+	Model         string `json:"model"`
+	Driver        string `json:"driver,omitempty"`
+	DriverVersion string `json:"driver_version,omitempty"`
+}
+
+// pciVendors names the PCI vendor IDs of common GPUs
+var pciVendors = map[string]string{
+	"0x10de": "NVIDIA",
+	"0x1002": "AMD",
+	"0x8086": "Intel",
+	"0x106b": "Apple",
+	"0x15ad": "VMware",
+	"0x1af4": "Red Hat (virtio)",
+	"0x1234": "QEMU",
+	"0x80ee": "VirtualBox",
+	"0x1414": "Microsoft",
+	"0x5143": "Qualcomm",
+	"0x1ab8": "Parallels",
+}
+
+// gpuVendor normalizes a vendor string or PCI ID to a short name
+func gpuVendor(vendor string) string {
+	if name, ok := pciVendors[strings.ToLower(vendor)]; ok {
+		return name
+	}
+	lower := strings.ToLower(vendor)
+	for _, known := range []string{"NVIDIA", "AMD", "Intel", "Apple", "Qualcomm"} {
+		if strings.Contains(lower, strings.ToLower(known)) {
+			return known
+		}
+	}
+	if strings.Contains(lower, "advanced micro devices") || strings.Contains(lower, "ati ") {
+		return "AMD"
+	}
+	return vendor
+}
@@ -0,0 +1,39 @@
+package ddc
+
+import (
+	"encoding/json"
+	"errors"
+	"os/exec"
+)
+
+// GPUs lists the graphics adapters from system_profiler. macOS ships its own
+// drivers, so only the Metal family is reported as the driver.
+func (d *Detector) GPUs() []GPU {
+	out, err := exec.Command("system_profiler", "SPDisplaysDataType", "-json").Output()
+	if err != nil {
+		return nil
+	}
+
+	var sp struct {
+		SPDisplaysDataType []struct {
+			Model  string `json:"sppci_model"`
+			Vendor string `json:"spdisplays_vendor"`
+			Metal  string `json:"spdisplays_mtlgpufamilysupport"`
+		} `json:"SPDisplaysDataType"`
+	}
+	// Field types vary between releases; keep whatever decoded
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal(out, &sp); err != nil && !errors.As(err, &typeErr) {
+		return nil
+	}
+
+	var gpus []GPU
+	for _, g := range sp.SPDisplaysDataType {
+		vendor := g.Vendor
+		if vendor == "" || vendor == "sppci_vendor_Apple" {
+			vendor = "Apple"
+		}
+		gpus = append(gpus, GPU{Vendor: gpuVendor(vendor), Model: g.Model, Driver: g.Metal})
+	}
+	return gpus
+}
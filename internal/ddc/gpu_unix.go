@@ -0,0 +1,72 @@
+//go:build !windows && !darwin
+
+package ddc
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GPUs lists the graphics adapters with a DRM device, naming them with lspci
+// and reading driver versions from the kernel module or nvidia-smi
+func (d *Detector) GPUs() []GPU {
+	cards := drmGlob("card[0-9]*")
+	seen := make(map[string]bool)
+	var gpus []GPU
+	for _, card := range cards {
+		if strings.Contains(filepath.Base(card), "-") {
+			continue // A connector, e.g. card1-DP-1
+		}
+		device, err := filepath.EvalSymlinks(filepath.Join(card, "device"))
+		if err != nil || seen[device] {
+			continue
+		}
+		seen[device] = true
+
+		gpu := GPU{Vendor: gpuVendor(readSysfs(filepath.Join(device, "vendor")))}
+		if driver, err := os.Readlink(filepath.Join(device, "driver")); err == nil {
+			gpu.Driver = filepath.Base(driver)
+			gpu.DriverVersion = readSysfs(filepath.Join("/sys/module", gpu.Driver, "version"))
+		}
+		gpu.Model = lspciName(filepath.Base(device))
+		if gpu.Model == "" {
+			gpu.Model = readSysfs(filepath.Join(device, "device"))
+		}
+		gpus = append(gpus, gpu)
+	}
+
+	// The proprietary NVIDIA module doesn't always export its version
+	for i := range gpus {
+		if gpus[i].Vendor == "NVIDIA" && gpus[i].DriverVersion == "" {
+			if out, err := exec.Command("nvidia-smi", "--query-gpu=driver_version", "--format=csv,noheader").Output(); err == nil {
+				gpus[i].DriverVersion, _, _ = strings.Cut(strings.TrimSpace(string(out)), "\n")
+			}
+		}
+	}
+	return gpus
+}
+
+// lspciName returns the device name lspci has for a PCI slot such as
+// "0000:01:00.0", or "" when lspci isn't installed or the device isn't PCI
+func lspciName(slot string) string {
+	out, err := exec.Command("lspci", "-mm", "-s", slot).Output()
+	if err != nil {
+		return ""
+	}
+	// 01:00.0 "VGA compatible controller" "NVIDIA Corporation" "GA104 [GeForce RTX 3070]" ...
+	fields := strings.Split(string(out), `"`)
+	if len(fields) < 6 {
+		return ""
+	}
+	return strings.TrimSpace(fields[5])
+}
+
+func readSysfs(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,46 @@
+package ddc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"time"
+)
+
+// videoControllerScript lists Win32_VideoController as a JSON array
+const videoControllerScript = `@(Get-CimInstance -ClassName Win32_VideoController |
+  Select-Object Name, AdapterCompatibility, DriverVersion, InstalledDisplayDrivers) | ConvertTo-Json -Compress`
+
+// GPUs lists the display adapters from Win32_VideoController
+func (d *Detector) GPUs() []GPU {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", videoControllerScript).Output()
+	if err != nil {
+		return nil
+	}
+
+	var controllers []struct {
+		Name                    string
+		AdapterCompatibility    string
+		DriverVersion           string
+		InstalledDisplayDrivers string
+	}
+	if err := json.Unmarshal(bytes.TrimPrefix(out, []byte("\xef\xbb\xbf")), &controllers); err != nil {
+		return nil
+	}
+
+	var gpus []GPU
+	for _, c := range controllers {
+		driver, _, _ := bytes.Cut([]byte(c.InstalledDisplayDrivers), []byte(","))
+		gpus = append(gpus, GPU{
+			Vendor:        gpuVendor(c.AdapterCompatibility),
+			Model:         c.Name,
+			Driver:        string(driver),
+			DriverVersion: c.DriverVersion,
+		})
+	}
+	return gpus
+}
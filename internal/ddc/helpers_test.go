@@ -0,0 +1,29 @@
+package ddc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newReplayClient returns a client whose tools answer from recordings. It
+// never opens the host's i2c buses and doesn't check for virtualization, so
+// results don't depend on the machine.
+func newReplayClient(osType OSType, recordings map[string]Recording) (*DDCClientImpl, *ReplayRunner) {
+	runner := &ReplayRunner{Recordings: recordings}
+	c := NewDDCClientImpl(osType)
+	c.SetCommandRunner(runner)
+	c.i2cScanned = true
+	c.virtualOnce.Do(func() {})
+	return c, runner
+}
+
+// corpusSample returns a recorded tool output from testdata/corpus
+func corpusSample(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "corpus", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,121 @@
+package ddc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/linux"
+)
+
+// linuxBuses returns the DDC buses usable through i2c-dev, or nil when
+// ddcutil has to be used instead. Buses are scanned on first use and again
+// by each detection.
+func (c *DDCClientImpl) linuxBuses() []linux.Bus {
+	c.i2cMu.Lock()
+	scanned := c.i2cScanned
+	c.i2cMu.Unlock()
+	if !scanned {
+		return c.scanI2C()
+	}
+
+	c.i2cMu.Lock()
+	defer c.i2cMu.Unlock()
+	return c.i2cBuses
+}
+
+// scanI2C enumerates the i2c-dev buses of connected monitors
+func (c *DDCClientImpl) scanI2C() []linux.Bus {
+	var buses []linux.Bus
+	_, err := c.commands.LookPath("ddcutil")
+	if (err != nil || c.preferredTool != "ddcutil") && linux.Available() {
+		buses, _ = linux.Buses()
+	}
+
+	c.i2cMu.Lock()
+	defer c.i2cMu.Unlock()
+	c.i2cBuses, c.i2cScanned = buses, true
+	return buses
+}
+
+// i2cBus maps a monitor ID (1-based, like ddcutil's display numbers) to its bus
+func (c *DDCClientImpl) i2cBus(monitorID string) (int, bool) {
+	buses := c.linuxBuses()
+	n, err := strconv.Atoi(monitorID)
+	if err != nil || n < 1 || n > len(buses) {
+		return 0, false
+	}
+	return buses[n-1].Number, true
+}
+
+// withI2C opens the monitor's bus for fn
+func withI2C(bus int, fn func(d *linux.Device) error) error {
+	d, err := linux.Open(bus)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return fn(d)
+}
+
+func (c *DDCClientImpl) detectWithI2C(buses []linux.Bus) []Monitor {
+	var monitors []Monitor
+	for i, bus := range buses {
+		m := Monitor{
+			ID:        strconv.Itoa(i + 1),
+			Name:      bus.Name,
+			Connector: bus.Connector,
+			Inputs:    make(map[string]byte),
+		}
+		if m.Name == "" {
+			m.Name = bus.Connector
+		}
+
+		caps, err := c.cachedCapabilities(&m, func() (*Capabilities, error) {
+			return c.getI2CCapabilities(bus.Number)
+		})
+		if err == nil {
+			m.Inputs = caps.SupportedInputs
+			m.DDCSupported, m.DDCTool = true, "i2c-dev"
+		}
+		if value, err := c.getI2CVCP(bus.Number, VCPInputSource); err == nil {
+			m.CurrentInput = c.linuxInputCodeToName(byte(value.Current))
+			m.DDCSupported, m.DDCTool = true, "i2c-dev"
+		}
+		monitors = append(monitors, m)
+	}
+	return monitors
+}
+
+func (c *DDCClientImpl) getI2CCapabilities(bus int) (*Capabilities, error) {
+	var raw string
+	err := withI2C(bus, func(d *linux.Device) error {
+		var err error
+		raw, err = d.Capabilities()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.parseMCCSCapabilities(raw), nil
+}
+
+func (c *DDCClientImpl) getI2CVCP(bus int, code byte) (VCPValue, error) {
+	var value VCPValue
+	err := withI2C(bus, func(d *linux.Device) error {
+		var err error
+		value.Current, value.Max, err = d.GetVCP(code)
+		return err
+	})
+	return value, err
+}
+
+func (c *DDCClientImpl) setI2CVCPs(bus int, writes []VCPWrite) error {
+	return withI2C(bus, func(d *linux.Device) error {
+		for _, w := range writes {
+			if err := d.SetVCP(w.Code, w.Value); err != nil {
+				return fmt.Errorf("VCP 0x%02X: %w", w.Code, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,30 @@
+package ddc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResolveInput maps a user-supplied input name to its VCP 0x60 value. It
+// accepts names the monitor advertised, common names (hdmi-1, dp, usb-c), and
+// raw values in decimal or hex (17, 0x11).
+func ResolveInput(monitor Monitor, name string) (byte, error) {
+	for inputName, code := range monitor.Inputs {
+		if strings.EqualFold(inputName, name) {
+			return code, nil
+		}
+	}
+
+	for inputName, code := range M1DDCInputSources {
+		if strings.EqualFold(inputName, name) {
+			return byte(code), nil
+		}
+	}
+
+	if value, err := strconv.ParseUint(name, 0, 8); err == nil {
+		return byte(value), nil
+	}
+
+	return 0, fmt.Errorf("unknown input %q", name)
+}
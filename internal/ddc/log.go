@@ -0,0 +1,42 @@
+package ddc
+
+import (
+	"context"
+	"log/slog"
+)
+
+// discardHandler drops every record. Clients and detectors log nothing until
+// they are given a logger, so library users never see output they didn't ask for.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+var discardLogger = slog.New(discardHandler{})
+
+// SetLogger sets where the client logs detection, validation and retries.
+// A nil logger discards them, which is the default.
+func (c *DDCClientImpl) SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = discardLogger
+	}
+	c.logger = l
+}
+
+// SetLogger sets where the detector logs problems it works around, and the
+// logger of the clients it creates. A nil logger discards them.
+func (d *Detector) SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = discardLogger
+	}
+	d.logger = l
+}
+
+// newClient creates a client for the detector's OS that logs to its logger
+func (d *Detector) newClient() *DDCClientImpl {
+	c := NewDDCClientImpl(d.osType)
+	c.SetLogger(d.logger)
+	return c
+}
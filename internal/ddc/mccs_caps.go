@@ -0,0 +1,149 @@
+package ddc
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// parseMCCSCapabilities parses a raw MCCS capabilities string, e.g.
+// "(prot(monitor)type(lcd)model(U2720Q)vcp(02 10 12 60(0F 11 12))mccs_ver(2.1))".
+// Every vcp() code becomes a Feature; a parenthesized list after a code holds
+// the values that non-continuous feature accepts.
+func (c *DDCClientImpl) parseMCCSCapabilities(raw string) *Capabilities {
+	caps := &Capabilities{SupportedInputs: make(map[string]byte), Raw: raw}
+
+	sections := capabilitySections(raw)
+	caps.Protocol = sections["prot"]
+	caps.Type = sections["type"]
+	caps.Model = sections["model"]
+	caps.MCCSVersion = sections["mccs_ver"]
+
+	depth, current := 0, -1
+	for _, tok := range tokenizeCapabilities(sections["vcp"]) {
+		switch tok {
+		case "(":
+			depth++
+			continue
+		case ")":
+			if depth > 0 {
+				depth--
+			}
+			continue
+		}
+
+		// Some monitors omit the separators: "0210126062"
+		for _, code := range splitHexRun(tok) {
+			value, err := strconv.ParseUint(code, 16, 8)
+			if err != nil {
+				continue
+			}
+			if depth > 0 {
+				// Deeper nesting (rare vendor extensions) isn't a value list
+				if depth == 1 && current >= 0 {
+					if caps.Values == nil {
+						caps.Values = make(map[byte][]uint16)
+					}
+					caps.Values[byte(current)] = append(caps.Values[byte(current)], uint16(value))
+					if current == int(VCPInputSource) {
+						caps.SupportedInputs[c.linuxInputCodeToName(byte(value))] = byte(value)
+					}
+				}
+				continue
+			}
+
+			current = int(value)
+			caps.Features = append(caps.Features, byte(value))
+			switch byte(value) {
+			case VCPBrightness:
+				caps.SupportedBrightness = true
+			case VCPContrast:
+				caps.SupportedContrast = true
+			case VCPVolume:
+				caps.SupportedVolume = true
+			case VCPPowerMode:
+				caps.SupportedPower = true
+			}
+		}
+	}
+	return caps
+}
+
+// capabilitySections splits the top level of a capabilities string into
+// lowercase section names and their contents. Strings truncated by the
+// monitor keep whatever their last section had.
+func capabilitySections(raw string) map[string]string {
+	sections := make(map[string]string)
+
+	s := strings.TrimSpace(raw)
+	if strings.HasPrefix(s, "(") {
+		s = s[1:]
+	}
+	for {
+		open := strings.IndexByte(s, '(')
+		if open == -1 {
+			return sections
+		}
+		name := strings.ToLower(strings.TrimSpace(s[:open]))
+
+		depth, end := 0, len(s)
+		for i := open; i < len(s); i++ {
+			if s[i] == '(' {
+				depth++
+			} else if s[i] == ')' {
+				depth--
+				if depth == 0 {
+					end = i
+					break
+				}
+			}
+		}
+		if _, seen := sections[name]; !seen && name != "" {
+			sections[name] = strings.TrimSpace(s[open+1 : end])
+		}
+		if end >= len(s) {
+			return sections
+		}
+		s = s[end+1:]
+	}
+}
+
+// splitHexRun splits an even-length run of hex digits into byte-sized codes
+func splitHexRun(tok string) []string {
+	if len(tok) <= 2 || len(tok)%2 != 0 {
+		return []string{tok}
+	}
+	if _, err := hex.DecodeString(tok); err != nil {
+		return []string{tok}
+	}
+	codes := make([]string, 0, len(tok)/2)
+	for i := 0; i < len(tok); i += 2 {
+		codes = append(codes, tok[i:i+2])
+	}
+	return codes
+}
+
+// tokenizeCapabilities splits "02 60(0F 11)" into "02", "60", "(", "0F", "11", ")"
+func tokenizeCapabilities(s string) []string {
+	var tokens []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush()
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
@@ -0,0 +1,127 @@
+package ddc
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MockWrite is a SetVCP call received by a MockDDCClient
+type MockWrite struct {
+	MonitorID string
+	Code      byte
+	Value     uint16
+}
+
+// MockDDCClient is an in-memory DDCClient for exercising the wrappers, the
+// service layer and commands without a monitor. Reads answer from Values,
+// which SetVCP updates; every write is kept in Writes.
+type MockDDCClient struct {
+	Monitors     []Monitor
+	Capabilities map[string]*Capabilities     // By monitor ID
+	Values       map[string]map[byte]VCPValue // By monitor ID and VCP code
+	Errors       map[string]map[byte]error    // Returned by reads and writes of a feature
+	DetectErr    error                        // Returned by DetectMonitors
+	DropWrites   bool                         // Acknowledge writes without applying them, like flaky monitors
+
+	mu     sync.Mutex
+	Writes []MockWrite
+}
+
+// NewMockDDCClient returns a mock with the given monitors, each answering
+// brightness and contrast reads with 50 of 100
+func NewMockDDCClient(monitors ...Monitor) *MockDDCClient {
+	m := &MockDDCClient{
+		Monitors:     monitors,
+		Capabilities: make(map[string]*Capabilities),
+		Values:       make(map[string]map[byte]VCPValue),
+		Errors:       make(map[string]map[byte]error),
+	}
+	for _, monitor := range monitors {
+		m.Values[monitor.ID] = map[byte]VCPValue{
+			VCPBrightness: {Current: 50, Max: 100},
+			VCPContrast:   {Current: 50, Max: 100},
+		}
+	}
+	return m
+}
+
+// Backend names the mock in reports
+func (m *MockDDCClient) Backend() string {
+	return "mock"
+}
+
+func (m *MockDDCClient) DetectMonitors() ([]Monitor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.DetectErr != nil {
+		return nil, m.DetectErr
+	}
+	return append([]Monitor(nil), m.Monitors...), nil
+}
+
+func (m *MockDDCClient) GetCapabilities(monitorID string) (*Capabilities, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.checkMonitor(monitorID); err != nil {
+		return nil, err
+	}
+	caps, ok := m.Capabilities[monitorID]
+	if !ok {
+		return nil, fmt.Errorf("monitor %s did not report capabilities", monitorID)
+	}
+	return caps, nil
+}
+
+func (m *MockDDCClient) SetVCP(monitorID string, code byte, value uint16) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.checkFeature(monitorID, code); err != nil {
+		return err
+	}
+
+	m.Writes = append(m.Writes, MockWrite{MonitorID: monitorID, Code: code, Value: value})
+	if m.DropWrites {
+		return nil
+	}
+	if m.Values[monitorID] == nil {
+		m.Values[monitorID] = make(map[byte]VCPValue)
+	}
+	v := m.Values[monitorID][code]
+	v.Current = value
+	m.Values[monitorID][code] = v
+	return nil
+}
+
+func (m *MockDDCClient) GetVCP(monitorID string, code byte) (uint16, error) {
+	v, err := m.GetVCPValue(monitorID, code)
+	return v.Current, err
+}
+
+func (m *MockDDCClient) GetVCPValue(monitorID string, code byte) (VCPValue, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.checkFeature(monitorID, code); err != nil {
+		return VCPValue{}, err
+	}
+	v, ok := m.Values[monitorID][code]
+	if !ok {
+		return VCPValue{}, fmt.Errorf("monitor %s does not support VCP 0x%02X", monitorID, code)
+	}
+	return v, nil
+}
+
+func (m *MockDDCClient) checkMonitor(monitorID string) error {
+	for _, monitor := range m.Monitors {
+		if monitor.ID == monitorID {
+			return nil
+		}
+	}
+	return fmt.Errorf("monitor %s not found", monitorID)
+}
+
+func (m *MockDDCClient) checkFeature(monitorID string, code byte) error {
+	if err := m.checkMonitor(monitorID); err != nil {
+		return err
+	}
+	return m.Errors[monitorID][code]
+}
@@ -0,0 +1,13 @@
+package linux
+
+import "errors"
+
+// ErrUnavailable is returned when i2c-dev isn't usable on this system
+var ErrUnavailable = errors.New("native i2c-dev DDC/CI support not available")
+
+// Bus is an i2c bus wired to a connected display's DDC channel
+type Bus struct {
+	Number    int    // i2c adapter number, as in /dev/i2c-<Number>
+	Connector string // DRM connector, e.g. "DP-3"
+	Name      string // Manufacturer and monitor name from the EDID, if readable
+}
@@ -0,0 +1,217 @@
+//go:build linux
+
+package linux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	i2cSlave = 0x0703 // I2C_SLAVE ioctl from linux/i2c-dev.h
+
+	ddcAddr = 0x37 // DDC/CI display address (0x6E/0x6F on the wire)
+
+	replyDelay = 40 * time.Millisecond // MCCS minimum wait before reading a reply
+	writeDelay = 50 * time.Millisecond // MCCS minimum wait after a write
+)
+
+// Buses lists the DDC buses of connected displays that answer at the DDC/CI
+// address, in i2c adapter order. Like ddcutil, it leaves out laptop panels
+// and displays without DDC/CI, so the numbering matches ddcutil's "Display N"
+// and "--monitor 1" means the same display with either backend.
+func Buses() ([]Bus, error) {
+	connectors, err := filepath.Glob("/sys/class/drm/card*-*")
+	if err != nil {
+		return nil, err
+	}
+
+	var buses []Bus
+	for _, dir := range connectors {
+		status, err := os.ReadFile(filepath.Join(dir, "status"))
+		if err != nil || strings.TrimSpace(string(status)) != "connected" {
+			continue
+		}
+		connector := filepath.Base(dir)
+		if idx := strings.Index(connector, "-"); idx != -1 {
+			connector = connector[idx+1:]
+		}
+		if internalPanel(connector) {
+			continue
+		}
+		number, ok := connectorBus(dir)
+		if !ok || !responds(number) {
+			continue
+		}
+
+		bus := Bus{Number: number, Connector: connector}
+		if edid, err := os.ReadFile(filepath.Join(dir, "edid")); err == nil {
+			bus.Name = edidName(edid)
+		}
+		buses = append(buses, bus)
+	}
+
+	if len(buses) == 0 {
+		return nil, ErrUnavailable
+	}
+	sort.Slice(buses, func(i, j int) bool { return buses[i].Number < buses[j].Number })
+	return buses, nil
+}
+
+// connectorBus finds the i2c adapter of a DRM connector: HDMI/DVI expose a
+// "ddc" link to it, DisplayPort an "i2c-N" child for its AUX channel
+func connectorBus(dir string) (int, bool) {
+	if target, err := os.Readlink(filepath.Join(dir, "ddc")); err == nil {
+		if n, ok := parseBusName(filepath.Base(target)); ok {
+			return n, true
+		}
+	}
+	children, _ := filepath.Glob(filepath.Join(dir, "i2c-*"))
+	for _, child := range children {
+		if n, ok := parseBusName(filepath.Base(child)); ok {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// internalPanel reports whether a connector drives a built-in panel, which
+// ddcutil doesn't number as a display
+func internalPanel(connector string) bool {
+	for _, prefix := range []string{"eDP", "LVDS", "DSI"} {
+		if strings.HasPrefix(connector, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// responds reports whether a device acknowledges a read at the DDC/CI
+// address of a bus, as ddcutil checks before numbering a display
+func responds(bus int) bool {
+	d, err := Open(bus)
+	if err != nil {
+		return false
+	}
+	defer d.Close()
+	_, err = unix.Read(d.fd, make([]byte, 1))
+	return err == nil
+}
+
+func parseBusName(name string) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimPrefix(name, "i2c-"))
+	return n, err == nil && strings.HasPrefix(name, "i2c-")
+}
+
+// Available reports whether at least one display's DDC bus can be opened.
+// Buses only lists buses it could open and probe.
+func Available() bool {
+	_, err := Buses()
+	return err == nil
+}
+
+func devicePath(bus int) string {
+	return fmt.Sprintf("/dev/i2c-%d", bus)
+}
+
+// Device is an open DDC/CI channel to one display
+type Device struct {
+	fd int
+}
+
+// Open opens /dev/i2c-<bus> addressed to the display's DDC/CI endpoint
+func Open(bus int) (*Device, error) {
+	fd, err := unix.Open(devicePath(bus), unix.O_RDWR|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", devicePath(bus), err)
+	}
+	if err := unix.IoctlSetInt(fd, i2cSlave, ddcAddr); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("select DDC/CI address on %s: %w", devicePath(bus), err)
+	}
+	return &Device{fd: fd}, nil
+}
+
+// Close releases the device
+func (d *Device) Close() error {
+	return unix.Close(d.fd)
+}
+
+// GetVCP reads a feature's current and maximum value
+func (d *Device) GetVCP(code byte) (current, maximum uint16, err error) {
+	reply, err := d.request([]byte{0x01, code}, 11)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Reply payload: 0x02, result, code, type, max high/low, current high/low
+	if len(reply) != 8 || reply[0] != 0x02 || reply[2] != code {
+		return 0, 0, fmt.Errorf("unexpected reply to VCP 0x%02X: % X", code, reply)
+	}
+	if reply[1] != 0 {
+		return 0, 0, fmt.Errorf("VCP 0x%02X is not supported by the monitor", code)
+	}
+	maximum = uint16(reply[4])<<8 | uint16(reply[5])
+	current = uint16(reply[6])<<8 | uint16(reply[7])
+	return current, maximum, nil
+}
+
+// SetVCP writes a feature value
+func (d *Device) SetVCP(code byte, value uint16) error {
+	err := d.write([]byte{0x03, code, byte(value >> 8), byte(value)})
+	time.Sleep(writeDelay)
+	return err
+}
+
+// Capabilities reads the display's MCCS capabilities string, fragment by fragment
+func (d *Device) Capabilities() (string, error) {
+	var caps []byte
+	for offset := 0; offset < 0x10000; {
+		reply, err := d.request([]byte{0xF3, byte(offset >> 8), byte(offset)}, 38)
+		if err != nil {
+			return "", fmt.Errorf("capabilities at offset %d: %w", offset, err)
+		}
+		// Reply payload: 0xE3, offset high/low, data
+		if len(reply) < 3 || reply[0] != 0xE3 || int(reply[1])<<8|int(reply[2]) != offset {
+			return "", fmt.Errorf("unexpected capabilities reply: % X", reply)
+		}
+		data := reply[3:]
+		if len(data) == 0 {
+			break
+		}
+		caps = append(caps, data...)
+		offset += len(data)
+		time.Sleep(writeDelay)
+	}
+	return strings.TrimRight(string(caps), "\x00"), nil
+}
+
+// request sends a command and returns the payload of the display's reply,
+// reading at most size bytes
+func (d *Device) request(payload []byte, size int) ([]byte, error) {
+	if err := d.write(payload); err != nil {
+		return nil, err
+	}
+	time.Sleep(replyDelay)
+
+	buf := make([]byte, size)
+	n, err := unix.Read(d.fd, buf)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	return parseReply(buf[:n])
+}
+
+func (d *Device) write(payload []byte) error {
+	if _, err := unix.Write(d.fd, frame(payload)); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+//go:build !linux
+
+package linux
+
+// Buses is only implemented on Linux
+func Buses() ([]Bus, error) {
+	return nil, ErrUnavailable
+}
+
+// Available is always false outside Linux
+func Available() bool {
+	return false
+}
+
+// Device is an open DDC/CI channel to one display
+type Device struct{}
+
+// Open is only implemented on Linux
+func Open(bus int) (*Device, error) {
+	return nil, ErrUnavailable
+}
+
+// Close is only implemented on Linux
+func (d *Device) Close() error {
+	return ErrUnavailable
+}
+
+// GetVCP is only implemented on Linux
+func (d *Device) GetVCP(code byte) (current, maximum uint16, err error) {
+	return 0, 0, ErrUnavailable
+}
+
+// SetVCP is only implemented on Linux
+func (d *Device) SetVCP(code byte, value uint16) error {
+	return ErrUnavailable
+}
+
+// Capabilities is only implemented on Linux
+func (d *Device) Capabilities() (string, error) {
+	return "", ErrUnavailable
+}
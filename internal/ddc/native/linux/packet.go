@@ -0,0 +1,79 @@
+package linux
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	hostAddr      = 0x51 // Source address byte of host-to-display messages
+	destChecksum  = 0x6E // Write address the host checksum starts from
+	replyChecksum = 0x50 // Virtual host address the reply checksum starts from
+)
+
+// frame wraps a DDC/CI payload as source address, length and checksum
+func frame(payload []byte) []byte {
+	msg := append([]byte{hostAddr, 0x80 | byte(len(payload))}, payload...)
+	checksum := byte(destChecksum)
+	for _, b := range msg {
+		checksum ^= b
+	}
+	return append(msg, checksum)
+}
+
+// parseReply validates a display reply (source, length, payload, checksum)
+// and returns its payload
+func parseReply(buf []byte) ([]byte, error) {
+	if len(buf) < 3 {
+		return nil, fmt.Errorf("short reply (%d bytes)", len(buf))
+	}
+	if buf[1]&0x80 == 0 {
+		return nil, fmt.Errorf("malformed reply length byte 0x%02X", buf[1])
+	}
+	length := int(buf[1] & 0x7F)
+	if len(buf) < length+3 {
+		return nil, fmt.Errorf("truncated reply: want %d bytes, got %d", length+3, len(buf))
+	}
+
+	checksum := byte(replyChecksum)
+	for _, b := range buf[:length+2] {
+		checksum ^= b
+	}
+	if checksum != buf[length+2] {
+		return nil, fmt.Errorf("reply checksum mismatch")
+	}
+	return buf[2 : length+2], nil
+}
+
+// edidName returns "<manufacturer> <monitor name>" from an EDID base block
+func edidName(edid []byte) string {
+	if len(edid) < 128 {
+		return ""
+	}
+
+	// Manufacturer ID: three 5-bit letters, big-endian in bytes 8-9
+	id := uint16(edid[8])<<8 | uint16(edid[9])
+	mfg := string([]byte{
+		byte('A' - 1 + (id>>10)&0x1F),
+		byte('A' - 1 + (id>>5)&0x1F),
+		byte('A' - 1 + id&0x1F),
+	})
+
+	// The monitor name is a 0xFC display descriptor in one of four slots
+	for slot := 54; slot <= 108; slot += 18 {
+		d := edid[slot : slot+18]
+		if d[0] == 0 && d[1] == 0 && d[3] == 0xFC {
+			name := strings.TrimSpace(strings.Map(printable, strings.SplitN(string(d[5:]), "\n", 2)[0]))
+			return strings.TrimSpace(mfg + " " + name)
+		}
+	}
+	return mfg
+}
+
+// printable drops the control and non-ASCII bytes some EDIDs pad names with
+func printable(r rune) rune {
+	if r < 0x20 || r > 0x7E {
+		return -1
+	}
+	return r
+}
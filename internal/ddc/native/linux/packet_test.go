@@ -0,0 +1,49 @@
+package linux
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzParseReply(f *testing.F) {
+	// Get VCP reply for brightness 75 of 100, and a null message
+	f.Add(checksummed([]byte{0x6E, 0x88, 0x02, 0x00, 0x10, 0x00, 0x00, 0x64, 0x00, 0x4B}))
+	f.Add(checksummed([]byte{0x6E, 0x80}))
+	f.Add([]byte{0x6E, 0xFF, 0x00})
+	f.Add([]byte{0x6E})
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		payload, err := parseReply(buf)
+		if err != nil {
+			return
+		}
+		if len(payload) != int(buf[1]&0x7F) || !bytes.Equal(payload, buf[2:2+len(payload)]) {
+			t.Fatalf("payload % X doesn't match the reply % X", payload, buf)
+		}
+	})
+}
+
+func FuzzEdidName(f *testing.F) {
+	edid := make([]byte, 128)
+	edid[8], edid[9] = 0x10, 0xAC // DEL
+	copy(edid[90:], append([]byte{0, 0, 0, 0xFC, 0}, "DELL U2720Q\n "...))
+	f.Add(edid)
+	f.Add(make([]byte, 128))
+	f.Add(make([]byte, 12))
+	f.Fuzz(func(t *testing.T, edid []byte) {
+		name := edidName(edid)
+		for _, r := range name {
+			if printable(r) < 0 {
+				t.Fatalf("unprintable %q in name %q", r, name)
+			}
+		}
+	})
+}
+
+// checksummed appends the checksum a display computes over a reply
+func checksummed(msg []byte) []byte {
+	checksum := byte(replyChecksum)
+	for _, b := range msg {
+		checksum ^= b
+	}
+	return append(msg, checksum)
+}
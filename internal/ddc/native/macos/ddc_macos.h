@@ -9,16 +9,44 @@ extern "C" {
 
 char *GetMonitorsJSON();
 
-// Returns a JSON string representing an array of detected monitors.
-// The caller is responsible for freeing the returned char*.
-int SetVCP(unsigned int displayID, unsigned char featureCOde,
+// Sets a VCP feature value on a specified display, over IOAVService on
+// Apple Silicon or IOFramebuffer I2C on Intel. Returns 0 on success.
+int SetVCP(unsigned int displayID, unsigned char featureCode,
            unsigned short value);
 
 // Gets a VCP feature value from a specified display.
-// Returns 0 on success, non-zero on error.
+// Returns 0 on success, -5 if the feature is unsupported, other non-zero
+// values on error.
 int GetVCP(unsigned int displayID, unsigned char featureCode,
            unsigned short *value, unsigned short *maxValue);
 
+// Reads the MCCS capabilities string of a display. Returns NULL on error;
+// the caller frees the result with FreeString.
+char *GetCapabilities(unsigned int displayID);
+
+// Switches a display to the mode matching width x height (and refresh, when
+// greater than zero). Returns 0 on success, non-zero on error.
+int SetDisplayMode(unsigned int displayID, unsigned int width,
+                   unsigned int height, double refresh);
+
+// Moves a display's top-left corner to (x, y) in global coordinates. The
+// display placed at (0, 0) becomes the main display.
+int SetDisplayOrigin(unsigned int displayID, int x, int y);
+
+// Mirrors displayID onto masterID, or stops mirroring when masterID is 0.
+int SetDisplayMirror(unsigned int displayID, unsigned int masterID);
+
+// Returns the number of active displays, including built-in panels, or -1
+// on error.
+int ActiveDisplayCount(void);
+
+// Returns 1 if the display is asleep, 0 if it is awake.
+int DisplayIsAsleep(unsigned int displayID);
+
+// Registers for display reconfiguration callbacks and runs the calling
+// thread's run loop forever, calling goDisplayReconfigured after each change.
+void WatchDisplayReconfiguration(void);
+
 // Frees memory allocated by GetMonitorsJSON.
 void FreeString(char *str);
 
@@ -1,8 +1,13 @@
+//go:build darwin && cgo
+
 #import "ddc_macos.h"
+#import <ColorSync/ColorSync.h>
 #import <CoreGraphics/CoreGraphics.h>
 #import <Foundation/Foundation.h>
 #import <IOKit/graphics/IOGraphicsLib.h>
 #import <IOKit/i2c/IOI2CInterface.h>
+#include <math.h>
+#include <unistd.h>
 
 #define kMaxDisplays 16
 #define kDDCMinReplyDelay 30000000 // 30ms in nanoseconds
@@ -214,6 +219,12 @@ char *GetMonitorsJSON() {
               monitor[@"name"] = name;
             }
           }
+          // Base64 so it decodes straight into a Go []byte
+          CFDataRef edid = CFDictionaryGetValue(info, CFSTR(kIODisplayEDIDKey));
+          if (edid && CFGetTypeID(edid) == CFDataGetTypeID()) {
+            monitor[@"edid"] = [(__bridge NSData *)edid
+                base64EncodedStringWithOptions:0];
+          }
           CFRelease(info); // Add this - we own this reference
         }
         IOObjectRelease(service); // Add this - clean up the service
@@ -224,6 +235,18 @@ char *GetMonitorsJSON() {
         monitor[@"name"] = [NSString stringWithFormat:@"Display %u", displayID];
       }
 
+      // The UUID is stable across reboots and reconnects, unlike displayID
+      CFUUIDRef uuid = CGDisplayCreateUUIDFromDisplayID(displayID);
+      if (uuid) {
+        CFStringRef uuidString = CFUUIDCreateString(kCFAllocatorDefault, uuid);
+        if (uuidString) {
+          monitor[@"uuid"] = (__bridge NSString *)uuidString;
+          CFRelease(uuidString);
+        }
+        CFRelease(uuid);
+      }
+
+      monitor[@"main"] = @(CGDisplayIsMain(displayID) ? YES : NO);
       monitor[@"vendor_id"] = @(CGDisplayVendorNumber(displayID));
       monitor[@"model_id"] = @(CGDisplayModelNumber(displayID));
       monitor[@"serial"] = @(CGDisplaySerialNumber(displayID));
@@ -245,126 +268,383 @@ char *GetMonitorsJSON() {
   }
 }
 
+// Apple Silicon exposes display I2C through the private IOAVService API
+// instead of IOFramebuffer. Weakly linked so the bridge still loads where the
+// symbols are missing.
+typedef CFTypeRef IOAVServiceRef;
+extern IOAVServiceRef IOAVServiceCreateWithService(CFAllocatorRef allocator,
+                                                   io_service_t service)
+    __attribute__((weak_import));
+extern IOReturn IOAVServiceReadI2C(IOAVServiceRef service, uint32_t chipAddress,
+                                   uint32_t offset, void *outputBuffer,
+                                   uint32_t outputBufferSize)
+    __attribute__((weak_import));
+extern IOReturn IOAVServiceWriteI2C(IOAVServiceRef service,
+                                    uint32_t chipAddress, uint32_t dataAddress,
+                                    void *inputBuffer, uint32_t inputBufferSize)
+    __attribute__((weak_import));
+
+#define kDDCAddress 0x37
+#define kDDCHostAddress 0x51
+#define kDDCReplyLength 11
+
+// Helper: Get the IOAVService of an external display. External
+// DCPAVServiceProxy entries appear in the same order as external displays in
+// CGGetOnlineDisplayList, which is also how m1ddc numbers them.
+static IOAVServiceRef GetAVService(CGDirectDisplayID displayID) {
+  if (IOAVServiceCreateWithService == NULL) {
+    return NULL;
+  }
+
+  CGDirectDisplayID displays[kMaxDisplays];
+  uint32_t displayCount = 0;
+  if (CGGetOnlineDisplayList(kMaxDisplays, displays, &displayCount) !=
+      kCGErrorSuccess) {
+    return NULL;
+  }
+  int target = -1, external = 0;
+  for (uint32_t i = 0; i < displayCount; i++) {
+    if (CGDisplayIsBuiltin(displays[i])) {
+      continue;
+    }
+    if (displays[i] == displayID) {
+      target = external;
+      break;
+    }
+    external++;
+  }
+  if (target < 0) {
+    return NULL;
+  }
+
+  io_iterator_t iter;
+  if (IOServiceGetMatchingServices(kIOMasterPortDefault,
+                                   IOServiceMatching("DCPAVServiceProxy"),
+                                   &iter) != kIOReturnSuccess) {
+    return NULL;
+  }
+
+  IOAVServiceRef avService = NULL;
+  io_service_t service;
+  int index = 0;
+  while ((service = IOIteratorNext(iter)) != 0) {
+    CFStringRef location = IORegistryEntryCreateCFProperty(
+        service, CFSTR("Location"), kCFAllocatorDefault, kNilOptions);
+    Boolean isExternal =
+        location && CFStringCompare(location, CFSTR("External"), 0) ==
+                        kCFCompareEqualTo;
+    if (location) {
+      CFRelease(location);
+    }
+    if (isExternal && index++ == target) {
+      avService = IOAVServiceCreateWithService(kCFAllocatorDefault, service);
+      IOObjectRelease(service);
+      break;
+    }
+    IOObjectRelease(service);
+  }
+  IOObjectRelease(iter);
+  return avService;
+}
+
+// Helper: Send a DDC/CI message over IOAVService and optionally read the
+// reply. payload excludes the host address, which IOAVService takes as the
+// data address.
+static int AVServiceTransfer(IOAVServiceRef service, UInt8 *payload,
+                             UInt8 payloadLen, UInt8 *reply, UInt8 replyLen) {
+  UInt8 data[8];
+  data[0] = 0x80 | payloadLen;
+  UInt8 checksum = (kDDCAddress << 1) ^ kDDCHostAddress ^ data[0];
+  for (UInt8 i = 0; i < payloadLen; i++) {
+    data[i + 1] = payload[i];
+    checksum ^= payload[i];
+  }
+  data[payloadLen + 1] = checksum;
+
+  if (IOAVServiceWriteI2C(service, kDDCAddress, kDDCHostAddress, data,
+                          payloadLen + 2) != kIOReturnSuccess) {
+    return -2;
+  }
+  if (!reply) {
+    return 0;
+  }
+
+  usleep(kDDCMinReplyDelay / 1000);
+  if (IOAVServiceReadI2C(service, kDDCAddress, 0, reply, replyLen) !=
+      kIOReturnSuccess) {
+    return -3;
+  }
+  return 0;
+}
+
+// Helper: Send a DDC/CI message over the IOFramebuffer I2C interface (Intel
+// Macs) and optionally read the reply
+static int FramebufferTransfer(CGDirectDisplayID displayID, UInt8 *payload,
+                               UInt8 payloadLen, UInt8 *reply,
+                               UInt8 replyLen) {
+  io_service_t service = GetIOServicePort(displayID);
+  if (!service) {
+    return -1;
+  }
+
+  UInt8 data[8];
+  data[0] = kDDCHostAddress;
+  data[1] = 0x80 | payloadLen;
+  UInt8 checksum = (kDDCAddress << 1) ^ data[0] ^ data[1];
+  for (UInt8 i = 0; i < payloadLen; i++) {
+    data[i + 2] = payload[i];
+    checksum ^= payload[i];
+  }
+  data[payloadLen + 2] = checksum;
+
+  IOI2CRequest request;
+  memset(&request, 0, sizeof(request));
+  request.sendAddress = kDDCAddress << 1;
+  request.sendTransactionType = kIOI2CSimpleTransactionType;
+  request.sendBuffer = (vm_address_t)data;
+  request.sendBytes = payloadLen + 3;
+  request.minReplyDelay = kDDCMinReplyDelay;
+  if (reply) {
+    request.replyAddress = (kDDCAddress << 1) | 1;
+    request.replyTransactionType = kIOI2CSimpleTransactionType;
+    request.replyBuffer = (vm_address_t)reply;
+    request.replyBytes = replyLen;
+  }
+
+  int rc = -2;
+  IOItemCount busCount;
+  IOReturn ret = IOFBGetI2CInterfaceCount(service, &busCount);
+  for (IOItemCount bus = 0; ret == kIOReturnSuccess && bus < busCount;
+       bus++) {
+    io_service_t interface;
+    if (IOFBCopyI2CInterfaceForBus(service, bus, &interface) !=
+        kIOReturnSuccess) {
+      continue;
+    }
+    IOI2CConnectRef connect;
+    IOReturn openRet = IOI2CInterfaceOpen(interface, kNilOptions, &connect);
+    IOObjectRelease(interface);
+    if (openRet != kIOReturnSuccess) {
+      continue;
+    }
+
+    IOReturn sendRet = IOI2CSendRequest(connect, kNilOptions, &request);
+    IOI2CInterfaceClose(connect, kNilOptions);
+    if (sendRet == kIOReturnSuccess && request.result == kIOReturnSuccess) {
+      rc = 0;
+      break;
+    }
+  }
+  IOObjectRelease(service);
+  return rc;
+}
+
+// Helper: Send a DDC/CI message to a display over whichever I2C path it has
+static int DDCTransfer(CGDirectDisplayID displayID, UInt8 *payload,
+                       UInt8 payloadLen, UInt8 *reply, UInt8 replyLen) {
+  IOAVServiceRef avService = GetAVService(displayID);
+  if (avService) {
+    int rc = AVServiceTransfer(avService, payload, payloadLen, reply, replyLen);
+    CFRelease(avService);
+    return rc;
+  }
+  return FramebufferTransfer(displayID, payload, payloadLen, reply, replyLen);
+}
+
 int SetVCP(unsigned int displayID, unsigned char featureCode,
            unsigned short value) {
   @autoreleasepool {
-    io_service_t service = GetIOServicePort(displayID);
-    if (!service) {
-      return -1;
+    UInt8 payload[4] = {0x03, featureCode, (value >> 8) & 0xFF, value & 0xFF};
+    return DDCTransfer(displayID, payload, sizeof(payload), NULL, 0);
+  }
+}
+
+int GetVCP(unsigned int displayID, unsigned char featureCode,
+           unsigned short *currentValue, unsigned short *maxValue) {
+  @autoreleasepool {
+    UInt8 payload[2] = {0x01, featureCode};
+    UInt8 reply[kDDCReplyLength];
+    memset(reply, 0, sizeof(reply));
+
+    int rc = DDCTransfer(displayID, payload, sizeof(payload), reply,
+                         sizeof(reply));
+    if (rc != 0) {
+      return rc;
     }
 
-    IOI2CRequest request;
-    memset(&request, 0, sizeof(request));
-
-    UInt8 data[7];
-    data[0] = 0x51; // DDC/CI host address
-    data[1] = 0x84; // Set VCP feature command
-    data[2] = featureCode;
-    data[3] = (value >> 8) & 0xFF; // High byte
-    data[4] = value & 0xFF;        // Low byte
-
-    // Calculation checksum
-    UInt8 checksum = 0x6E; // 0x50 XOR 0x51 XOR 0x84
-    checksum ^= featureCode;
-    checksum ^= data[3];
-    checksum ^= data[4];
-    data[5] = checksum;
-
-    request.sendAddress = 0x37 << 1; // DDC/CI address
-    request.sendTransactionType = kIOI2CSimpleTransactionType;
-    request.sendBuffer = (vm_address_t)data;
-    request.sendBytes = 6;
-    request.minReplyDelay = kDDCMinReplyDelay;
-
-    IOItemCount busCount;
-    IOReturn ret = IOFBGetI2CInterfaceCount(service, &busCount);
-    for (IOItemCount bus = 0; bus < busCount; bus++) {
-      io_service_t interface;
-      ret = IOFBCopyI2CInterfaceForBus(service, bus, &interface);
-      if (ret != kIOReturnSuccess)
-        continue;
-      IOI2CConnectRef connect;
-      ret = IOI2CInterfaceOpen(interface, kNilOptions, &connect);
-      IOObjectRelease(interface);
+    // Reply: [src][len][02][result][feature][type][max_h][max_l][cur_h][cur_l][chk]
+    UInt8 checksum = 0x50;
+    for (int i = 0; i < kDDCReplyLength - 1; i++) {
+      checksum ^= reply[i];
+    }
+    if (reply[2] != 0x02 || reply[4] != featureCode ||
+        checksum != reply[kDDCReplyLength - 1]) {
+      return -4;
+    }
+    if (reply[3] != 0) {
+      return -5; // Feature not supported
+    }
+    *maxValue = (reply[6] << 8) | reply[7];
+    *currentValue = (reply[8] << 8) | reply[9];
+    return 0;
+  }
+}
 
-      if (ret == kIOReturnSuccess) {
-        ret = IOI2CSendRequest(connect, kNilOptions, &request);
-        IOI2CInterfaceClose(connect, kNilOptions);
+char *GetCapabilities(unsigned int displayID) {
+  @autoreleasepool {
+    NSMutableData *caps = [NSMutableData data];
+    UInt16 offset = 0;
+
+    // The string arrives in fragments of up to 32 bytes, each requested by
+    // offset; an empty fragment ends it
+    while (offset < 1024) {
+      UInt8 payload[3] = {0xF3, offset >> 8, offset & 0xFF};
+      UInt8 reply[38];
+      int rc = -1;
+      UInt8 length = 0;
+
+      for (int attempt = 0; attempt < 3 && rc != 0; attempt++) {
+        memset(reply, 0, sizeof(reply));
+        rc = DDCTransfer(displayID, payload, sizeof(payload), reply,
+                         sizeof(reply));
+        if (rc != 0) {
+          continue;
+        }
 
-        if (ret == kIOReturnSuccess) {
-          IOObjectRelease(service);
-          return 0;
+        // Reply: [src][len][E3][offset_h][offset_l][data...][chk]
+        length = reply[1] & 0x7F;
+        if (length < 3 || length + 3 > sizeof(reply) || reply[2] != 0xE3) {
+          rc = -4;
+          continue;
         }
+        UInt8 checksum = 0x50;
+        for (int i = 0; i < length + 2; i++) {
+          checksum ^= reply[i];
+        }
+        if (checksum != reply[length + 2] ||
+            ((reply[3] << 8) | reply[4]) != offset) {
+          rc = -4;
+        }
+      }
+      if (rc != 0) {
+        return NULL;
       }
+
+      UInt8 fragment = length - 3;
+      if (fragment == 0) {
+        break;
+      }
+      [caps appendBytes:reply + 5 length:fragment];
+      offset += fragment;
     }
-    IOObjectRelease(service);
-    return -2;
+
+    char *result = malloc(caps.length + 1);
+    if (!result) {
+      return NULL;
+    }
+    memcpy(result, caps.bytes, caps.length);
+    result[caps.length] = 0;
+    return result;
   }
 }
 
-int GetVCP(unsigned int displayID, unsigned char featureCode,
-           unsigned short *currentValue, unsigned short *maxValue) {
+int SetDisplayMode(unsigned int displayID, unsigned int width,
+                   unsigned int height, double refresh) {
   @autoreleasepool {
-    io_service_t service = GetIOServicePort(displayID);
-    if (!service) {
+    CFArrayRef modes = CGDisplayCopyAllDisplayModes(displayID, NULL);
+    if (!modes) {
       return -1;
     }
 
-    // Send request
-    UInt8 sendData[5];
-    sendData[0] = 0x51;
-    sendData[1] = 0x82; // Get VCP feature command
-    sendData[2] = featureCode;
-    sendData[3] = 0x6E ^ featureCode; // Checksum
-
-    // Receive buffer
-    UInt8 recvData[11];
-
-    IOI2CRequest request;
-    memset(&request, 0, sizeof(request));
-    request.sendAddress = 0x37 << 1;
-    request.sendTransactionType = kIOI2CSimpleTransactionType;
-    request.sendBuffer = (vm_address_t)sendData;
-    request.sendBytes = 4;
-    request.replyAddress = 0x37 << 1;
-    request.replyTransactionType = kIOI2CSimpleTransactionType;
-    request.replyBuffer = (vm_address_t)recvData;
-    request.replyBytes = sizeof(recvData);
-    request.minReplyDelay = kDDCMinReplyDelay;
-
-    IOItemCount busCount;
-    IOReturn ret = IOFBGetI2CInterfaceCount(service, &busCount);
-
-    for (IOItemCount bus = 0; bus < busCount; bus++) {
-      io_service_t interface;
-      ret = IOFBCopyI2CInterfaceForBus(service, bus, &interface);
-      if (ret != kIOReturnSuccess)
+    // Pick the first mode with the requested size, and refresh rate if given
+    CGDisplayModeRef match = NULL;
+    CFIndex count = CFArrayGetCount(modes);
+    for (CFIndex i = 0; i < count; i++) {
+      CGDisplayModeRef mode =
+          (CGDisplayModeRef)CFArrayGetValueAtIndex(modes, i);
+      if (CGDisplayModeGetWidth(mode) != width ||
+          CGDisplayModeGetHeight(mode) != height) {
         continue;
-
-      IOI2CConnectRef connect;
-      ret = IOI2CInterfaceOpen(interface, kNilOptions, &connect);
-      IOObjectRelease(interface);
-
-      if (ret == kIOReturnSuccess) {
-        ret = IOI2CSendRequest(connect, kNilOptions, &request);
-        IOI2CInterfaceClose(connect, kNilOptions);
-
-        if (ret == kIOReturnSuccess && request.replyBytes >= 8) {
-          // Parse response:
-          // [addr][len][02][00][feature][type][max_h][max_l][cur_h][cur_l][checksum]
-          if (recvData[2] == 0x02 && recvData[4] == featureCode) {
-            *maxValue = (recvData[6] << 8) | recvData[7];
-            *currentValue = (recvData[8] << 8) | recvData[9];
-            IOObjectRelease(service);
-            return 0;
-          }
-        }
       }
+      if (refresh > 0 &&
+          fabs(CGDisplayModeGetRefreshRate(mode) - refresh) > 0.5) {
+        continue;
+      }
+      match = mode;
+      break;
     }
 
-    IOObjectRelease(service);
-    return -2;
+    if (!match) {
+      CFRelease(modes);
+      return -2;
+    }
+
+    CGDisplayConfigRef config;
+    if (CGBeginDisplayConfiguration(&config) != kCGErrorSuccess) {
+      CFRelease(modes);
+      return -3;
+    }
+
+    CGConfigureDisplayWithDisplayMode(config, displayID, match, NULL);
+    CGError err = CGCompleteDisplayConfiguration(config, kCGConfigurePermanently);
+    CFRelease(modes);
+
+    return err == kCGErrorSuccess ? 0 : -4;
+  }
+}
+
+int SetDisplayOrigin(unsigned int displayID, int x, int y) {
+  CGDisplayConfigRef config;
+  if (CGBeginDisplayConfiguration(&config) != kCGErrorSuccess) {
+    return -1;
+  }
+
+  CGConfigureDisplayOrigin(config, displayID, x, y);
+  CGError err = CGCompleteDisplayConfiguration(config, kCGConfigurePermanently);
+  return err == kCGErrorSuccess ? 0 : -2;
+}
+
+int SetDisplayMirror(unsigned int displayID, unsigned int masterID) {
+  CGDisplayConfigRef config;
+  if (CGBeginDisplayConfiguration(&config) != kCGErrorSuccess) {
+    return -1;
+  }
+
+  // kCGNullDirectDisplay (0) as the master turns mirroring off
+  CGConfigureDisplayMirrorOfDisplay(config, displayID, masterID);
+  CGError err = CGCompleteDisplayConfiguration(config, kCGConfigurePermanently);
+  return err == kCGErrorSuccess ? 0 : -2;
+}
+
+int ActiveDisplayCount(void) {
+  uint32_t count = 0;
+  if (CGGetActiveDisplayList(0, NULL, &count) != kCGErrorSuccess) {
+    return -1;
+  }
+  return (int)count;
+}
+
+int DisplayIsAsleep(unsigned int displayID) {
+  return CGDisplayIsAsleep(displayID) ? 1 : 0;
+}
+
+// Implemented in Go (watch.go)
+extern void goDisplayReconfigured(unsigned int displayID, unsigned int flags);
+
+static void displayReconfigured(CGDirectDisplayID display,
+                                CGDisplayChangeSummaryFlags flags,
+                                void *userInfo) {
+  // Every change is announced twice; the begin callback comes before the
+  // new configuration is visible
+  if (flags & kCGDisplayBeginConfigurationFlag) {
+    return;
   }
+  goDisplayReconfigured(display, flags);
+}
+
+void WatchDisplayReconfiguration(void) {
+  CGDisplayRegisterReconfigurationCallback(displayReconfigured, NULL);
+  CFRunLoopRun();
 }
 
 void FreeString(char *str) {
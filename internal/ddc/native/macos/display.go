@@ -0,0 +1,18 @@
+package macos
+
+import "errors"
+
+// ErrUnavailable is returned when the native CoreGraphics/IOKit bridge is not compiled in
+var ErrUnavailable = errors.New("native macOS display support not available in this build")
+
+// Display is an online external display as reported by CoreGraphics
+type Display struct {
+	ID       uint32 `json:"id"`        // CGDirectDisplayID
+	Name     string `json:"name"`      // Product name from IOKit, or "Display <id>"
+	UUID     string `json:"uuid"`      // Stable display UUID
+	Main     bool   `json:"main"`      // Whether this is the main display
+	VendorID uint32 `json:"vendor_id"` // EDID vendor number
+	ModelID  uint32 `json:"model_id"`  // EDID model number
+	Serial   uint32 `json:"serial"`    // EDID serial number
+	EDID     []byte `json:"edid"`      // Raw EDID, where IOKit exposes it (Intel Macs)
+}
@@ -0,0 +1,112 @@
+//go:build darwin && cgo
+
+package macos
+
+/*
+#cgo LDFLAGS: -framework CoreGraphics -framework Foundation -framework IOKit -framework ColorSync
+#include <stdlib.h>
+#include "ddc_macos.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Displays lists online external displays using CGGetOnlineDisplayList
+func Displays() ([]Display, error) {
+	cstr := C.GetMonitorsJSON()
+	if cstr == nil {
+		return nil, fmt.Errorf("GetMonitorsJSON returned no data")
+	}
+	defer C.FreeString(cstr)
+
+	var displays []Display
+	if err := json.Unmarshal([]byte(C.GoString(cstr)), &displays); err != nil {
+		return nil, fmt.Errorf("failed to parse display list: %w", err)
+	}
+
+	return displays, nil
+}
+
+// Capabilities reads a display's MCCS capabilities string
+func Capabilities(displayID uint32) (string, error) {
+	cstr := C.GetCapabilities(C.uint(displayID))
+	if cstr == nil {
+		return "", fmt.Errorf("failed to read capabilities of display %d", displayID)
+	}
+	defer C.FreeString(cstr)
+	return strings.TrimRight(C.GoString(cstr), "\x00"), nil
+}
+
+// SetDisplayMode switches a display to the given resolution and refresh rate.
+// A refresh of 0 accepts any rate for that resolution.
+func SetDisplayMode(displayID uint32, width, height int, refresh float64) error {
+	switch rc := C.SetDisplayMode(C.uint(displayID), C.uint(width), C.uint(height), C.double(refresh)); rc {
+	case 0:
+		return nil
+	case -2:
+		return fmt.Errorf("display %d has no %dx%d mode at %.0fHz", displayID, width, height, refresh)
+	default:
+		return fmt.Errorf("failed to set display mode (code %d)", int(rc))
+	}
+}
+
+// SetDisplayOrigin moves a display's top-left corner in global coordinates
+func SetDisplayOrigin(displayID uint32, x, y int) error {
+	if rc := C.SetDisplayOrigin(C.uint(displayID), C.int(x), C.int(y)); rc != 0 {
+		return fmt.Errorf("failed to move display %d (code %d)", displayID, int(rc))
+	}
+	return nil
+}
+
+// SetDisplayMirror mirrors a display onto master, or unmirrors it when master is 0
+func SetDisplayMirror(displayID, masterID uint32) error {
+	if rc := C.SetDisplayMirror(C.uint(displayID), C.uint(masterID)); rc != 0 {
+		return fmt.Errorf("failed to configure mirroring for display %d (code %d)", displayID, int(rc))
+	}
+	return nil
+}
+
+// ActiveDisplayCount returns the number of active displays, built-in included
+func ActiveDisplayCount() (int, error) {
+	count := int(C.ActiveDisplayCount())
+	if count < 0 {
+		return 0, fmt.Errorf("CGGetActiveDisplayList failed")
+	}
+	return count, nil
+}
+
+// DisplayAsleep reports whether a display is asleep (CGDisplayIsAsleep)
+func DisplayAsleep(displayID uint32) (bool, error) {
+	return C.DisplayIsAsleep(C.uint(displayID)) == 1, nil
+}
+
+// Available reports whether the native bridge is compiled in
+func Available() bool {
+	return true
+}
+
+// SetVCP writes a VCP feature over IOAVService (Apple Silicon) or
+// IOFramebuffer I2C (Intel)
+func SetVCP(displayID uint32, code byte, value uint16) error {
+	if rc := C.SetVCP(C.uint(displayID), C.uchar(code), C.ushort(value)); rc != 0 {
+		return fmt.Errorf("failed to set VCP 0x%02X on display %d (code %d)", code, displayID, int(rc))
+	}
+	return nil
+}
+
+// GetVCP reads a VCP feature's current and maximum value
+func GetVCP(displayID uint32, code byte) (current, maximum uint16, err error) {
+	var cur, maxValue C.ushort
+	switch rc := C.GetVCP(C.uint(displayID), C.uchar(code), &cur, &maxValue); rc {
+	case 0:
+		return uint16(cur), uint16(maxValue), nil
+	case -5:
+		return 0, 0, fmt.Errorf("VCP 0x%02X is not supported by display %d", code, displayID)
+	default:
+		return 0, 0, fmt.Errorf("failed to read VCP 0x%02X from display %d (code %d)", code, displayID, int(rc))
+	}
+}
@@ -0,0 +1,58 @@
+//go:build !darwin || !cgo
+
+package macos
+
+// Displays is unavailable without cgo on macOS
+func Displays() ([]Display, error) {
+	return nil, ErrUnavailable
+}
+
+// SetDisplayMode is unavailable without cgo on macOS
+func SetDisplayMode(displayID uint32, width, height int, refresh float64) error {
+	return ErrUnavailable
+}
+
+// SetDisplayOrigin is unavailable without cgo on macOS
+func SetDisplayOrigin(displayID uint32, x, y int) error {
+	return ErrUnavailable
+}
+
+// SetDisplayMirror is unavailable without cgo on macOS
+func SetDisplayMirror(displayID, masterID uint32) error {
+	return ErrUnavailable
+}
+
+// ActiveDisplayCount is unavailable without cgo on macOS
+func ActiveDisplayCount() (int, error) {
+	return 0, ErrUnavailable
+}
+
+// DisplayAsleep is unavailable without cgo on macOS
+func DisplayAsleep(displayID uint32) (bool, error) {
+	return false, ErrUnavailable
+}
+
+// Available is false without cgo on macOS
+func Available() bool {
+	return false
+}
+
+// SetVCP is unavailable without cgo on macOS
+func SetVCP(displayID uint32, code byte, value uint16) error {
+	return ErrUnavailable
+}
+
+// GetVCP is unavailable without cgo on macOS
+func GetVCP(displayID uint32, code byte) (current, maximum uint16, err error) {
+	return 0, 0, ErrUnavailable
+}
+
+// DisplayChanges is unavailable without cgo on macOS
+func DisplayChanges() (<-chan struct{}, error) {
+	return nil, ErrUnavailable
+}
+
+// Capabilities is unavailable without cgo on macOS
+func Capabilities(displayID uint32) (string, error) {
+	return "", ErrUnavailable
+}
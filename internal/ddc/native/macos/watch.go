@@ -0,0 +1,39 @@
+//go:build darwin && cgo
+
+package macos
+
+/*
+#include "ddc_macos.h"
+*/
+import "C"
+
+import (
+	"runtime"
+	"sync"
+)
+
+var (
+	watchOnce sync.Once
+	changes   = make(chan struct{}, 1)
+)
+
+// DisplayChanges returns a channel that receives a value after a display is
+// added, removed or reconfigured. The callbacks run on a dedicated thread's
+// run loop for the life of the process.
+func DisplayChanges() (<-chan struct{}, error) {
+	watchOnce.Do(func() {
+		go func() {
+			runtime.LockOSThread()
+			C.WatchDisplayReconfiguration()
+		}()
+	})
+	return changes, nil
+}
+
+//export goDisplayReconfigured
+func goDisplayReconfigured(displayID, flags C.uint) {
+	select {
+	case changes <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,214 @@
+//go:build windows
+
+package windows
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// availableOnce caches available, which doesn't change while we run
+var availableOnce = sync.OnceValue(available)
+
+var (
+	user32                  = windows.NewLazySystemDLL("user32.dll")
+	procEnumDisplayMonitors = user32.NewProc("EnumDisplayMonitors")
+	procGetMonitorInfoW     = user32.NewProc("GetMonitorInfoW")
+
+	dxva2                                       = windows.NewLazySystemDLL("dxva2.dll")
+	procGetNumberOfPhysicalMonitorsFromHMONITOR = dxva2.NewProc("GetNumberOfPhysicalMonitorsFromHMONITOR")
+	procGetPhysicalMonitorsFromHMONITOR         = dxva2.NewProc("GetPhysicalMonitorsFromHMONITOR")
+	procDestroyPhysicalMonitors                 = dxva2.NewProc("DestroyPhysicalMonitors")
+	procGetVCPFeatureAndVCPFeatureReply         = dxva2.NewProc("GetVCPFeatureAndVCPFeatureReply")
+	procSetVCPFeature                           = dxva2.NewProc("SetVCPFeature")
+	procGetCapabilitiesStringLength             = dxva2.NewProc("GetCapabilitiesStringLength")
+	procCapabilitiesRequestAndCapabilitiesReply = dxva2.NewProc("CapabilitiesRequestAndCapabilitiesReply")
+)
+
+// available loads dxva2 and checks every export we call. LazyProc.Call panics
+// on a missing export, and stripped-down images (Windows on ARM SKUs, Server
+// Core, Windows PE) don't always ship the whole monitor configuration API.
+func available() bool {
+	if err := dxva2.Load(); err != nil {
+		return false
+	}
+	for _, proc := range []*windows.LazyProc{
+		procEnumDisplayMonitors, procGetMonitorInfoW,
+		procGetNumberOfPhysicalMonitorsFromHMONITOR, procGetPhysicalMonitorsFromHMONITOR,
+		procDestroyPhysicalMonitors, procGetVCPFeatureAndVCPFeatureReply, procSetVCPFeature,
+		procGetCapabilitiesStringLength, procCapabilitiesRequestAndCapabilitiesReply,
+	} {
+		if proc.Find() != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// physicalMonitor mirrors the Win32 PHYSICAL_MONITOR structure (packed)
+type physicalMonitor struct {
+	Handle      windows.Handle
+	Description [128]uint16
+}
+
+// monitorInfoEx mirrors the Win32 MONITORINFOEXW structure
+type monitorInfoEx struct {
+	Size    uint32
+	Monitor windows.Rect
+	Work    windows.Rect
+	Flags   uint32
+	Device  [32]uint16
+}
+
+// EnumDisplayMonitors reports handles through a callback; a Go callback can
+// only be created a limited number of times, so one is shared behind a lock
+var (
+	enumMu       sync.Mutex
+	enumHandles  []windows.Handle
+	enumCallback = windows.NewCallback(func(hmonitor, hdc, rect, lparam uintptr) uintptr {
+		enumHandles = append(enumHandles, windows.Handle(hmonitor))
+		return 1
+	})
+)
+
+func displayMonitors() ([]windows.Handle, error) {
+	enumMu.Lock()
+	defer enumMu.Unlock()
+
+	enumHandles = nil
+	if ret, _, err := procEnumDisplayMonitors.Call(0, 0, enumCallback, 0); ret == 0 {
+		return nil, fmt.Errorf("EnumDisplayMonitors: %w", err)
+	}
+	return enumHandles, nil
+}
+
+// session holds the physical monitor handles for one operation
+type session struct {
+	monitors []Monitor
+	handles  []physicalMonitor
+}
+
+func open() (*session, error) {
+	if !availableOnce() {
+		return nil, ErrUnavailable
+	}
+
+	hmonitors, err := displayMonitors()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &session{}
+	for _, hmon := range hmonitors {
+		var info monitorInfoEx
+		info.Size = uint32(unsafe.Sizeof(info))
+		procGetMonitorInfoW.Call(uintptr(hmon), uintptr(unsafe.Pointer(&info)))
+		device := windows.UTF16ToString(info.Device[:])
+
+		var count uint32
+		if ret, _, _ := procGetNumberOfPhysicalMonitorsFromHMONITOR.Call(uintptr(hmon), uintptr(unsafe.Pointer(&count))); ret == 0 || count == 0 {
+			continue
+		}
+		physical := make([]physicalMonitor, count)
+		if ret, _, _ := procGetPhysicalMonitorsFromHMONITOR.Call(uintptr(hmon), uintptr(count), uintptr(unsafe.Pointer(&physical[0]))); ret == 0 {
+			continue
+		}
+
+		for _, pm := range physical {
+			s.handles = append(s.handles, pm)
+			s.monitors = append(s.monitors, Monitor{
+				Index:       len(s.monitors) + 1,
+				Device:      device,
+				Description: windows.UTF16ToString(pm.Description[:]),
+			})
+		}
+	}
+	return s, nil
+}
+
+func (s *session) close() {
+	if len(s.handles) > 0 {
+		procDestroyPhysicalMonitors.Call(uintptr(len(s.handles)), uintptr(unsafe.Pointer(&s.handles[0])))
+	}
+}
+
+func (s *session) handle(index int) (windows.Handle, error) {
+	if index < 1 || index > len(s.handles) {
+		return 0, fmt.Errorf("monitor %d not found", index)
+	}
+	return s.handles[index-1].Handle, nil
+}
+
+// withMonitor runs fn with the physical monitor handle at index
+func withMonitor(index int, fn func(h windows.Handle) error) error {
+	s, err := open()
+	if err != nil {
+		return err
+	}
+	defer s.close()
+
+	h, err := s.handle(index)
+	if err != nil {
+		return err
+	}
+	return fn(h)
+}
+
+// Monitors lists physical monitors in enumeration order
+func Monitors() ([]Monitor, error) {
+	s, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer s.close()
+	return s.monitors, nil
+}
+
+// GetVCP reads a feature's current and maximum value
+func GetVCP(index int, code byte) (current, maximum uint32, err error) {
+	err = withMonitor(index, func(h windows.Handle) error {
+		var codeType uint32
+		ret, _, callErr := procGetVCPFeatureAndVCPFeatureReply.Call(uintptr(h), uintptr(code),
+			uintptr(unsafe.Pointer(&codeType)), uintptr(unsafe.Pointer(&current)), uintptr(unsafe.Pointer(&maximum)))
+		if ret == 0 {
+			return fmt.Errorf("GetVCPFeatureAndVCPFeatureReply(0x%02X): %w", code, callErr)
+		}
+		return nil
+	})
+	return current, maximum, err
+}
+
+// SetVCP writes a feature value
+func SetVCP(index int, code byte, value uint32) error {
+	return withMonitor(index, func(h windows.Handle) error {
+		if ret, _, err := procSetVCPFeature.Call(uintptr(h), uintptr(code), uintptr(value)); ret == 0 {
+			return fmt.Errorf("SetVCPFeature(0x%02X): %w", code, err)
+		}
+		return nil
+	})
+}
+
+// Capabilities reads the monitor's MCCS capabilities string
+func Capabilities(index int) (string, error) {
+	var caps string
+	err := withMonitor(index, func(h windows.Handle) error {
+		var length uint32
+		if ret, _, err := procGetCapabilitiesStringLength.Call(uintptr(h), uintptr(unsafe.Pointer(&length))); ret == 0 {
+			return fmt.Errorf("GetCapabilitiesStringLength: %w", err)
+		}
+		if length == 0 {
+			return nil
+		}
+
+		buf := make([]byte, length)
+		if ret, _, err := procCapabilitiesRequestAndCapabilitiesReply.Call(uintptr(h), uintptr(unsafe.Pointer(&buf[0])), uintptr(length)); ret == 0 {
+			return fmt.Errorf("CapabilitiesRequestAndCapabilitiesReply: %w", err)
+		}
+		caps = windows.ByteSliceToString(buf)
+		return nil
+	})
+	return caps, err
+}
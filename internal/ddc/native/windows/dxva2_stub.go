@@ -0,0 +1,23 @@
+//go:build !windows
+
+package windows
+
+// Monitors is only implemented on Windows
+func Monitors() ([]Monitor, error) {
+	return nil, ErrUnavailable
+}
+
+// GetVCP is only implemented on Windows
+func GetVCP(index int, code byte) (current, maximum uint32, err error) {
+	return 0, 0, ErrUnavailable
+}
+
+// SetVCP is only implemented on Windows
+func SetVCP(index int, code byte, value uint32) error {
+	return ErrUnavailable
+}
+
+// Capabilities is only implemented on Windows
+func Capabilities(index int) (string, error) {
+	return "", ErrUnavailable
+}
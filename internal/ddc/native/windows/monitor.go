@@ -0,0 +1,13 @@
+package windows
+
+import "errors"
+
+// ErrUnavailable is returned when the dxva2 monitor configuration API isn't available
+var ErrUnavailable = errors.New("native Windows monitor configuration API not available")
+
+// Monitor is a physical monitor as reported by dxva2
+type Monitor struct {
+	Index       int    // Position in enumeration order, starting at 1
+	Device      string // GDI display device, e.g. \\.\DISPLAY1
+	Description string // Physical monitor description, often "Generic PnP Monitor"
+}
@@ -0,0 +1,217 @@
+package ddc
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// The fuzz targets feed the parsers of external tool output arbitrary
+// input. Besides not panicking, each checks the invariants its callers rely
+// on. Run one with e.g. go test -fuzz=FuzzParseMCCSCapabilities ./internal/ddc
+
+func FuzzParseMCCSCapabilities(f *testing.F) {
+	f.Add("(prot(monitor)type(lcd)model(U2720Q)cmds(01 02 03 07 0C E3 F3)vcp(02 04 05 08 10 12 14(05 08 0B 0C) 16 18 1A 52 60(0F 11 12) AA(01 02) AC AE B2 B6 C6 C8 C9 D6(01 04 05) DC(00 03 05) DF E0 E1 E2(00 1D 02 04) F0(00 0C) F1 F2 FD)mccs_ver(2.1))")
+	f.Add("(prot(monitor)type(LCD)model(LG)vcp(0210126062(0F1112)))")
+	f.Add("vcp(60(0F 11 (12)))")
+	f.Add("((((")
+	f.Add("vcp(")
+	f.Add("")
+	c := NewDDCClientImpl(OSLinux)
+	f.Fuzz(func(t *testing.T, raw string) {
+		caps := c.parseMCCSCapabilities(raw)
+		if caps.Raw != raw {
+			t.Fatalf("Raw not kept")
+		}
+		for name, code := range caps.SupportedInputs {
+			if name == "" {
+				t.Fatalf("empty input name for 0x%02X", code)
+			}
+		}
+	})
+}
+
+func FuzzParseLinuxInputSources(f *testing.F) {
+	f.Add("   Feature: 60 (Input Source)\n      Values: 0f 11 12\n   Feature: 62 (Audio speaker volume)\n")
+	f.Add("   Feature: 60 (Input Source)\n      Values:\n         0f: DisplayPort-1\n         11: HDMI-1\n")
+	f.Add("Feature: 60 (Input Source)\nValues: 11 (interpretation unavailable)\n")
+	f.Add("Feature: 60 (Input Source)\n:\nValues: zz\n")
+	c := NewDDCClientImpl(OSLinux)
+	f.Fuzz(func(t *testing.T, output string) {
+		for name, code := range c.parseLinuxInputSources(output) {
+			if c.linuxInputCodeToName(code) != name {
+				t.Fatalf("input %q has code 0x%02X", name, code)
+			}
+		}
+	})
+}
+
+func FuzzParseDdcutilDetectOutput(f *testing.F) {
+	f.Add("Display 1\n   I2C bus:  /dev/i2c-6\n   DRM connector:           card1-DP-3\n   EDID synopsis:\n      Mfg id:               DEL - Dell Inc.\n      Model:                DELL U2720Q\n      Serial number:        ABC1234\n   VCP version:         2.1\n")
+	f.Add("Invalid display\n   I2C bus:  /dev/i2c-4\n   DRM connector:           card1-eDP-1\n   Mfg id:  BOE\n\nDisplay 2\n   DRM connector: card0-HDMI-A-1\n")
+	f.Add("Phantom display\n   Model: X\nDisplay x\nDisplay 99999999999999999999\n")
+	f.Add("DRM connector:\nMfg id:\nModel:\n")
+	f.Fuzz(func(t *testing.T, output string) {
+		// No recordings: the capabilities and input reads made for each
+		// display fail, as with a monitor that stopped answering
+		c, _ := newReplayClient(OSLinux, nil)
+		for _, m := range c.parseDdcutilDetectOutput(output) {
+			if m.ID == "" || !m.DDCSupported || m.Inputs == nil {
+				t.Fatalf("incomplete monitor %+v", m)
+			}
+		}
+	})
+}
+
+func FuzzParseDdcutilVCP(f *testing.F) {
+	f.Add("VCP code 0x10 (Brightness                    ): current value =    75, max value =   100")
+	f.Add("VCP code 0x60 (Input Source                  ): DisplayPort-1 (sl=0x0f)")
+	f.Add("VCP code 0x60 (Input Source): Invalid value (mh=0x00, ml=0x12, sh=0x00, sl=0x11)")
+	f.Add("VCP code 0xd6 (Power mode): Unsupported feature code (Unsupported VCP code)")
+	f.Add("current value = 99999999, max value = 1")
+	f.Fuzz(func(t *testing.T, output string) {
+		value, err := parseDdcutilVCP(output)
+		if err != nil && (value != VCPValue{}) {
+			t.Fatalf("value %+v returned with error %v", value, err)
+		}
+	})
+}
+
+func FuzzParseVCPValue(f *testing.F) {
+	for _, tool := range []string{"m1ddc", "ddcctl"} {
+		f.Add(tool, "75")
+		f.Add(tool, "Current luminance: 75")
+		f.Add(tool, "I: VCP control #16 (0x10) = current: 75, max: 100")
+		f.Add(tool, "Display 2: brightness = 75")
+		f.Add(tool, "E: Failed to read\n70000")
+		f.Add(tool, "  65536  ")
+	}
+	c := NewDDCClientImpl(OSMacOS)
+	f.Fuzz(func(t *testing.T, tool, output string) {
+		if _, err := c.parseVCPValue(output, tool, VCPBrightness); err == nil && tool != "m1ddc" && tool != "ddcctl" {
+			t.Fatalf("parsed output of unknown tool %q", tool)
+		}
+	})
+}
+
+func FuzzParseSystemProfilerDisplays(f *testing.F) {
+	f.Add([]byte(`{"SPDisplaysDataType":[{"_name":"Apple M1","spdisplays_ndrvs":[{"_name":"DELL U2720Q","_spdisplays_displayID":"2","_spdisplays_display-vendor-id":"10ac","spdisplays_connection_type":"spdisplays_displayport"},{"_name":"Color LCD","_spdisplays_displayID":"1","spdisplays_connection_type":"spdisplays_internal"}]}]}`))
+	f.Add([]byte(`{"SPDisplaysDataType":[{"spdisplays_ndrvs":[{"_name":"(null)","_spdisplays_displayID":"3","_spdisplays_display-vendor-id":5}]}]}`))
+	f.Add([]byte(`{"SPDisplaysDataType":null}`))
+	f.Add([]byte(`{`))
+	c := NewDDCClientImpl(OSMacOS)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		monitors, err := c.parseSystemProfilerDisplays(data)
+		if err == nil && len(monitors) == 0 {
+			t.Fatal("no monitors and no error")
+		}
+		for _, m := range monitors {
+			if m.ID == "" {
+				t.Fatalf("monitor without ID: %+v", m)
+			}
+		}
+	})
+}
+
+func FuzzParseXrandrOutput(f *testing.F) {
+	f.Add("Monitors: 2\n 0: +*eDP-1 1920/344x1080/194+0+0  eDP-1\n 1: +HDMI-1 2560/597x1440/336+1920+0  HDMI-1\n")
+	f.Add(" : \n:::\n")
+	c := NewDDCClientImpl(OSLinux)
+	f.Fuzz(func(t *testing.T, output string) {
+		monitors, _ := c.parseXrandrOutput(output)
+		for _, m := range monitors {
+			if m.Connector == "" {
+				t.Fatalf("monitor without connector: %+v", m)
+			}
+		}
+	})
+}
+
+func FuzzParseEDID(f *testing.F) {
+	f.Add(testEDID("DEL", 0x4109, "ABC1234", "DELL U2720Q"))
+	f.Add(testEDID("GSM", 0x5B09, "", "LG HDR 4K"))
+	f.Add(make([]byte, 128))
+	f.Add([]byte{0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		e, err := ParseEDID(data)
+		if err != nil {
+			return
+		}
+		if len(data) < 128 {
+			t.Fatalf("parsed a %d-byte EDID", len(data))
+		}
+		if !utf8.ValidString(e.Model) || !utf8.ValidString(e.Serial) || strings.ContainsRune(e.Model, 0) {
+			t.Fatalf("descriptor text not sanitized: %q %q", e.Model, e.Serial)
+		}
+	})
+}
+
+func FuzzParseCompositorOutputs(f *testing.F) {
+	f.Add([]byte(`[{"name":"DP-3","make":"Dell Inc.","model":"DELL U2720Q","serial":"ABC1234","active":true,"focused":false}]`))
+	f.Add([]byte(`[{"id":1,"name":"DP-3","make":"Dell Inc.","model":"DELL U2720Q","serial":"ABC1234","focused":true,"disabled":false}]`))
+	f.Add([]byte(`{"heads":[{"name":"DP-3","make":"Dell Inc.","model":"DELL U2720Q","serial":"ABC1234","enabled":true}]}`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		parseSwayOutputs(data)
+		parseHyprlandOutputs(data)
+		parseWlrOutputs(data)
+	})
+}
+
+func FuzzParseSystemInfoCSV(f *testing.F) {
+	f.Add(`"DESK","Microsoft Windows 11 Pro","10.0.22631 N/A Build 22631","Microsoft Corporation","Standalone Workstation","Multiprocessor Free","Jane","","id","3/14/2024","3/14/2024","Dell","OptiPlex","x64-based PC","1","BIOS","C:\WINDOWS","C:\WINDOWS\system32"`)
+	f.Add(`"a","b"` + "\n\"unterminated")
+	f.Fuzz(func(t *testing.T, output string) {
+		var info WindowsInfo
+		if err := parseSystemInfoCSV(output, &info); err == nil && info.ProductName == "" && info.Version == "" {
+			t.Fatal("success without information")
+		}
+	})
+}
+
+func FuzzParseWindowsInfoOutputs(f *testing.F) {
+	f.Add([]byte("\xef\xbb\xbf{\"Caption\":\"Microsoft Windows 11 Pro\",\"Version\":\"10.0.22631\"}"))
+	f.Add([]byte("\xff\xfeC\x00a\x00p\x00t\x00i\x00o\x00n\x00=\x00X\x00"))
+	f.Add([]byte("Caption=Microsoft Windows 11 Pro\r\nVersion=10.0.22631\r\n"))
+	f.Add([]byte("\xff\xfe\x00"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var cim, wmi WindowsInfo
+		parseCIMOutput(data, &cim)
+		for _, cp := range []uint32{437, 850, 932, 936, 949, 950, 65001} {
+			if s := decodeCodePage(cp, data); cp != 65001 && !utf8.ValidString(s) && !strings.HasPrefix(string(data), "\xff\xfe") {
+				if _, known := codePages[cp]; known {
+					t.Fatalf("code page %d decoded to invalid UTF-8", cp)
+				}
+			}
+			parseWMIOutput(decodeCodePage(cp, data), &wmi)
+		}
+	})
+}
+
+// testEDID builds a valid EDID base block with serial and name descriptors
+func testEDID(manufacturer string, product uint16, serial, name string) []byte {
+	e := make([]byte, 128)
+	copy(e, edidHeader)
+	id := uint16(manufacturer[0]-'A'+1)<<10 | uint16(manufacturer[1]-'A'+1)<<5 | uint16(manufacturer[2]-'A'+1)
+	e[8], e[9] = byte(id>>8), byte(id)
+	e[10], e[11] = byte(product), byte(product>>8)
+	e[16], e[17] = 12, 30 // Week 12 of 2020
+	e[18], e[19] = 1, 4
+
+	descriptor := func(offset int, tag byte, text string) {
+		copy(e[offset:], []byte{0, 0, 0, tag, 0})
+		field := []byte(text + "\n" + strings.Repeat(" ", 13))
+		copy(e[offset+5:offset+18], field[:13])
+	}
+	if serial != "" {
+		descriptor(72, 0xFF, serial)
+	}
+	descriptor(90, 0xFC, name)
+
+	var sum byte
+	for _, b := range e[:127] {
+		sum += b
+	}
+	e[127] = -sum
+	return e
+}
@@ -0,0 +1,29 @@
+//go:build darwin
+
+package ddc
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// processNames returns the executable path of every running process
+func processNames() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "ps", "-axo", "comm=").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
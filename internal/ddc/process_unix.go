@@ -0,0 +1,36 @@
+//go:build !windows && !darwin
+
+package ddc
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// processNames returns the command name of every running process. The BSDs
+// usually don't mount procfs, so ps is asked there.
+func processNames() ([]string, error) {
+	paths, err := filepath.Glob("/proc/[0-9]*/comm")
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		out, err := exec.Command("ps", "-axo", "comm=").Output()
+		if err != nil {
+			return nil, err
+		}
+		return strings.Fields(string(out)), nil
+	}
+
+	var names []string
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		names = append(names, strings.TrimSpace(string(data)))
+	}
+	return names, nil
+}
@@ -0,0 +1,27 @@
+//go:build windows
+
+package ddc
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// processNames returns the executable name of every running process
+func processNames() ([]string, error) {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.CloseHandle(snapshot)
+
+	var entry windows.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+
+	var names []string
+	for err = windows.Process32First(snapshot, &entry); err == nil; err = windows.Process32Next(snapshot, &entry) {
+		names = append(names, windows.UTF16ToString(entry.ExeFile[:]))
+	}
+	return names, nil
+}
@@ -0,0 +1,160 @@
+package ddc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sibteali786/monitorswitch/internal/mccs"
+)
+
+// Quirk describes monitors whose name contains Model, or the one monitor
+// with StableID: VCP codes that misbehave, vendor-specific value names, and
+// settle delays and retries
+type Quirk struct {
+	Model    string                       `json:"model"`               // Case-insensitive substring of the monitor name; "" matches all
+	StableID string                       `json:"stable_id,omitempty"` // Only this monitor (see StableKey), whatever Model says
+	Codes    []string                     `json:"codes"`               // Unsafe VCP codes, e.g. "0xF4"
+	Reason   string                       `json:"reason"`              // Shown when a write is refused
+	Values   map[string]map[string]string `json:"values,omitempty"`    // VCP code -> value -> name, e.g. "0x60" -> "0x1B" -> "USB-C"
+
+	// Settle delays as Go durations ("200ms", "3s"), see SettleDelays
+	WriteDelay  string `json:"write_delay,omitempty"`
+	VerifyDelay string `json:"verify_delay,omitempty"`
+	InputDelay  string `json:"input_delay,omitempty"`
+
+	// Attempts replaces the retry policy's write attempts for the monitor,
+	// unless the policy turns retrying off
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// Matches reports whether the quirk applies to monitor
+func (q Quirk) Matches(monitor Monitor) bool {
+	if q.StableID != "" {
+		return strings.EqualFold(q.StableID, StableKey(monitor))
+	}
+	return strings.Contains(strings.ToLower(monitor.Name), strings.ToLower(q.Model))
+}
+
+// QuirkAttempts returns the write attempts quirks.json sets for monitor, or 0
+func QuirkAttempts(monitor Monitor) int {
+	quirks, _ := LoadQuirks()
+	attempts := 0
+	for _, q := range quirks {
+		if q.Matches(monitor) && q.Attempts > 0 {
+			attempts = q.Attempts
+		}
+	}
+	return attempts
+}
+
+// builtinUnsafeCodes are codes that reset or reconfigure any monitor
+var builtinUnsafeCodes = map[byte]string{
+	0x04: "restores factory defaults",
+	0x05: "restores factory brightness/contrast",
+	0x06: "restores factory geometry",
+	0x08: "restores factory color settings",
+	0x0A: "restores factory TV defaults",
+	0xB0: "stores or restores monitor settings",
+}
+
+// UnsafeVCPReason reports why writing code to monitor is considered unsafe.
+// Besides the built-in list, vendor-specific codes (0xE0-0xFF) and any code
+// listed in quirks.json in the config directory are refused.
+func UnsafeVCPReason(monitor Monitor, code byte) (string, bool) {
+	if reason, ok := builtinUnsafeCodes[code]; ok {
+		return fmt.Sprintf("VCP 0x%02X %s", code, reason), true
+	}
+
+	quirks, err := LoadQuirks()
+	if err == nil {
+		for _, q := range quirks {
+			if !q.Matches(monitor) {
+				continue
+			}
+			for _, c := range q.Codes {
+				if v, err := strconv.ParseUint(c, 0, 8); err == nil && byte(v) == code {
+					return fmt.Sprintf("VCP 0x%02X is listed as unsafe for %q: %s", code, q.Model, q.Reason), true
+				}
+			}
+		}
+	}
+
+	if code >= 0xE0 {
+		return fmt.Sprintf("VCP 0x%02X is manufacturer-specific; its effect on this model is unknown", code), true
+	}
+	return "", false
+}
+
+// DecodeVCPValue renders a value with its name, preferring vendor names from
+// quirks.json for this monitor over the standard MCCS names
+func DecodeVCPValue(monitor Monitor, code byte, value uint16) string {
+	quirks, _ := LoadQuirks()
+	for _, q := range quirks {
+		if !q.Matches(monitor) {
+			continue
+		}
+		for c, values := range q.Values {
+			if vc, err := strconv.ParseUint(c, 0, 8); err != nil || byte(vc) != code {
+				continue
+			}
+			for v, valueName := range values {
+				if vv, err := strconv.ParseUint(v, 0, 16); err == nil && byte(vv) == byte(value) {
+					return fmt.Sprintf("%d (%s)", value, valueName)
+				}
+			}
+		}
+	}
+	return mccs.Decode(code, value)
+}
+
+func quirksPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "monitorswitch", "quirks.json"), nil
+}
+
+// LoadQuirks reads the user's quirk list, returning none if it doesn't exist
+func LoadQuirks() ([]Quirk, error) {
+	path, err := quirksPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var quirks []Quirk
+	if err := json.Unmarshal(data, &quirks); err != nil {
+		return nil, fmt.Errorf("corrupt quirks file %s: %w", path, err)
+	}
+	return quirks, nil
+}
+
+// SaveQuirks writes the user's quirk list
+func SaveQuirks(quirks []Quirk) error {
+	path, err := quirksPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(quirks, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
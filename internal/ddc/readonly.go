@@ -0,0 +1,26 @@
+package ddc
+
+import "errors"
+
+// ErrReadOnly is returned for writes made through a ReadOnlyClient
+var ErrReadOnly = errors.New("read-only mode: writes to monitors are disabled")
+
+// ReadOnlyClient wraps a DDCClient and refuses every write, so an observer
+// can read monitor state without any code path changing the hardware
+type ReadOnlyClient struct {
+	DDCClient
+}
+
+// NewReadOnlyClient wraps client so that SetVCP always fails
+func NewReadOnlyClient(client DDCClient) *ReadOnlyClient {
+	return &ReadOnlyClient{DDCClient: client}
+}
+
+func (c *ReadOnlyClient) SetVCP(monitorID string, code byte, value uint16) error {
+	return ErrReadOnly
+}
+
+// Unwrap returns the wrapped client
+func (c *ReadOnlyClient) Unwrap() DDCClient {
+	return c.DDCClient
+}
@@ -0,0 +1,135 @@
+package ddc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrVerificationFailed is wrapped by SetVCP errors when the monitor
+// acknowledged a write but never reported the new value
+var ErrVerificationFailed = errors.New("monitor did not apply the write")
+
+// VerificationError reports the value a monitor kept returning after writes
+type VerificationError struct {
+	Code     byte
+	Want     uint16
+	Got      uint16
+	Attempts int
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("VCP 0x%02X: wrote %d but the monitor still reports %d after %d attempts",
+		e.Code, e.Want, e.Got, e.Attempts)
+}
+
+func (e *VerificationError) Unwrap() error { return ErrVerificationFailed }
+
+// RetryPolicy controls how DDCClientImpl.SetVCP retries and verifies writes.
+// DDC/CI has no delivery guarantee and monitors routinely drop writes.
+type RetryPolicy struct {
+	Attempts int           // Writes to try; 1 disables retrying
+	Backoff  time.Duration // Wait before the first retry, doubled for each one after
+	Timeout  time.Duration // Give up retrying after this long; 0 for no limit
+	Verify   bool          // Read the feature back and retry if it didn't change
+}
+
+// DefaultRetryPolicy retries twice, waiting 100ms and then 200ms
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts: 3,
+	Backoff:  100 * time.Millisecond,
+	Timeout:  5 * time.Second,
+	Verify:   true,
+}
+
+// unverifiableCodes are written without reading back: resets and degauss are
+// actions rather than values, and monitors put into standby stop answering
+var unverifiableCodes = map[byte]bool{
+	0x01:         true, // Degauss
+	0x02:         true, // New control value
+	0x04:         true, // Restore factory defaults
+	0x05:         true, // Restore factory brightness/contrast
+	0x06:         true, // Restore factory geometry
+	0x08:         true, // Restore factory color
+	0x0A:         true, // Restore factory TV defaults
+	0xB0:         true, // Save/restore settings
+	VCPPowerMode: true,
+}
+
+// SetRetryPolicy replaces the retry policy of SetVCP
+func (c *DDCClientImpl) SetRetryPolicy(p RetryPolicy) {
+	c.retry = p
+}
+
+// setVCPVerified writes with write, retrying with exponential backoff until
+// the monitor reports the value or the policy runs out
+func (c *DDCClientImpl) setVCPVerified(monitorID string, code byte, value uint16, write func() error) error {
+	p := c.retry
+	attempts := max(p.Attempts, 1)
+	c.settleMu.Lock()
+	if n, ok := c.attempts[monitorID]; ok && p.Attempts > 1 {
+		attempts = n
+	}
+	c.settleMu.Unlock()
+	start := time.Now()
+	backoff := p.Backoff
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			if p.Timeout > 0 && time.Since(start)+backoff > p.Timeout {
+				break
+			}
+			c.logger.Debug("retrying VCP write", "monitor", monitorID, "code", fmt.Sprintf("0x%02X", code),
+				"attempt", attempt, "after", backoff, "error", err)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		if err = write(); err != nil {
+			continue
+		}
+		if !p.Verify || unverifiableCodes[code] {
+			return nil
+		}
+		time.Sleep(c.postWriteDelay(monitorID, code))
+
+		got, readErr := c.GetVCPValue(monitorID, code)
+		if readErr != nil {
+			// Some monitors don't answer reads right after a write (notably
+			// input switches); the write itself was acknowledged
+			return nil
+		}
+		if writeApplied(code, value, got.Current) {
+			return nil
+		}
+		err = &VerificationError{Code: code, Want: value, Got: got.Current, Attempts: attempt}
+	}
+	c.logger.Warn("VCP write failed", "monitor", monitorID, "code", fmt.Sprintf("0x%02X", code), "error", err)
+	return err
+}
+
+// postWriteDelay is how long to wait after a write before reading the
+// feature back: monitors keep reporting the old value for the MCCS post-write
+// delay, or longer if quirks.json says so, and after an input switch
+func (c *DDCClientImpl) postWriteDelay(monitorID string, code byte) time.Duration {
+	c.settleMu.Lock()
+	d, ok := c.settle[monitorID]
+	c.settleMu.Unlock()
+	if !ok {
+		d = DefaultSettleDelays
+	}
+	if code == VCPInputSource {
+		return max(d.Write, d.Input)
+	}
+	return d.Write
+}
+
+// writeApplied compares a written value with what the monitor reads back.
+// Input source replies often set the high byte, so only the low byte counts.
+func writeApplied(code byte, want, got uint16) bool {
+	if code == VCPInputSource {
+		return want&0xFF == got&0xFF
+	}
+	return want == got
+}
@@ -0,0 +1,169 @@
+package ddc
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// RouterClient combines several backends, e.g. DDC/CI for external monitors
+// and the kernel backlight for a laptop panel. Monitors that several
+// backends detect on the same connector are merged, and every request goes
+// to the first backend that supports it on that monitor.
+type RouterClient struct {
+	backends []DDCClient
+
+	mu     sync.Mutex
+	owners map[string][]routeTarget // Backends that detected each monitor, in priority order
+}
+
+// routeTarget is a monitor as one backend knows it
+type routeTarget struct {
+	backend DDCClient
+	monitor Monitor // With the backend's own ID
+}
+
+// NewRouterClient routes between backends, the first being the primary:
+// its monitor IDs win when monitors are merged
+func NewRouterClient(backends ...DDCClient) *RouterClient {
+	return &RouterClient{backends: backends, owners: make(map[string][]routeTarget)}
+}
+
+// Backend names every backend, e.g. "i2c-dev + backlight"
+func (r *RouterClient) Backend() string {
+	var names []string
+	for _, b := range r.backends {
+		if name := BackendName(b); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, " + ")
+}
+
+// DetectMonitors detects with every backend. A failing secondary backend
+// is skipped; the primary's error is only returned when nothing was found.
+func (r *RouterClient) DetectMonitors() ([]Monitor, error) {
+	var monitors []Monitor
+	owners := make(map[string][]routeTarget)
+	var firstErr error
+
+	for i, b := range r.backends {
+		found, err := b.DetectMonitors()
+		if err != nil && i == 0 {
+			firstErr = err
+		}
+		for _, m := range found {
+			target := routeTarget{backend: b, monitor: m}
+			if j := connectorIndex(monitors, m.Connector); j >= 0 {
+				owners[monitors[j].ID] = append(owners[monitors[j].ID], target)
+				if monitors[j].DDCTool == "" {
+					monitors[j].DDCTool = m.DDCTool
+				}
+				continue
+			}
+			if _, taken := owners[m.ID]; taken {
+				m.ID = fmt.Sprintf("%s-%d", m.ID, i)
+			}
+			owners[m.ID] = []routeTarget{target}
+			monitors = append(monitors, m)
+		}
+	}
+
+	r.mu.Lock()
+	r.owners = owners
+	r.mu.Unlock()
+
+	if len(monitors) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return monitors, nil
+}
+
+// connectorIndex finds the monitor on connector, or -1
+func connectorIndex(monitors []Monitor, connector string) int {
+	if connector == "" {
+		return -1
+	}
+	for i, m := range monitors {
+		if m.Connector == connector {
+			return i
+		}
+	}
+	return -1
+}
+
+// Features combines what every backend of the monitor supports
+func (r *RouterClient) Features(m Monitor) FeatureSet {
+	r.mu.Lock()
+	owners := r.owners[m.ID]
+	r.mu.Unlock()
+
+	set := FeatureSet{}
+	for _, t := range owners {
+		for f, ok := range MonitorFeatures(t.backend, t.monitor) {
+			set[f] = set[f] || ok
+		}
+	}
+	return set
+}
+
+// route picks the backend for code, or for feature if one is given, on the
+// monitor: the first supporting it, or else the primary one so that it
+// reports the error. It returns the monitor's ID in that backend. Before
+// detection every request goes to the primary backend.
+func (r *RouterClient) route(monitorID string, code byte, feature Feature) (DDCClient, string) {
+	r.mu.Lock()
+	owners := r.owners[monitorID]
+	r.mu.Unlock()
+
+	if len(owners) == 0 {
+		return r.backends[0], monitorID
+	}
+	for _, t := range owners {
+		set := MonitorFeatures(t.backend, t.monitor)
+		if (feature != "" && set[feature]) || (feature == "" && set.Supports(code)) {
+			return t.backend, t.monitor.ID
+		}
+	}
+	return owners[0].backend, owners[0].monitor.ID
+}
+
+func (r *RouterClient) GetCapabilities(monitorID string) (*Capabilities, error) {
+	b, id := r.route(monitorID, 0, FeatureCapabilities)
+	return b.GetCapabilities(id)
+}
+
+func (r *RouterClient) SetVCP(monitorID string, code byte, value uint16) error {
+	b, id := r.route(monitorID, code, "")
+	return b.SetVCP(id, code, value)
+}
+
+func (r *RouterClient) GetVCP(monitorID string, code byte) (uint16, error) {
+	b, id := r.route(monitorID, code, "")
+	return b.GetVCP(id, code)
+}
+
+func (r *RouterClient) GetVCPValue(monitorID string, code byte) (VCPValue, error) {
+	b, id := r.route(monitorID, code, "")
+	return b.GetVCPValue(id, code)
+}
+
+// SetVCPBatch keeps a batch in one backend session when a single backend
+// handles every write
+func (r *RouterClient) SetVCPBatch(monitorID string, writes []VCPWrite) error {
+	if len(writes) == 0 {
+		return nil
+	}
+	backend, id := r.route(monitorID, writes[0].Code, "")
+	for _, w := range writes[1:] {
+		if b, _ := r.route(monitorID, w.Code, ""); b != backend {
+			return setVCPsSequentially(r, monitorID, writes)
+		}
+	}
+	return SetVCPs(backend, id, writes)
+}
+
+// Unwrap returns the primary backend
+func (r *RouterClient) Unwrap() DDCClient {
+	return r.backends[0]
+}
@@ -0,0 +1,103 @@
+package ddc
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// CommandRunner runs the command-line tools the client drives: ddcutil,
+// m1ddc, ddcctl, xrandr and system_profiler. SetCommandRunner swaps it out,
+// e.g. for a ReplayRunner, so output parsing can be exercised without the
+// tools or a monitor.
+type CommandRunner interface {
+	// Output runs name and returns its standard output
+	Output(ctx context.Context, name string, args ...string) ([]byte, error)
+	// CombinedOutput runs name and returns its standard output and error
+	CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error)
+	// LookPath reports where a tool is installed, like exec.LookPath
+	LookPath(file string) (string, error)
+}
+
+// ExecRunner runs commands with os/exec
+type ExecRunner struct{}
+
+func (ExecRunner) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
+}
+
+func (ExecRunner) CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
+
+func (ExecRunner) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+// SetCommandRunner replaces how the client runs external tools. A nil
+// runner restores ExecRunner.
+func (c *DDCClientImpl) SetCommandRunner(r CommandRunner) {
+	if r == nil {
+		r = ExecRunner{}
+	}
+	c.commands = r
+}
+
+// runTool runs a tool command line built by the macOS tool switches, which
+// leave it empty for tools they don't know
+func (c *DDCClientImpl) runTool(ctx context.Context, command []string) ([]byte, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("no DDC tool available")
+	}
+	return c.commands.Output(ctx, command[0], command[1:]...)
+}
+
+// Recording is the recorded result of one command
+type Recording struct {
+	Output string
+	Err    error
+}
+
+// ReplayRunner answers commands from recordings keyed by the command line,
+// e.g. "ddcutil --display 1 getvcp 0x60". Commands without a recording fail
+// as if the tool weren't installed. Every command run is kept in Calls.
+type ReplayRunner struct {
+	Recordings map[string]Recording
+
+	mu    sync.Mutex
+	Calls []string
+}
+
+func (r *ReplayRunner) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return r.replay(name, args)
+}
+
+func (r *ReplayRunner) CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return r.replay(name, args)
+}
+
+// LookPath finds tools that have at least one recording
+func (r *ReplayRunner) LookPath(file string) (string, error) {
+	for line := range r.Recordings {
+		if name, _, _ := strings.Cut(line, " "); name == file {
+			return file, nil
+		}
+	}
+	return "", &exec.Error{Name: file, Err: exec.ErrNotFound}
+}
+
+func (r *ReplayRunner) replay(name string, args []string) ([]byte, error) {
+	line := strings.Join(append([]string{name}, args...), " ")
+
+	r.mu.Lock()
+	r.Calls = append(r.Calls, line)
+	r.mu.Unlock()
+
+	rec, ok := r.Recordings[line]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, exec.ErrNotFound)
+	}
+	return []byte(rec.Output), rec.Err
+}
@@ -0,0 +1,22 @@
+package ddc
+
+// defaultMax is assumed for continuous features whose maximum is unknown
+const defaultMax = 100
+
+// PercentToRaw converts a 0-100 percentage to a raw value for a feature with
+// the given maximum (0 means unknown, treated as 100)
+func PercentToRaw(percent int, maxValue uint16) uint16 {
+	if maxValue == 0 {
+		maxValue = defaultMax
+	}
+	percent = min(max(percent, 0), 100)
+	return uint16((percent*int(maxValue) + 50) / 100)
+}
+
+// RawToPercent converts a raw value to a 0-100 percentage of maxValue
+func RawToPercent(raw, maxValue uint16) int {
+	if maxValue == 0 {
+		maxValue = defaultMax
+	}
+	return min((int(raw)*100+int(maxValue)/2)/int(maxValue), 100)
+}
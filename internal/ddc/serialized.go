@@ -0,0 +1,104 @@
+package ddc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sibteali786/monitorswitch/internal/filelock"
+)
+
+// SerializedClient wraps a DDCClient so that only one monitorswitch process
+// talks to the monitors at a time. Each call holds a system-wide file lock;
+// a call that can't take the lock fails rather than running unserialized.
+type SerializedClient struct {
+	DDCClient
+	path string
+}
+
+// NewSerializedClient wraps client with the shared DDC lock
+func NewSerializedClient(client DDCClient) *SerializedClient {
+	return &SerializedClient{
+		DDCClient: client,
+		path:      filepath.Join(os.TempDir(), "monitorswitch-ddc.lock"),
+	}
+}
+
+// lock takes the DDC lock. The lock file lives in the shared temp directory,
+// so it is never opened through a symlink, and only a file this call created
+// is made writable by everyone (despite the umask), so one user's lock file
+// doesn't lock other users out of the bus. Locking only needs read access.
+func (c *SerializedClient) lock() (func(), error) {
+	f, err := os.OpenFile(c.path, os.O_CREATE|os.O_EXCL|os.O_RDONLY|filelock.NoFollow, 0o666)
+	if err == nil {
+		f.Chmod(0o666)
+	} else if errors.Is(err, os.ErrExist) {
+		f, err = os.OpenFile(c.path, os.O_RDONLY|filelock.NoFollow, 0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open the DDC lock %s: %w", c.path, err)
+	}
+	if info, err := f.Stat(); err != nil || !info.Mode().IsRegular() {
+		f.Close()
+		return nil, fmt.Errorf("couldn't open the DDC lock %s: not a regular file", c.path)
+	}
+	if err := filelock.Lock(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("couldn't take the DDC lock %s: %w", c.path, err)
+	}
+	return func() {
+		filelock.Unlock(f)
+		f.Close()
+	}, nil
+}
+
+func (c *SerializedClient) DetectMonitors() ([]Monitor, error) {
+	unlock, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return c.DDCClient.DetectMonitors()
+}
+
+func (c *SerializedClient) GetCapabilities(monitorID string) (*Capabilities, error) {
+	unlock, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return c.DDCClient.GetCapabilities(monitorID)
+}
+
+func (c *SerializedClient) SetVCP(monitorID string, code byte, value uint16) error {
+	unlock, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return c.DDCClient.SetVCP(monitorID, code, value)
+}
+
+func (c *SerializedClient) GetVCP(monitorID string, code byte) (uint16, error) {
+	unlock, err := c.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	return c.DDCClient.GetVCP(monitorID, code)
+}
+
+func (c *SerializedClient) GetVCPValue(monitorID string, code byte) (VCPValue, error) {
+	unlock, err := c.lock()
+	if err != nil {
+		return VCPValue{}, err
+	}
+	defer unlock()
+	return c.DDCClient.GetVCPValue(monitorID, code)
+}
+
+// Unwrap returns the wrapped client
+func (c *SerializedClient) Unwrap() DDCClient {
+	return c.DDCClient
+}
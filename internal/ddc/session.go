@@ -0,0 +1,170 @@
+package ddc
+
+import (
+	"os"
+	"strings"
+)
+
+// Display servers a Linux desktop session can run on
+const (
+	DisplayServerX11     = "x11"
+	DisplayServerWayland = "wayland"
+	DisplayServerTTY     = "tty"
+)
+
+// Session describes the Linux or BSD desktop session monitorswitch runs in.
+// It decides which tools can enumerate outputs: xrandr only sees XWayland's
+// virtual outputs under Wayland, and nothing real in a VNC or RDP session.
+type Session struct {
+	DisplayServer string `json:"display_server"`
+	Desktop       string `json:"desktop,omitempty"` // GNOME, KDE, sway, Hyprland...
+	Remote        string `json:"remote,omitempty"`  // ssh, vnc or rdp
+	XWayland      bool   `json:"xwayland,omitempty"`
+}
+
+// remoteServers maps processes that serve a remote desktop to its protocol
+var remoteServers = map[string]string{
+	"Xvnc":      "vnc",
+	"Xtigervnc": "vnc",
+	"x11vnc":    "vnc",
+	"wayvnc":    "vnc",
+	"xrdp":      "rdp",
+	"Xorg.xrdp": "rdp",
+}
+
+// Session returns the desktop session, or nil on systems without one to
+// detect (macOS and Windows)
+func (d *Detector) Session() *Session {
+	if d.osType != OSLinux && !d.osType.IsBSD() {
+		return nil
+	}
+	s := DetectSession()
+	return &s
+}
+
+// DetectSession inspects the environment of the current process
+func DetectSession() Session {
+	var s Session
+
+	switch strings.ToLower(os.Getenv("XDG_SESSION_TYPE")) {
+	case "wayland":
+		s.DisplayServer = DisplayServerWayland
+	case "x11":
+		s.DisplayServer = DisplayServerX11
+	default:
+		// Unset under sudo and in many ssh -X sessions
+		switch {
+		case os.Getenv("WAYLAND_DISPLAY") != "":
+			s.DisplayServer = DisplayServerWayland
+		case os.Getenv("DISPLAY") != "":
+			s.DisplayServer = DisplayServerX11
+		default:
+			s.DisplayServer = DisplayServerTTY
+		}
+	}
+	s.XWayland = s.DisplayServer == DisplayServerWayland && os.Getenv("DISPLAY") != ""
+
+	switch DetectCompositor() {
+	case CompositorSway:
+		s.Desktop = "sway"
+	case CompositorHyprland:
+		s.Desktop = "Hyprland"
+	case CompositorWlroots:
+		s.Desktop = desktopName(os.Getenv("XDG_CURRENT_DESKTOP"), os.Getenv("DESKTOP_SESSION"))
+		if s.Desktop == "" {
+			s.Desktop = "wlroots"
+		}
+	default:
+		s.Desktop = desktopName(os.Getenv("XDG_CURRENT_DESKTOP"), os.Getenv("DESKTOP_SESSION"))
+	}
+
+	s.Remote = remoteProtocol()
+	return s
+}
+
+// desktopName normalizes XDG_CURRENT_DESKTOP, a colon-separated list such as
+// "ubuntu:GNOME", falling back to DESKTOP_SESSION
+func desktopName(current, session string) string {
+	for _, name := range strings.Split(current, ":") {
+		switch strings.ToLower(name) {
+		case "gnome", "gnome-classic", "gnome-flashback":
+			return "GNOME"
+		case "kde", "plasma":
+			return "KDE"
+		case "sway":
+			return "sway"
+		case "hyprland":
+			return "Hyprland"
+		}
+	}
+	if name, _, _ := strings.Cut(current, ":"); name != "" {
+		return name
+	}
+	return session
+}
+
+// remoteProtocol reports whether the session is being used remotely. VNC and
+// RDP servers run their own X server, so the DISPLAY we'd query is virtual.
+func remoteProtocol() string {
+	if os.Getenv("XRDP_SESSION") != "" {
+		return "rdp"
+	}
+	if os.Getenv("VNCDESKTOP") != "" {
+		return "vnc"
+	}
+	if names, err := processNames(); err == nil && os.Getenv("DISPLAY") != "" {
+		for _, name := range names {
+			if protocol, ok := remoteServers[name]; ok {
+				return protocol
+			}
+		}
+	}
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "" {
+		return "ssh"
+	}
+	return ""
+}
+
+// CanUseXrandr reports whether xrandr lists the real outputs of this machine
+func (s Session) CanUseXrandr() bool {
+	return s.DisplayServer == DisplayServerX11 && s.Remote != "vnc" && s.Remote != "rdp"
+}
+
+// String describes the session, e.g. "Wayland (GNOME)" or "X11 over rdp"
+func (s Session) String() string {
+	var b strings.Builder
+	switch s.DisplayServer {
+	case DisplayServerX11:
+		b.WriteString("X11")
+	case DisplayServerWayland:
+		b.WriteString("Wayland")
+	default:
+		b.WriteString("no graphical session")
+	}
+	if s.Desktop != "" {
+		b.WriteString(" (" + s.Desktop + ")")
+	}
+	if s.Remote != "" {
+		b.WriteString(" over " + s.Remote)
+	}
+	return b.String()
+}
+
+// Advice explains how the session limits monitor detection, for detect and
+// report. ddcAvailable is whether i2c-dev or ddcutil can reach the monitors.
+func (s Session) Advice(ddcAvailable bool) []string {
+	var advice []string
+	switch s.Remote {
+	case "vnc", "rdp":
+		advice = append(advice, "This is a remote ("+strings.ToUpper(s.Remote)+") session: its screen is virtual, so only monitors physically attached to this machine can be controlled, and xrandr isn't used to list them")
+	case "ssh":
+		advice = append(advice, "Running over SSH: DDC/CI still reaches this machine's monitors, but output names come from the kernel rather than the desktop")
+	}
+	if s.DisplayServer == DisplayServerWayland && DetectCompositor() == CompositorNone {
+		advice = append(advice, "Under Wayland xrandr only sees XWayland's virtual outputs; outputs are listed from the kernel's DRM connectors instead")
+	}
+	if !ddcAvailable {
+		advice = append(advice, "Install ddcutil, or on Linux load the i2c-dev module (sudo modprobe i2c-dev), to switch inputs; the desktop session alone can't talk DDC/CI")
+	}
+	return advice
+}
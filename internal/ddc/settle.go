@@ -0,0 +1,197 @@
+package ddc
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// maxLearnedDelay caps how far a learned settle delay can grow
+const maxLearnedDelay = 2 * time.Second
+
+// SettleDelays are how long a monitor needs before it responds reliably again
+type SettleDelays struct {
+	Write  time.Duration // After any write, before the next command
+	Verify time.Duration // After a write, before reading it back to verify
+	Input  time.Duration // After an input switch, before the next command
+}
+
+// DefaultSettleDelays suit most monitors
+var DefaultSettleDelays = SettleDelays{
+	Write:  interWriteDelay,
+	Verify: 500 * time.Millisecond,
+}
+
+// SettleDelaysFor returns the delays for monitor, with any write_delay,
+// verify_delay or input_delay from matching quirks.json entries applied
+func SettleDelaysFor(monitor Monitor) SettleDelays {
+	d := DefaultSettleDelays
+	quirks, _ := LoadQuirks()
+	for _, q := range quirks {
+		if !q.Matches(monitor) {
+			continue
+		}
+		setDuration(&d.Write, q.WriteDelay)
+		setDuration(&d.Verify, q.VerifyDelay)
+		setDuration(&d.Input, q.InputDelay)
+	}
+	return d
+}
+
+func setDuration(d *time.Duration, s string) {
+	if v, err := time.ParseDuration(s); err == nil && s != "" {
+		*d = v
+	}
+}
+
+// SettlingClient waits for each monitor's settle delay between commands. A
+// command that fails soon after a write is retried once after waiting again,
+// and the monitor's learned delay grows so the next run waits long enough.
+type SettlingClient struct {
+	DDCClient
+
+	// Learned holds extra delays learned in earlier runs, by StableKey.
+	// OnLearn, if set, is told when one grows so it can be persisted.
+	Learned map[string]time.Duration
+	OnLearn func(key string, delay time.Duration)
+
+	mu       sync.Mutex
+	monitors map[string]Monitor
+	busyTill map[string]time.Time
+}
+
+// NewSettlingClient wraps client with per-monitor settle delays
+func NewSettlingClient(client DDCClient) *SettlingClient {
+	return &SettlingClient{
+		DDCClient: client,
+		Learned:   make(map[string]time.Duration),
+		monitors:  make(map[string]Monitor),
+		busyTill:  make(map[string]time.Time),
+	}
+}
+
+func (c *SettlingClient) DetectMonitors() ([]Monitor, error) {
+	monitors, err := c.DDCClient.DetectMonitors()
+	c.mu.Lock()
+	for _, m := range monitors {
+		c.monitors[m.ID] = m
+	}
+	c.mu.Unlock()
+	return monitors, err
+}
+
+// Delays returns the settle delays in effect for a monitor, learned ones included
+func (c *SettlingClient) Delays(monitorID string) SettleDelays {
+	c.mu.Lock()
+	m, ok := c.monitors[monitorID]
+	if !ok {
+		m = Monitor{ID: monitorID}
+	}
+	learned := c.Learned[StableKey(m)]
+	c.mu.Unlock()
+
+	d := SettleDelaysFor(m)
+	d.Write = max(d.Write, learned)
+	return d
+}
+
+func (c *SettlingClient) GetCapabilities(monitorID string) (*Capabilities, error) {
+	var caps *Capabilities
+	err := c.settle(monitorID, func() (err error) {
+		caps, err = c.DDCClient.GetCapabilities(monitorID)
+		return err
+	})
+	return caps, err
+}
+
+func (c *SettlingClient) GetVCP(monitorID string, code byte) (uint16, error) {
+	value, err := c.GetVCPValue(monitorID, code)
+	return value.Current, err
+}
+
+func (c *SettlingClient) GetVCPValue(monitorID string, code byte) (VCPValue, error) {
+	var value VCPValue
+	err := c.settle(monitorID, func() (err error) {
+		value, err = c.DDCClient.GetVCPValue(monitorID, code)
+		return err
+	})
+	return value, err
+}
+
+func (c *SettlingClient) SetVCP(monitorID string, code byte, value uint16) error {
+	err := c.settle(monitorID, func() error {
+		return c.DDCClient.SetVCP(monitorID, code, value)
+	})
+	if err == nil {
+		c.wrote(monitorID, code)
+	}
+	return err
+}
+
+func (c *SettlingClient) SetVCPBatch(monitorID string, writes []VCPWrite) error {
+	err := c.settle(monitorID, func() error {
+		return SetVCPs(c.DDCClient, monitorID, writes)
+	})
+	if err == nil && len(writes) > 0 {
+		c.wrote(monitorID, writes[len(writes)-1].Code)
+	}
+	return err
+}
+
+// settle waits out the monitor's delay, runs fn, and retries it once if it
+// failed while the monitor was still settling from a recent write
+func (c *SettlingClient) settle(monitorID string, fn func() error) error {
+	c.mu.Lock()
+	busyTill := c.busyTill[monitorID]
+	c.mu.Unlock()
+
+	recentWrite := !busyTill.IsZero() && time.Since(busyTill) < maxLearnedDelay
+	time.Sleep(time.Until(busyTill))
+
+	err := fn()
+	if err == nil || !recentWrite || errors.Is(err, ErrAsleep) || errors.Is(err, ErrReadOnly) {
+		return err
+	}
+
+	delay := c.Delays(monitorID).Write
+	time.Sleep(delay)
+	if err := fn(); err != nil {
+		return err
+	}
+	c.learn(monitorID, min(2*delay, maxLearnedDelay))
+	return nil
+}
+
+func (c *SettlingClient) wrote(monitorID string, code byte) {
+	d := c.Delays(monitorID)
+	wait := d.Write
+	if code == VCPInputSource {
+		wait = max(wait, d.Input)
+	}
+
+	c.mu.Lock()
+	c.busyTill[monitorID] = time.Now().Add(wait)
+	c.mu.Unlock()
+}
+
+func (c *SettlingClient) learn(monitorID string, delay time.Duration) {
+	c.mu.Lock()
+	key := monitorID
+	if m, ok := c.monitors[monitorID]; ok {
+		key = StableKey(m)
+	}
+	grew := delay > c.Learned[key]
+	if grew {
+		c.Learned[key] = delay
+	}
+	c.mu.Unlock()
+
+	if grew && c.OnLearn != nil {
+		c.OnLearn(key, delay)
+	}
+}
+
+// Unwrap returns the wrapped client
+func (c *SettlingClient) Unwrap() DDCClient {
+	return c.DDCClient
+}
@@ -0,0 +1,67 @@
+package ddc
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrAsleep is returned for reads from a monitor that is in standby
+var ErrAsleep = errors.New("monitor is asleep")
+
+// StandbyClient skips reads from sleeping monitors, which otherwise stall for
+// the full DDC timeout and can wake the monitor. Writes still go through, so
+// a power-on or input switch can wake it deliberately.
+type StandbyClient struct {
+	DDCClient
+
+	mu       sync.Mutex
+	monitors map[string]Monitor
+}
+
+// NewStandbyClient wraps client with standby checks
+func NewStandbyClient(client DDCClient) *StandbyClient {
+	return &StandbyClient{DDCClient: client, monitors: make(map[string]Monitor)}
+}
+
+func (c *StandbyClient) DetectMonitors() ([]Monitor, error) {
+	monitors, err := c.DDCClient.DetectMonitors()
+	c.mu.Lock()
+	for _, m := range monitors {
+		c.monitors[m.ID] = m
+	}
+	c.mu.Unlock()
+	return monitors, err
+}
+
+func (c *StandbyClient) GetCapabilities(monitorID string) (*Capabilities, error) {
+	if c.asleep(monitorID) {
+		return nil, ErrAsleep
+	}
+	return c.DDCClient.GetCapabilities(monitorID)
+}
+
+func (c *StandbyClient) GetVCP(monitorID string, code byte) (uint16, error) {
+	if c.asleep(monitorID) {
+		return 0, ErrAsleep
+	}
+	return c.DDCClient.GetVCP(monitorID, code)
+}
+
+func (c *StandbyClient) GetVCPValue(monitorID string, code byte) (VCPValue, error) {
+	if c.asleep(monitorID) {
+		return VCPValue{}, ErrAsleep
+	}
+	return c.DDCClient.GetVCPValue(monitorID, code)
+}
+
+func (c *StandbyClient) asleep(monitorID string) bool {
+	c.mu.Lock()
+	m, ok := c.monitors[monitorID]
+	c.mu.Unlock()
+	return ok && MonitorAsleep(m)
+}
+
+// Unwrap returns the wrapped client
+func (c *StandbyClient) Unwrap() DDCClient {
+	return c.DDCClient
+}
@@ -0,0 +1,20 @@
+//go:build darwin
+
+package ddc
+
+import (
+	"strconv"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/macos"
+)
+
+// MonitorAsleep reports whether CoreGraphics considers the display asleep.
+// Monitors not identified by a CGDirectDisplayID count as awake.
+func MonitorAsleep(m Monitor) bool {
+	id, err := strconv.ParseUint(m.ID, 10, 32)
+	if err != nil {
+		return false
+	}
+	asleep, err := macos.DisplayAsleep(uint32(id))
+	return err == nil && asleep
+}
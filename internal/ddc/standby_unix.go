@@ -0,0 +1,25 @@
+//go:build !windows && !darwin
+
+package ddc
+
+import (
+	"os"
+	"strings"
+)
+
+// MonitorAsleep reports whether the monitor's DRM connector is in a DPMS
+// power-saving state. Monitors without a known connector count as awake.
+func MonitorAsleep(m Monitor) bool {
+	if m.Connector == "" {
+		return false
+	}
+
+	for _, path := range drmGlob("card*-" + m.Connector + "/dpms") {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		return strings.TrimSpace(string(data)) != "On"
+	}
+	return false
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package ddc
+
+// MonitorAsleep always reports awake: Windows exposes no per-monitor power
+// state without a physical monitor handle, which the client doesn't have yet
+func MonitorAsleep(m Monitor) bool {
+	return false
+}
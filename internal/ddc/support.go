@@ -0,0 +1,65 @@
+package ddc
+
+// SupportLevel describes how far a feature can be controlled through the tool
+type SupportLevel string
+
+const (
+	SupportYes      SupportLevel = "yes"
+	SupportNo       SupportLevel = "no"
+	SupportReadOnly SupportLevel = "read-only"
+)
+
+// FeatureSupport is one row of a SupportMatrix
+type FeatureSupport struct {
+	Feature string       `json:"feature"`
+	Code    byte         `json:"code"`
+	Level   SupportLevel `json:"level"`
+}
+
+// SupportMatrix lists per-feature support for a monitor
+type SupportMatrix []FeatureSupport
+
+// matrixFeatures are the features shown in the support matrix, in display order
+var matrixFeatures = []struct {
+	name string
+	code byte
+}{
+	{"input switch", VCPInputSource},
+	{"brightness", VCPBrightness},
+	{"contrast", VCPContrast},
+	{"volume", VCPVolume},
+	{"power", VCPPowerMode},
+}
+
+// BuildSupportMatrix derives feature support from the advertised capabilities
+// and a read probe of each feature. A feature that answers reads but isn't
+// advertised is reported read-only; without capabilities, readable means yes.
+func BuildSupportMatrix(client DDCClient, monitor Monitor) SupportMatrix {
+	caps, err := client.GetCapabilities(monitor.ID)
+	if err != nil {
+		caps = nil
+	}
+	known := caps.Known()
+
+	var matrix SupportMatrix
+	for _, f := range matrixFeatures {
+		advertised := caps.Supports(f.code)
+		if f.code == VCPInputSource && len(monitor.Inputs) > 0 {
+			advertised = true
+		}
+		_, readErr := client.GetVCP(monitor.ID, f.code)
+
+		level := SupportNo
+		switch {
+		case advertised:
+			level = SupportYes
+		case readErr == nil && known:
+			level = SupportReadOnly
+		case readErr == nil:
+			level = SupportYes
+		}
+
+		matrix = append(matrix, FeatureSupport{Feature: f.name, Code: f.code, Level: level})
+	}
+	return matrix
+}
@@ -1,6 +1,9 @@
 package ddc
 
-import "runtime"
+import (
+	"log/slog"
+	"runtime"
+)
 
 // OSType represents the operating system type
 type OSType string
@@ -9,54 +12,107 @@ const (
 	OSLinux   OSType = "linux"
 	OSMacOS   OSType = "darwin"
 	OSWindows OSType = "windows"
+	OSFreeBSD OSType = "freebsd"
+	OSOpenBSD OSType = "openbsd"
 )
 
+// IsBSD reports whether the OS is one of the BSDs. They share the Linux
+// client code: ddcutil where installed, DRM connectors and xrandr.
+func (o OSType) IsBSD() bool {
+	return o == OSFreeBSD || o == OSOpenBSD
+}
+
+// OSInfo describes the operating system. Name and Version summarize it;
+// the one of Linux, MacOS, Windows or BSD matching Type has the details.
+type OSInfo struct {
+	Type    OSType       `json:"type"`
+	Name    string       `json:"name,omitempty"`    // e.g. "Ubuntu", "macOS", "Windows 11 Pro"
+	Version string       `json:"version,omitempty"` // e.g. "22.04", "14.5", "10.0.22631"
+	Linux   *LinuxInfo   `json:"linux,omitempty"`
+	MacOS   *MacOSInfo   `json:"macos,omitempty"`
+	Windows *WindowsInfo `json:"windows,omitempty"`
+	BSD     *BSDInfo     `json:"bsd,omitempty"`
+	Error   string       `json:"error,omitempty"` // Why the details couldn't be read
+}
+
 // LinuxInfo contains detailed Linux distribution information
 type LinuxInfo struct {
-	Name          string // Distribution name (e.g., "Ubuntu")
-	Version       string // Version number (e.g., "20.04")
-	ID            string // Distribution ID (e.g., "ubuntu")
-	VersionID     string // Version ID (e.g., "20.04")
-	PrettyName    string // Pretty name (e.g., "Ubuntu 20.04.3 LTS")
-	Codename      string // Release codename (e.g., "focal")
-	KernelName    string // Kernel name (e.g., "Linux")
-	KernelRelease string // Kernel release (e.g., "5.4.0-88-generic")
-	KernelVersion string // Kernel version
-	Machine       string // Machine architecture (e.g., "x86_64")
+	Name          string `json:"name,omitempty"`           // Distribution name (e.g., "Ubuntu")
+	Version       string `json:"version,omitempty"`        // Version number (e.g., "20.04")
+	ID            string `json:"id,omitempty"`             // Distribution ID (e.g., "ubuntu")
+	VersionID     string `json:"version_id,omitempty"`     // Version ID (e.g., "20.04")
+	PrettyName    string `json:"pretty_name,omitempty"`    // Pretty name (e.g., "Ubuntu 20.04.3 LTS")
+	Codename      string `json:"codename,omitempty"`       // Release codename (e.g., "focal")
+	KernelName    string `json:"kernel_name,omitempty"`    // Kernel name (e.g., "Linux")
+	KernelRelease string `json:"kernel_release,omitempty"` // Kernel release (e.g., "5.4.0-88-generic")
+	KernelVersion string `json:"kernel_version,omitempty"` // Kernel version
+	Machine       string `json:"machine,omitempty"`        // Machine architecture (e.g., "x86_64")
+}
+
+// BSDInfo contains FreeBSD or OpenBSD release information
+type BSDInfo struct {
+	Name          string `json:"name,omitempty"`           // OS name (e.g., "FreeBSD")
+	Version       string `json:"version,omitempty"`        // Userland version (e.g., "14.1-RELEASE-p3")
+	KernelRelease string `json:"kernel_release,omitempty"` // Kernel release (e.g., "14.1-RELEASE")
+	Machine       string `json:"machine,omitempty"`        // Machine architecture (e.g., "amd64")
 }
 
 // MacOSInfo contains detailed macOS system information
 type MacOSInfo struct {
-	ProductName    string // Product name (e.g., "macOS")
-	ProductVersion string // Version (e.g., "12.6")
-	BuildVersion   string // Build version (e.g., "21G115")
-	KernelName     string // Kernel name (e.g., "Darwin")
-	KernelRelease  string // Kernel release (e.g., "21.6.0")
-	KernelVersion  string // Kernel version
-	Machine        string // Machine architecture (e.g., "x86_64")
-	ModelName      string // Model name (e.g., "MacBook Pro")
-	ModelID        string // Model identifier (e.g., "MacBookPro16,1")
+	ProductName    string `json:"product_name,omitempty"`    // Product name (e.g., "macOS")
+	ProductVersion string `json:"product_version,omitempty"` // Version (e.g., "12.6")
+	BuildVersion   string `json:"build_version,omitempty"`   // Build version (e.g., "21G115")
+	KernelName     string `json:"kernel_name,omitempty"`     // Kernel name (e.g., "Darwin")
+	KernelRelease  string `json:"kernel_release,omitempty"`  // Kernel release (e.g., "21.6.0")
+	KernelVersion  string `json:"kernel_version,omitempty"`  // Kernel version
+	Machine        string `json:"machine,omitempty"`         // Machine architecture (e.g., "x86_64")
+	ModelName      string `json:"model_name,omitempty"`      // Model name (e.g., "MacBook Pro")
+	ModelID        string `json:"model_id,omitempty"`        // Model identifier (e.g., "MacBookPro16,1")
 }
 
 // WindowsInfo contains detailed Windows system information
 type WindowsInfo struct {
-	ProductName     string // Product name (e.g., "Windows 11 Pro")
-	Version         string // Version (e.g., "10.0.22000")
-	Build           string // Build number (e.g., "22000")
-	DisplayVersion  string // Display version (e.g., "21H2")
-	Edition         string // Edition (e.g., "Pro", "Home")
-	Architecture    string // Architecture (e.g., "AMD64")
-	InstallDate     string // Install date
-	RegisteredOwner string // Registered owner
-	SystemRoot      string // System root (e.g., "C:\\Windows")
+	ProductName     string `json:"product_name,omitempty"`    // Product name (e.g., "Windows 11 Pro")
+	Version         string `json:"version,omitempty"`         // Version (e.g., "10.0.22000")
+	Build           string `json:"build,omitempty"`           // Build number (e.g., "22000")
+	DisplayVersion  string `json:"display_version,omitempty"` // Display version (e.g., "21H2")
+	Edition         string `json:"edition,omitempty"`         // Edition (e.g., "Pro", "Home")
+	Architecture    string `json:"architecture,omitempty"`    // Architecture (e.g., "AMD64")
+	InstallDate     string `json:"install_date,omitempty"`    // Install date
+	RegisteredOwner string `json:"-"`                         // Registered owner, kept out of reports
+	SystemRoot      string `json:"system_root,omitempty"`     // System root (e.g., "C:\\Windows")
 }
 
+// Common VCP feature codes
+const (
+	VCPBrightness  byte = 0x10
+	VCPContrast    byte = 0x12
+	VCPInputSource byte = 0x60
+	VCPVolume      byte = 0x62
+	VCPPowerMode   byte = 0xD6
+)
+
 // DDCClient interface defines the contract for DDC/CI monitor control
 type DDCClient interface {
 	DetectMonitors() ([]Monitor, error)
 	GetCapabilities(monitorId string) (*Capabilities, error)
 	SetVCP(monitorID string, code byte, value uint16) error
 	GetVCP(monitorID string, code byte) (uint16, error)
+	GetVCPValue(monitorID string, code byte) (VCPValue, error)
+}
+
+// VCPValue is a VCP reading with the maximum the monitor reports for it
+type VCPValue struct {
+	Current uint16 `json:"current"`
+	Max     uint16 `json:"max,omitempty"` // 0 if the backend doesn't report a maximum
+}
+
+// MaxOr returns the reported maximum, or def if none was reported
+func (v VCPValue) MaxOr(def uint16) uint16 {
+	if v.Max == 0 {
+		return def
+	}
+	return v.Max
 }
 
 // Monitor represents a physical monitor
@@ -65,24 +121,90 @@ type Monitor struct {
 	Name         string          // Human-readable monitor name
 	Inputs       map[string]byte // Available input sources (name -> VCP code)
 	CurrentInput string          // Currently active input source
+	Connector    string          // Output/connector name (e.g., "DP-3"), if known
+	UUID         string          // Stable display UUID (macOS), if known
+	Main         bool            // Whether this is the OS main/primary display
+
+	// Identity from the EDID, if the OS exposes it. StableID survives reboots
+	// and port changes, unlike ID, which is an enumeration index on most backends.
+	Manufacturer string // Three-letter PNP ID, e.g. "DEL"
+	Model        string // Model name from the EDID
+	Serial       string // Serial number
+	StableID     string // e.g. "DEL-A0B1-8M2TZ13"; empty without a serial number
+
+	// How detection reached the monitor. Outputs only listed by the desktop
+	// or the kernel have DDCSupported false and can't be controlled.
+	DDCSupported bool                 // Whether the monitor answered DDC/CI
+	DDCTool      string               // "i2c-dev", "ddcutil", "IOKit", "m1ddc", "ddcctl" or "dxva2"
+	Validation   *DDCValidationResult // Whether writes take effect, if the backend probed it
+}
+
+// StableKey identifies a monitor in what is kept across runs: its StableID,
+// or its ID when it has none, since IDs can move to another monitor after a
+// reboot or replug
+func StableKey(m Monitor) string {
+	if m.StableID != "" {
+		return m.StableID
+	}
+	return m.ID
 }
 
 // Capabilities represents monitor capabilities
 type Capabilities struct {
-	SupportedInputs     map[string]byte // Supported input sources (name -> VCP code)
-	SupportedBrightness bool            // Whether brightness control is supported
-	SupportedContrast   bool            // Whether contrast control is supported
+	SupportedInputs     map[string]byte   // Supported input sources (name -> VCP code)
+	SupportedBrightness bool              // Whether brightness control is supported
+	SupportedContrast   bool              // Whether contrast control is supported
+	SupportedVolume     bool              // Whether volume control is supported
+	SupportedPower      bool              // Whether power mode (VCP 0xD6) is supported
+	Features            []byte            // Every advertised VCP code, if the backend reports them
+	Values              map[byte][]uint16 // Accepted values of non-continuous features, e.g. 0x60 -> 0x0F, 0x11
+	Raw                 string            // Unparsed MCCS capabilities string, if the backend exposes it
+
+	// Other sections of the capabilities string, if present
+	Protocol    string // prot(), usually "monitor"
+	Type        string // type(), e.g. "lcd"
+	Model       string // model()
+	MCCSVersion string // mccs_ver(), e.g. "2.1"
+}
+
+// Known reports whether the monitor advertised anything at all
+func (c *Capabilities) Known() bool {
+	return c != nil && (len(c.SupportedInputs) > 0 || c.SupportedBrightness ||
+		c.SupportedContrast || c.SupportedVolume || c.SupportedPower)
+}
+
+// Supports reports whether a VCP feature code is advertised
+func (c *Capabilities) Supports(code byte) bool {
+	if c == nil {
+		return false
+	}
+
+	switch code {
+	case VCPInputSource:
+		return len(c.SupportedInputs) > 0
+	case VCPBrightness:
+		return c.SupportedBrightness
+	case VCPContrast:
+		return c.SupportedContrast
+	case VCPVolume:
+		return c.SupportedVolume
+	case VCPPowerMode:
+		return c.SupportedPower
+	}
+	return false
 }
 
 // Detector is the main OS detection struct
 type Detector struct {
 	osType OSType
+	logger *slog.Logger // See SetLogger
 }
 
 // NewDetector creates a new OS detector instance
 func NewDetector() *Detector {
 	return &Detector{
 		osType: OSType(runtime.GOOS),
+		logger: discardLogger,
 	}
 }
 
@@ -0,0 +1,97 @@
+package ddc
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sibteali786/monitorswitch/internal/mccs"
+)
+
+// ValidatingClient checks writes against the monitor's advertised
+// capabilities. Monitors silently ignore features and values they don't
+// support, so refusing up front gives a clear error instead of nothing.
+// Monitors whose capabilities can't be read are never blocked.
+type ValidatingClient struct {
+	DDCClient
+	Force bool // Skip validation
+
+	mu   sync.Mutex
+	caps map[string]*Capabilities
+}
+
+// NewValidatingClient wraps client with capability validation
+func NewValidatingClient(client DDCClient) *ValidatingClient {
+	return &ValidatingClient{DDCClient: client, caps: make(map[string]*Capabilities)}
+}
+
+func (c *ValidatingClient) SetVCP(monitorID string, code byte, value uint16) error {
+	if !c.Force {
+		if err := c.validate(monitorID, code, value); err != nil {
+			return err
+		}
+	}
+	return c.DDCClient.SetVCP(monitorID, code, value)
+}
+
+func (c *ValidatingClient) validate(monitorID string, code byte, value uint16) error {
+	c.mu.Lock()
+	caps, ok := c.caps[monitorID]
+	c.mu.Unlock()
+	if !ok {
+		caps, _ = c.DDCClient.GetCapabilities(monitorID)
+		c.mu.Lock()
+		c.caps[monitorID] = caps
+		c.mu.Unlock()
+	}
+	if !caps.Known() {
+		return nil
+	}
+
+	if !advertises(caps, code) {
+		return fmt.Errorf("monitor %s does not advertise VCP 0x%02X (%s); use --force to send it anyway",
+			monitorID, code, mccs.Name(code))
+	}
+
+	if code == VCPInputSource && len(caps.SupportedInputs) > 0 {
+		for _, input := range caps.SupportedInputs {
+			if input == byte(value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("monitor %s does not advertise input 0x%02X (%s); use --force to send it anyway",
+			monitorID, byte(value), mccs.Decode(code, value))
+	}
+	return nil
+}
+
+// InvalidateCapabilities makes the next write read the monitor's
+// capabilities again
+func (c *ValidatingClient) InvalidateCapabilities(monitorID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.caps, monitorID)
+}
+
+// advertises reports whether caps lists code. When the backend doesn't
+// report the full feature list, only the features it does track are checked.
+func advertises(caps *Capabilities, code byte) bool {
+	if len(caps.Features) > 0 {
+		for _, f := range caps.Features {
+			if f == code {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch code {
+	case VCPInputSource, VCPBrightness, VCPContrast, VCPVolume, VCPPowerMode:
+		return caps.Supports(code)
+	}
+	return true
+}
+
+// Unwrap returns the wrapped client
+func (c *ValidatingClient) Unwrap() DDCClient {
+	return c.DDCClient
+}
@@ -0,0 +1,115 @@
+package ddc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kinds of virtualized environment
+const (
+	VirtualMachine   = "vm"
+	VirtualContainer = "container"
+	VirtualWSL       = "wsl"
+)
+
+// Virtualization is the VM or container monitorswitch runs in
+type Virtualization struct {
+	Kind string `json:"kind"` // VirtualMachine, VirtualContainer or VirtualWSL
+	Name string `json:"name"` // e.g. "Docker", "QEMU/KVM", "VMware"
+}
+
+// ErrVirtualized is wrapped by detection errors when monitorswitch runs in a
+// VM or container with no way to reach a physical monitor's DDC/CI bus
+var ErrVirtualized = errors.New("no physical display access")
+
+// VirtualizedError explains why detection stopped early and what to do
+type VirtualizedError struct {
+	Env Virtualization
+}
+
+func (e *VirtualizedError) Error() string {
+	switch e.Env.Kind {
+	case VirtualContainer:
+		return fmt.Sprintf("running in a %s container without i2c devices, so monitors can't be reached: "+
+			"on the host, load i2c-dev and pass the monitor's bus through (e.g. docker run --device /dev/i2c-N), "+
+			"or run monitorswitch on the host with --host", e.Env.Name)
+	case VirtualWSL:
+		return "running under WSL, which has no access to the monitors: run the Windows build (monitorswitch.exe) instead"
+	default:
+		return fmt.Sprintf("running in a %s virtual machine whose displays are virtual and don't speak DDC/CI: "+
+			"pass a physical GPU through to the VM, or control the host's monitors with --host", e.Env.Name)
+	}
+}
+
+func (e *VirtualizedError) Unwrap() error { return ErrVirtualized }
+
+// String describes the environment, e.g. "container (Docker)"
+func (v Virtualization) String() string {
+	return fmt.Sprintf("%s (%s)", v.Kind, v.Name)
+}
+
+// hypervisors maps DMI/BIOS vendor and product strings to hypervisor names
+var hypervisors = []struct{ match, name string }{
+	{"qemu", "QEMU/KVM"},
+	{"kvm", "QEMU/KVM"},
+	{"vmware", "VMware"},
+	{"virtualbox", "VirtualBox"},
+	{"innotek", "VirtualBox"},
+	{"parallels", "Parallels"},
+	{"xen", "Xen"},
+	{"bochs", "Bochs"},
+	{"virtual machine", "Hyper-V"}, // Microsoft Corporation "Virtual Machine"
+	{"virtualmac", "Apple Virtualization"},
+	{"google compute engine", "Google Compute Engine"},
+	{"amazon ec2", "Amazon EC2"},
+}
+
+// hypervisorName returns the hypervisor the firmware strings name, or ""
+func hypervisorName(fields ...string) string {
+	for _, field := range fields {
+		lower := strings.ToLower(field)
+		for _, h := range hypervisors {
+			if strings.Contains(lower, h.match) {
+				return h.name
+			}
+		}
+	}
+	return ""
+}
+
+// Virtual reports whether the adapter is emulated by a hypervisor rather
+// than a physical GPU with real display outputs
+func (g GPU) Virtual() bool {
+	switch g.Vendor {
+	case "VMware", "Red Hat (virtio)", "QEMU", "VirtualBox", "Parallels":
+		return true
+	case "Microsoft":
+		// Basic Display Adapter, Hyper-V Video and the RDP adapters
+		return true
+	}
+	return hypervisorName(g.Model) != ""
+}
+
+// checkPhysicalAccess fails fast with a VirtualizedError when running
+// virtualized without a passed-through GPU or i2c bus, instead of letting
+// every DDC/CI tool time out in turn
+func (c *DDCClientImpl) checkPhysicalAccess() error {
+	c.virtualOnce.Do(func() {
+		v := DetectVirtualization()
+		if v != nil && !physicalDisplayAccess(v) {
+			c.virtualErr = &VirtualizedError{Env: *v}
+		}
+	})
+	return c.virtualErr
+}
+
+// hasPhysicalGPU reports whether any graphics adapter is a real one
+func hasPhysicalGPU() bool {
+	for _, g := range NewDetector().GPUs() {
+		if !g.Virtual() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package ddc
+
+import "golang.org/x/sys/unix"
+
+// DetectVirtualization returns the VM this process runs in, or nil on bare
+// metal. macOS has no containers with display access to detect.
+func DetectVirtualization() *Virtualization {
+	if present, err := unix.SysctlUint32("kern.hv_vmm_present"); err != nil || present == 0 {
+		return nil
+	}
+
+	model, _ := unix.Sysctl("hw.model")
+	name := hypervisorName(model)
+	if name == "" {
+		name = "hypervisor"
+	}
+	return &Virtualization{Kind: VirtualMachine, Name: name}
+}
+
+// physicalDisplayAccess reports whether a VM can reach a real monitor. macOS
+// hypervisors don't pass through GPUs, so guests never can.
+func physicalDisplayAccess(v *Virtualization) bool {
+	return false
+}
@@ -0,0 +1,77 @@
+//go:build !windows && !darwin
+
+package ddc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DetectVirtualization returns the container or VM this process runs in,
+// or nil on bare metal
+func DetectVirtualization() *Virtualization {
+	if os.Getenv("WSL_DISTRO_NAME") != "" || strings.Contains(strings.ToLower(readSysfs("/proc/sys/kernel/osrelease")), "microsoft") {
+		return &Virtualization{Kind: VirtualWSL, Name: "WSL"}
+	}
+	if name := containerName(); name != "" {
+		return &Virtualization{Kind: VirtualContainer, Name: name}
+	}
+
+	name := hypervisorName(
+		readSysfs("/sys/class/dmi/id/sys_vendor"),
+		readSysfs("/sys/class/dmi/id/product_name"),
+		readSysfs("/sys/class/dmi/id/board_vendor"),
+		readSysfs("/sys/hypervisor/type"),
+	)
+	if name != "" {
+		return &Virtualization{Kind: VirtualMachine, Name: name}
+	}
+	return nil
+}
+
+// containerName identifies the container runtime from the marker files and
+// cgroups runtimes leave behind
+func containerName() string {
+	if _, err := os.Stat("/.dockerenv"); err == nil {
+		return "Docker"
+	}
+	if _, err := os.Stat("/run/.containerenv"); err == nil {
+		return "Podman"
+	}
+
+	cgroup := readSysfs("/proc/1/cgroup")
+	switch {
+	case strings.Contains(cgroup, "kubepods"):
+		return "Kubernetes"
+	case strings.Contains(cgroup, "docker"):
+		return "Docker"
+	case strings.Contains(cgroup, "/lxc"):
+		return "LXC"
+	}
+
+	// Set by systemd-nspawn, LXC and Podman for the container's init
+	switch env := os.Getenv("container"); env {
+	case "":
+		return ""
+	case "systemd-nspawn":
+		return "systemd-nspawn"
+	default:
+		return strings.ToUpper(env[:1]) + env[1:]
+	}
+}
+
+// physicalDisplayAccess reports whether a monitor's DDC/CI bus is reachable
+// anyway: a GPU passed through to a VM, or i2c devices passed into a container
+func physicalDisplayAccess(v *Virtualization) bool {
+	if v.Kind == VirtualWSL {
+		return false
+	}
+	buses, _ := filepath.Glob("/dev/i2c-*")
+	if len(buses) == 0 {
+		return false
+	}
+	// Containers only see the buses they were given; VMs can have an
+	// emulated SMBus, so also require a physical GPU
+	return v.Kind == VirtualContainer || hasPhysicalGPU()
+}
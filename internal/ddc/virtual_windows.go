@@ -0,0 +1,25 @@
+package ddc
+
+import "golang.org/x/sys/windows/registry"
+
+// DetectVirtualization returns the VM this process runs in, or nil on bare
+// metal, from the firmware strings Windows keeps in the registry
+func DetectVirtualization() *Virtualization {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `HARDWARE\DESCRIPTION\System\BIOS`, registry.QUERY_VALUE)
+	if err != nil {
+		return nil
+	}
+	defer key.Close()
+
+	manufacturer, _, _ := key.GetStringValue("SystemManufacturer")
+	product, _, _ := key.GetStringValue("SystemProductName")
+	if name := hypervisorName(manufacturer, product); name != "" {
+		return &Virtualization{Kind: VirtualMachine, Name: name}
+	}
+	return nil
+}
+
+// physicalDisplayAccess reports whether a GPU was passed through to the VM
+func physicalDisplayAccess(v *Virtualization) bool {
+	return hasPhysicalGPU()
+}
@@ -0,0 +1,175 @@
+package ddc
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// Parsers of the Windows system information tools. They don't depend on
+// Windows APIs, so they're tested with output captured on other languages.
+
+// systeminfo CSV columns. Headers and labels are translated, but the column
+// order is the same in every Windows language.
+const (
+	sysInfoOSName       = 1
+	sysInfoOSVersion    = 2
+	sysInfoOwner        = 6
+	sysInfoInstallDate  = 9
+	sysInfoSystemType   = 13
+	sysInfoWindowsDir   = 16
+	sysInfoColumnsKnown = 17
+)
+
+// windowsVersionPattern finds "10.0.22000" in "10.0.22000 N/A Build 22000",
+// "10.0.19045 Nicht zutreffend Build 19045" or "10.0.22631 N/A ビルド 22631"
+var windowsVersionPattern = regexp.MustCompile(`\b(\d+\.\d+)\.(\d+)\b`)
+
+// parseSystemInfoCSV reads systeminfo's CSV output by column position, so
+// it works on non-English Windows where the "OS Name" label is translated
+func parseSystemInfoCSV(output string, info *WindowsInfo) error {
+	r := csv.NewReader(strings.NewReader(output))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	record, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("failed to parse systeminfo output: %w", err)
+	}
+	if len(record) < sysInfoColumnsKnown {
+		return fmt.Errorf("unexpected systeminfo output: %d columns", len(record))
+	}
+
+	info.ProductName = strings.TrimSpace(record[sysInfoOSName])
+	if matches := windowsVersionPattern.FindStringSubmatch(record[sysInfoOSVersion]); matches != nil {
+		info.Version = matches[0]
+		info.Build = matches[2]
+	}
+	info.Architecture = strings.TrimSpace(record[sysInfoSystemType])
+	info.InstallDate = strings.TrimSpace(record[sysInfoInstallDate])
+	info.RegisteredOwner = strings.TrimSpace(record[sysInfoOwner])
+	info.SystemRoot = strings.TrimSpace(record[sysInfoWindowsDir])
+
+	// Verify we got at least some information
+	if info.ProductName == "" && info.Version == "" {
+		return fmt.Errorf("no useful information from systeminfo")
+	}
+
+	return nil
+}
+
+// parseCIMOutput reads the JSON printed by cimOSScript
+func parseCIMOutput(output []byte, info *WindowsInfo) error {
+	var result struct {
+		Caption          string
+		Version          string
+		BuildNumber      string
+		OSArchitecture   string
+		InstallDate      string
+		RegisteredUser   string
+		WindowsDirectory string
+	}
+	// PowerShell may prefix a UTF-8 byte order mark
+	if err := json.Unmarshal(bytes.TrimPrefix(output, []byte("\xef\xbb\xbf")), &result); err != nil {
+		return fmt.Errorf("failed to parse Get-CimInstance output: %w", err)
+	}
+
+	info.ProductName = result.Caption
+	info.Version = result.Version
+	info.Build = result.BuildNumber
+	info.Architecture = result.OSArchitecture
+	info.InstallDate = result.InstallDate
+	info.RegisteredOwner = result.RegisteredUser
+	info.SystemRoot = result.WindowsDirectory
+
+	if info.ProductName == "" && info.Version == "" {
+		return fmt.Errorf("no useful information from Get-CimInstance")
+	}
+	return nil
+}
+
+// parseWMIOutput reads "wmic os get ... /format:list" output
+func parseWMIOutput(output string, info *WindowsInfo) error {
+	lines := strings.Split(output, "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Lines look like: "Caption=Microsoft Windows 11 Pro"; property names
+		// are not translated, values like Caption are
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		switch key {
+		case "Caption":
+			info.ProductName = value
+		case "Version":
+			info.Version = value
+		case "BuildNumber":
+			info.Build = value
+		case "OSArchitecture":
+			info.Architecture = value
+		}
+	}
+
+	// Verify we got at least some information
+	if info.ProductName == "" && info.Version == "" {
+		return fmt.Errorf("no useful information from WMI")
+	}
+
+	return nil
+}
+
+// codePages decodes the OEM code pages console tools print in, by number
+var codePages = map[uint32]encoding.Encoding{
+	437: charmap.CodePage437,
+	850: charmap.CodePage850,
+	852: charmap.CodePage852,
+	855: charmap.CodePage855,
+	858: charmap.CodePage858,
+	860: charmap.CodePage860,
+	862: charmap.CodePage862,
+	863: charmap.CodePage863,
+	865: charmap.CodePage865,
+	866: charmap.CodePage866,
+	932: japanese.ShiftJIS,
+	936: simplifiedchinese.GBK,
+	949: korean.EUCKR,
+	950: traditionalchinese.Big5,
+}
+
+// decodeCodePage turns a console tool's output into UTF-8. Output that is
+// UTF-16 (wmic when redirected) or already UTF-8 (code page 65001) is
+// recognized regardless of the code page.
+func decodeCodePage(codePage uint32, output []byte) string {
+	if bytes.HasPrefix(output, []byte{0xFF, 0xFE}) {
+		units := make([]uint16, 0, len(output)/2)
+		for i := 2; i+1 < len(output); i += 2 {
+			units = append(units, uint16(output[i])|uint16(output[i+1])<<8)
+		}
+		return string(utf16.Decode(units))
+	}
+	if enc, ok := codePages[codePage]; ok {
+		if decoded, err := enc.NewDecoder().Bytes(output); err == nil {
+			return string(decoded)
+		}
+	}
+	return string(output)
+}
@@ -0,0 +1,74 @@
+package ddc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseWindowsInfoLocales(t *testing.T) {
+	tests := []struct {
+		file     string
+		codePage uint32
+		parse    func(data []byte, codePage uint32, info *WindowsInfo) error
+		want     WindowsInfo
+	}{
+		{
+			file: "systeminfo-en-US.csv", codePage: 437, parse: parseSystemInfoFixture,
+			want: WindowsInfo{ProductName: "Microsoft Windows 11 Pro", Version: "10.0.22631", Build: "22631",
+				Architecture: "x64-based PC", InstallDate: "3/14/2024, 9:12:45 AM", RegisteredOwner: "Jane Doe", SystemRoot: `C:\WINDOWS`},
+		},
+		{
+			file: "systeminfo-de-DE.csv", codePage: 850, parse: parseSystemInfoFixture,
+			want: WindowsInfo{ProductName: "Microsoft Windows 11 Pro", Version: "10.0.22631", Build: "22631",
+				Architecture: "x64-based PC", InstallDate: "14.03.2024, 09:12:45", RegisteredOwner: "Jürgen Müller", SystemRoot: `C:\WINDOWS`},
+		},
+		{
+			file: "systeminfo-ja-JP.csv", codePage: 932, parse: parseSystemInfoFixture,
+			want: WindowsInfo{ProductName: "Microsoft Windows 11 Pro", Version: "10.0.22631", Build: "22631",
+				Architecture: "x64-based PC", InstallDate: "2024/03/14, 9:12:45", RegisteredOwner: "山田ソフト", SystemRoot: `C:\WINDOWS`},
+		},
+		{
+			file: "cim-de-DE.json", codePage: 850,
+			parse: func(data []byte, _ uint32, info *WindowsInfo) error { return parseCIMOutput(data, info) },
+			want: WindowsInfo{ProductName: "Microsoft Windows 11 Pro für Workstations", Version: "10.0.22631", Build: "22631",
+				Architecture: "64-Bit", InstallDate: "2024-03-14T09:12:45.0000000+01:00", RegisteredOwner: "Jürgen Müller", SystemRoot: `C:\WINDOWS`},
+		},
+		{
+			file: "wmic-ja-JP.txt", codePage: 932,
+			parse: func(data []byte, codePage uint32, info *WindowsInfo) error {
+				return parseWMIOutput(decodeCodePage(codePage, data), info)
+			},
+			want: WindowsInfo{ProductName: "Microsoft Windows 11 Pro", Version: "10.0.22631", Build: "22631", Architecture: "64 ビット"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "windows", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got WindowsInfo
+			if err := tt.parse(data, tt.codePage, &got); err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func parseSystemInfoFixture(data []byte, codePage uint32, info *WindowsInfo) error {
+	return parseSystemInfoCSV(decodeCodePage(codePage, data), info)
+}
+
+func TestDecodeCodePageFallsBackToUTF8(t *testing.T) {
+	if got := decodeCodePage(65001, []byte("Jürgen")); got != "Jürgen" {
+		t.Errorf("got %q", got)
+	}
+	if got := decodeCodePage(0, []byte("plain")); got != "plain" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,15 @@
+package ddc
+
+import "golang.org/x/sys/windows"
+
+var procGetOEMCP = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetOEMCP")
+
+// consoleCodePage is the code page console tools print in: the console's
+// when there is one, else the system OEM code page
+func consoleCodePage() uint32 {
+	if cp, err := windows.GetConsoleOutputCP(); err == nil && cp != 0 {
+		return cp
+	}
+	cp, _, _ := procGetOEMCP.Call()
+	return uint32(cp)
+}
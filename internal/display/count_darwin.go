@@ -0,0 +1,9 @@
+package display
+
+import "github.com/sibteali786/monitorswitch/internal/ddc/native/macos"
+
+// ActiveDisplayCount returns how many displays are active on this machine,
+// including the built-in panel
+func ActiveDisplayCount() (int, error) {
+	return macos.ActiveDisplayCount()
+}
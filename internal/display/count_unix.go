@@ -0,0 +1,29 @@
+//go:build !windows && !darwin
+
+package display
+
+import (
+	"os"
+	"strings"
+)
+
+// ActiveDisplayCount returns how many displays are connected to this machine,
+// including built-in panels, using DRM connector status in sysfs
+func ActiveDisplayCount() (int, error) {
+	paths, err := connectorStatusPaths()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if strings.TrimSpace(string(data)) == "connected" {
+			count++
+		}
+	}
+	return count, nil
+}
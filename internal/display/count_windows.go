@@ -0,0 +1,16 @@
+package display
+
+import "fmt"
+
+var procGetSystemMetrics = user32.NewProc("GetSystemMetrics")
+
+const smCMonitors = 80
+
+// ActiveDisplayCount returns how many displays are attached to the desktop
+func ActiveDisplayCount() (int, error) {
+	count, _, _ := procGetSystemMetrics.Call(smCMonitors)
+	if count == 0 {
+		return 0, fmt.Errorf("GetSystemMetrics(SM_CMONITORS) failed")
+	}
+	return int(count), nil
+}
@@ -0,0 +1,56 @@
+package display
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Point is a position in the global desktop coordinate space
+type Point struct {
+	X int
+	Y int
+}
+
+// Layout describes how outputs are arranged on the desktop
+type Layout struct {
+	Primary   string           // Output to make primary/main, "" to leave unchanged
+	Positions map[string]Point // Output -> top-left position
+	Mirror    bool             // Mirror every output onto Primary
+	Extend    bool             // Stop mirroring and extend the desktop
+}
+
+// ParsePosition parses an "OUTPUT=X,Y" assignment such as "DP-1=2560,0"
+func ParsePosition(s string) (string, Point, error) {
+	output, coords, ok := strings.Cut(s, "=")
+	if !ok || output == "" {
+		return "", Point{}, fmt.Errorf("invalid position %q: expected OUTPUT=X,Y", s)
+	}
+
+	xs, ys, ok := strings.Cut(coords, ",")
+	if !ok {
+		return "", Point{}, fmt.Errorf("invalid position %q: expected OUTPUT=X,Y", s)
+	}
+
+	x, err := strconv.Atoi(strings.TrimSpace(xs))
+	if err != nil {
+		return "", Point{}, fmt.Errorf("invalid X coordinate in %q", s)
+	}
+	y, err := strconv.Atoi(strings.TrimSpace(ys))
+	if err != nil {
+		return "", Point{}, fmt.Errorf("invalid Y coordinate in %q", s)
+	}
+
+	return output, Point{X: x, Y: y}, nil
+}
+
+// Validate checks for contradictory layout requests
+func (l Layout) Validate() error {
+	if l.Mirror && l.Extend {
+		return fmt.Errorf("cannot mirror and extend at the same time")
+	}
+	if l.Mirror && l.Primary == "" {
+		return fmt.Errorf("mirroring requires a primary output to mirror")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package display
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/macos"
+)
+
+// ApplyLayout arranges displays via CoreGraphics display configuration.
+// Outputs are CGDirectDisplayIDs; the display placed at 0,0 becomes main.
+func ApplyLayout(layout Layout) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+
+	primary, err := parseDisplayID(layout.Primary)
+	if err != nil && layout.Primary != "" {
+		return err
+	}
+
+	if layout.Mirror || layout.Extend {
+		displays, err := macos.Displays()
+		if err != nil {
+			return err
+		}
+		for _, d := range displays {
+			if d.ID == primary {
+				continue
+			}
+			master := uint32(0)
+			if layout.Mirror {
+				master = primary
+			}
+			if err := macos.SetDisplayMirror(d.ID, master); err != nil {
+				return err
+			}
+		}
+	}
+
+	for output, p := range layout.Positions {
+		id, err := parseDisplayID(output)
+		if err != nil {
+			return err
+		}
+		if err := macos.SetDisplayOrigin(id, p.X, p.Y); err != nil {
+			return err
+		}
+	}
+
+	if layout.Primary != "" {
+		if _, placed := layout.Positions[layout.Primary]; !placed {
+			return macos.SetDisplayOrigin(primary, 0, 0)
+		}
+	}
+	return nil
+}
+
+func parseDisplayID(output string) (uint32, error) {
+	id, err := strconv.ParseUint(output, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid display ID: %s", output)
+	}
+	return uint32(id), nil
+}
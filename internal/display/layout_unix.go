@@ -0,0 +1,100 @@
+//go:build !windows && !darwin
+
+package display
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ApplyLayout arranges outputs with xrandr, or wlr-randr under Wayland
+// (which supports positions only)
+func ApplyLayout(layout Layout) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if layout.Primary != "" || layout.Mirror || layout.Extend {
+			return fmt.Errorf("primary display and mirroring are not supported under Wayland; only positions can be set")
+		}
+		var args []string
+		for output, p := range layout.Positions {
+			args = append(args, "--output", output, "--pos", fmt.Sprintf("%d,%d", p.X, p.Y))
+		}
+		cmd = exec.CommandContext(ctx, "wlr-randr", args...)
+	} else {
+		args, err := xrandrLayoutArgs(layout)
+		if err != nil {
+			return err
+		}
+		cmd = exec.CommandContext(ctx, "xrandr", args...)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to apply layout: %w (%s)", err, output)
+	}
+	return nil
+}
+
+func xrandrLayoutArgs(layout Layout) ([]string, error) {
+	var args []string
+
+	if layout.Mirror || layout.Extend {
+		outputs, err := connectedXrandrOutputs()
+		if err != nil {
+			return nil, err
+		}
+
+		previous := layout.Primary
+		for _, output := range outputs {
+			if output == layout.Primary {
+				continue
+			}
+			if layout.Mirror {
+				args = append(args, "--output", output, "--auto", "--same-as", layout.Primary)
+			} else if _, placed := layout.Positions[output]; !placed && previous != "" {
+				args = append(args, "--output", output, "--auto", "--right-of", previous)
+			}
+			previous = output
+		}
+	}
+
+	for output, p := range layout.Positions {
+		args = append(args, "--output", output, "--pos", fmt.Sprintf("%dx%d", p.X, p.Y))
+	}
+
+	if layout.Primary != "" {
+		args = append(args, "--output", layout.Primary, "--primary")
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("layout is empty")
+	}
+	return args, nil
+}
+
+// connectedXrandrOutputs parses lines like "DP-1 connected primary 2560x1440+0+0 ..."
+func connectedXrandrOutputs() ([]string, error) {
+	output, err := exec.Command("xrandr", "--query").Output()
+	if err != nil {
+		return nil, fmt.Errorf("xrandr command failed: %w", err)
+	}
+
+	var outputs []string
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[1] == "connected" {
+			outputs = append(outputs, fields[0])
+		}
+	}
+	return outputs, nil
+}
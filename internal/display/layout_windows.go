@@ -0,0 +1,83 @@
+package display
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var procSetDisplayConfig = user32.NewProc("SetDisplayConfig")
+
+const (
+	dmPosition = 0x00000020
+
+	cdsSetPrimary = 0x00000010
+	cdsNoReset    = 0x10000000
+
+	sdcTopologyClone  = 0x00000002
+	sdcTopologyExtend = 0x00000004
+	sdcApply          = 0x00000080
+)
+
+// ApplyLayout arranges GDI display devices (e.g., `\\.\DISPLAY1`).
+// Mirroring uses the clone/extend topologies of SetDisplayConfig.
+func ApplyLayout(layout Layout) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+
+	if layout.Mirror || layout.Extend {
+		topology := uintptr(sdcTopologyExtend)
+		if layout.Mirror {
+			topology = sdcTopologyClone
+		}
+		if ret, _, _ := procSetDisplayConfig.Call(0, 0, 0, 0, sdcApply|topology); ret != 0 {
+			return fmt.Errorf("SetDisplayConfig failed (code %d)", int32(ret))
+		}
+	}
+
+	// Stage every change with CDS_NORESET, then apply them all at once
+	for output, p := range layout.Positions {
+		flags := uint32(cdsUpdateRegistry | cdsNoReset)
+		if output == layout.Primary {
+			flags |= cdsSetPrimary
+		}
+		if err := stagePosition(output, p, flags); err != nil {
+			return err
+		}
+	}
+
+	if _, placed := layout.Positions[layout.Primary]; layout.Primary != "" && !placed {
+		// The primary display is always at the origin
+		if err := stagePosition(layout.Primary, Point{}, cdsUpdateRegistry|cdsNoReset|cdsSetPrimary); err != nil {
+			return err
+		}
+	}
+
+	if len(layout.Positions) > 0 || layout.Primary != "" {
+		if ret, _, _ := procChangeDisplaySettingsExW.Call(0, 0, 0, 0, 0); int32(ret) != dispChangeSuccessful {
+			return fmt.Errorf("failed to apply display layout (code %d)", int32(ret))
+		}
+	}
+	return nil
+}
+
+func stagePosition(output string, p Point, flags uint32) error {
+	device, err := windows.UTF16PtrFromString(output)
+	if err != nil {
+		return fmt.Errorf("invalid display device name: %s", output)
+	}
+
+	var dm devMode
+	dm.Size = uint16(unsafe.Sizeof(dm))
+	dm.Fields = dmPosition
+	dm.PositionX = int32(p.X)
+	dm.PositionY = int32(p.Y)
+
+	ret, _, _ := procChangeDisplaySettingsExW.Call(uintptr(unsafe.Pointer(device)), uintptr(unsafe.Pointer(&dm)), 0, uintptr(flags), 0)
+	if int32(ret) != dispChangeSuccessful {
+		return fmt.Errorf("failed to position %s (code %d)", output, int32(ret))
+	}
+	return nil
+}
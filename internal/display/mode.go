@@ -0,0 +1,54 @@
+package display
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Mode is a display resolution with an optional refresh rate. It is applied
+// through the OS display APIs and is deliberately separate from DDC/CI.
+type Mode struct {
+	Width   int     // Horizontal resolution in pixels
+	Height  int     // Vertical resolution in pixels
+	Refresh float64 // Refresh rate in Hz, 0 for "any"
+}
+
+// ParseMode parses strings like "2560x1440" or "2560x1440@120"
+func ParseMode(s string) (Mode, error) {
+	var mode Mode
+
+	res, rate, hasRate := strings.Cut(strings.TrimSpace(s), "@")
+	w, h, ok := strings.Cut(strings.ToLower(res), "x")
+	if !ok {
+		return mode, fmt.Errorf("invalid mode %q: expected WIDTHxHEIGHT[@HZ]", s)
+	}
+
+	width, err := strconv.Atoi(w)
+	if err != nil || width <= 0 {
+		return mode, fmt.Errorf("invalid width in mode %q", s)
+	}
+	height, err := strconv.Atoi(h)
+	if err != nil || height <= 0 {
+		return mode, fmt.Errorf("invalid height in mode %q", s)
+	}
+	mode.Width, mode.Height = width, height
+
+	if hasRate {
+		refresh, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(rate), "hz"), 64)
+		if err != nil || refresh <= 0 {
+			return mode, fmt.Errorf("invalid refresh rate in mode %q", s)
+		}
+		mode.Refresh = refresh
+	}
+
+	return mode, nil
+}
+
+// String formats the mode the way ParseMode accepts it
+func (m Mode) String() string {
+	if m.Refresh > 0 {
+		return fmt.Sprintf("%dx%d@%g", m.Width, m.Height, m.Refresh)
+	}
+	return fmt.Sprintf("%dx%d", m.Width, m.Height)
+}
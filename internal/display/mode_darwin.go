@@ -0,0 +1,19 @@
+package display
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/macos"
+)
+
+// SetMode applies a resolution/refresh to a display identified by its
+// CGDirectDisplayID, as reported by detect
+func SetMode(output string, mode Mode) error {
+	displayID, err := strconv.ParseUint(output, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid display ID: %s", output)
+	}
+
+	return macos.SetDisplayMode(uint32(displayID), mode.Width, mode.Height, mode.Refresh)
+}
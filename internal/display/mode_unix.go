@@ -0,0 +1,46 @@
+//go:build !windows && !darwin
+
+package display
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// SetMode applies a resolution/refresh to an output (e.g., "DP-1") using
+// wlr-randr under Wayland compositors and xrandr under X11
+func SetMode(output string, mode Mode) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if _, err := exec.LookPath("wlr-randr"); err != nil {
+			return fmt.Errorf("wlr-randr not found; it is required to change modes under Wayland")
+		}
+		cmd = exec.CommandContext(ctx, "wlr-randr", "--output", output, "--mode", wlrModeString(mode))
+	} else {
+		args := []string{"--output", output, "--mode", fmt.Sprintf("%dx%d", mode.Width, mode.Height)}
+		if mode.Refresh > 0 {
+			args = append(args, "--rate", strconv.FormatFloat(mode.Refresh, 'f', -1, 64))
+		}
+		cmd = exec.CommandContext(ctx, "xrandr", args...)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set mode %s: %w (%s)", mode, err, output)
+	}
+	return nil
+}
+
+// wlr-randr wants "2560x1440@120Hz"
+func wlrModeString(mode Mode) string {
+	if mode.Refresh > 0 {
+		return fmt.Sprintf("%dx%d@%gHz", mode.Width, mode.Height, mode.Refresh)
+	}
+	return fmt.Sprintf("%dx%d", mode.Width, mode.Height)
+}
@@ -0,0 +1,88 @@
+package display
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	user32                       = windows.NewLazySystemDLL("user32.dll")
+	procEnumDisplaySettingsW     = user32.NewProc("EnumDisplaySettingsW")
+	procChangeDisplaySettingsExW = user32.NewProc("ChangeDisplaySettingsExW")
+)
+
+const (
+	enumCurrentSettings = 0xFFFFFFFF
+
+	dmPelsWidth        = 0x00080000
+	dmPelsHeight       = 0x00100000
+	dmDisplayFrequency = 0x00400000
+
+	cdsUpdateRegistry    = 0x00000001
+	dispChangeSuccessful = 0
+)
+
+// devMode mirrors the display variant of the Win32 DEVMODEW structure
+type devMode struct {
+	DeviceName         [32]uint16
+	SpecVersion        uint16
+	DriverVersion      uint16
+	Size               uint16
+	DriverExtra        uint16
+	Fields             uint32
+	PositionX          int32
+	PositionY          int32
+	DisplayOrientation uint32
+	DisplayFixedOutput uint32
+	Color              int16
+	Duplex             int16
+	YResolution        int16
+	TTOption           int16
+	Collate            int16
+	FormName           [32]uint16
+	LogPixels          uint16
+	BitsPerPel         uint32
+	PelsWidth          uint32
+	PelsHeight         uint32
+	DisplayFlags       uint32
+	DisplayFrequency   uint32
+	ICMMethod          uint32
+	ICMIntent          uint32
+	MediaType          uint32
+	DitherType         uint32
+	Reserved1          uint32
+	Reserved2          uint32
+	PanningWidth       uint32
+	PanningHeight      uint32
+}
+
+// SetMode applies a resolution/refresh to a GDI display device
+// (e.g., `\\.\DISPLAY1`) using ChangeDisplaySettingsEx
+func SetMode(output string, mode Mode) error {
+	device, err := windows.UTF16PtrFromString(output)
+	if err != nil {
+		return fmt.Errorf("invalid display device name: %s", output)
+	}
+
+	var dm devMode
+	dm.Size = uint16(unsafe.Sizeof(dm))
+	if ret, _, _ := procEnumDisplaySettingsW.Call(uintptr(unsafe.Pointer(device)), uintptr(enumCurrentSettings), uintptr(unsafe.Pointer(&dm))); ret == 0 {
+		return fmt.Errorf("could not read current settings for %s", output)
+	}
+
+	dm.PelsWidth = uint32(mode.Width)
+	dm.PelsHeight = uint32(mode.Height)
+	dm.Fields = dmPelsWidth | dmPelsHeight
+	if mode.Refresh > 0 {
+		dm.DisplayFrequency = uint32(mode.Refresh + 0.5)
+		dm.Fields |= dmDisplayFrequency
+	}
+
+	ret, _, _ := procChangeDisplaySettingsExW.Call(uintptr(unsafe.Pointer(device)), uintptr(unsafe.Pointer(&dm)), 0, cdsUpdateRegistry, 0)
+	if int32(ret) != dispChangeSuccessful {
+		return fmt.Errorf("ChangeDisplaySettingsEx failed for %s (code %d)", output, int32(ret))
+	}
+	return nil
+}
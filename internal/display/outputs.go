@@ -0,0 +1,7 @@
+package display
+
+// Output is a display as the OS sees it, without any DDC/CI traffic
+type Output struct {
+	Name        string // Connector or OS display name (e.g., "DP-1", "\\.\DISPLAY1")
+	Description string // Monitor or adapter name, if known
+}
@@ -0,0 +1,21 @@
+package display
+
+import (
+	"strconv"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/macos"
+)
+
+// Outputs lists online displays from CoreGraphics
+func Outputs() ([]Output, error) {
+	displays, err := macos.Displays()
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []Output
+	for _, d := range displays {
+		outputs = append(outputs, Output{Name: strconv.FormatUint(uint64(d.ID), 10), Description: d.Name})
+	}
+	return outputs, nil
+}
@@ -0,0 +1,45 @@
+//go:build !windows && !darwin
+
+package display
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// connectorStatusPaths returns the status file of every DRM connector, from
+// sysfs on Linux or linsysfs on FreeBSD with drm-kmod
+func connectorStatusPaths() ([]string, error) {
+	for _, dir := range []string{"/sys/class/drm", "/compat/linux/sys/class/drm"} {
+		if paths, _ := filepath.Glob(filepath.Join(dir, "card*-*/status")); len(paths) > 0 {
+			return paths, nil
+		}
+	}
+	return nil, fmt.Errorf("no DRM connectors found")
+}
+
+// Outputs lists connected DRM connectors from sysfs
+func Outputs() ([]Output, error) {
+	paths, err := connectorStatusPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []Output
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil || strings.TrimSpace(string(data)) != "connected" {
+			continue
+		}
+
+		// card0-DP-1 -> DP-1
+		name := filepath.Base(filepath.Dir(path))
+		if i := strings.Index(name, "-"); i >= 0 {
+			name = name[i+1:]
+		}
+		outputs = append(outputs, Output{Name: name})
+	}
+	return outputs, nil
+}
@@ -0,0 +1,42 @@
+package display
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var procEnumDisplayDevicesW = user32.NewProc("EnumDisplayDevicesW")
+
+const displayDeviceAttachedToDesktop = 0x00000001
+
+// displayDevice mirrors the Win32 DISPLAY_DEVICEW structure
+type displayDevice struct {
+	Cb           uint32
+	DeviceName   [32]uint16
+	DeviceString [128]uint16
+	StateFlags   uint32
+	DeviceID     [128]uint16
+	DeviceKey    [128]uint16
+}
+
+// Outputs lists the display outputs attached to the desktop
+func Outputs() ([]Output, error) {
+	var outputs []Output
+	for i := uint32(0); ; i++ {
+		var dd displayDevice
+		dd.Cb = uint32(unsafe.Sizeof(dd))
+		ret, _, _ := procEnumDisplayDevicesW.Call(0, uintptr(i), uintptr(unsafe.Pointer(&dd)), 0)
+		if ret == 0 {
+			break
+		}
+		if dd.StateFlags&displayDeviceAttachedToDesktop == 0 {
+			continue
+		}
+		outputs = append(outputs, Output{
+			Name:        windows.UTF16ToString(dd.DeviceName[:]),
+			Description: windows.UTF16ToString(dd.DeviceString[:]),
+		})
+	}
+	return outputs, nil
+}
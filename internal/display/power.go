@@ -0,0 +1,22 @@
+package display
+
+import "fmt"
+
+// PowerState is a display power state understood by both DDC (VCP 0xD6)
+// and the OS-level fallbacks
+type PowerState string
+
+const (
+	PowerOn      PowerState = "on"
+	PowerStandby PowerState = "standby"
+	PowerOff     PowerState = "off"
+)
+
+// ParsePowerState validates a power state name
+func ParsePowerState(s string) (PowerState, error) {
+	switch state := PowerState(s); state {
+	case PowerOn, PowerStandby, PowerOff:
+		return state, nil
+	}
+	return "", fmt.Errorf("invalid power state %q: expected on, standby or off", s)
+}
@@ -0,0 +1,29 @@
+package display
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// SetPower changes display power through the OS, returning the mechanism
+// used. macOS sleeps and wakes all displays together.
+func SetPower(state PowerState) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	switch state {
+	case PowerOn:
+		// Declaring user activity wakes sleeping displays
+		if err := exec.CommandContext(ctx, "caffeinate", "-u", "-t", "1").Run(); err != nil {
+			return "", fmt.Errorf("caffeinate failed: %w", err)
+		}
+		return "caffeinate -u", nil
+	default:
+		if err := exec.CommandContext(ctx, "pmset", "displaysleepnow").Run(); err != nil {
+			return "", fmt.Errorf("pmset displaysleepnow failed: %w", err)
+		}
+		return "pmset displaysleepnow", nil
+	}
+}
@@ -0,0 +1,49 @@
+//go:build !windows && !darwin
+
+package display
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// SetPower changes display power through the OS, returning the mechanism
+// used. It affects every display driven by the session.
+func SetPower(state PowerState) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Sway exposes per-session DPMS over IPC
+	if os.Getenv("SWAYSOCK") != "" {
+		power := "off"
+		if state == PowerOn {
+			power = "on"
+		}
+		if err := exec.CommandContext(ctx, "swaymsg", "output", "*", "power", power).Run(); err == nil {
+			return "sway output power", nil
+		}
+	}
+
+	if os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "" {
+		dpms := "off"
+		if state == PowerOn {
+			dpms = "on"
+		}
+		if err := exec.CommandContext(ctx, "hyprctl", "dispatch", "dpms", dpms).Run(); err == nil {
+			return "hyprctl dpms", nil
+		}
+	}
+
+	if os.Getenv("DISPLAY") != "" {
+		if _, err := exec.LookPath("xset"); err == nil {
+			if err := exec.CommandContext(ctx, "xset", "dpms", "force", string(state)).Run(); err == nil {
+				return "xset dpms", nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no OS-level display power mechanism available (tried sway, Hyprland, xset)")
+}
@@ -0,0 +1,38 @@
+package display
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+var procSendMessageTimeoutW = user32.NewProc("SendMessageTimeoutW")
+
+const (
+	hwndBroadcast   = 0xFFFF
+	wmSysCommand    = 0x0112
+	scMonitorPower  = 0xF170
+	smtoAbortIfHung = 0x0002
+)
+
+// SetPower changes display power through the OS, returning the mechanism
+// used. SC_MONITORPOWER affects every display.
+func SetPower(state PowerState) (string, error) {
+	// SC_MONITORPOWER: -1 = on, 1 = low power, 2 = off
+	var lParam uintptr
+	switch state {
+	case PowerOn:
+		lParam = ^uintptr(0)
+	case PowerStandby:
+		lParam = 1
+	default:
+		lParam = 2
+	}
+
+	// SendMessageTimeout avoids blocking on windows that never answer the broadcast
+	var result uintptr
+	ret, _, err := procSendMessageTimeoutW.Call(hwndBroadcast, wmSysCommand, scMonitorPower, lParam, smtoAbortIfHung, 2000, uintptr(unsafe.Pointer(&result)))
+	if ret == 0 {
+		return "", fmt.Errorf("SendMessage SC_MONITORPOWER failed: %v", err)
+	}
+	return "SendMessage SC_MONITORPOWER", nil
+}
@@ -0,0 +1,39 @@
+package display
+
+import (
+	"fmt"
+	"time"
+)
+
+// pollInterval is how often Changes compares the output list when the OS
+// offers no change notification
+const pollInterval = 2 * time.Second
+
+// notify signals a change without blocking; one pending signal is enough
+// since receivers re-read the whole display setup
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// pollChanges signals ch whenever the list of outputs changes
+func pollChanges(ch chan struct{}) {
+	last := outputsKey()
+	for {
+		time.Sleep(pollInterval)
+		if key := outputsKey(); key != last {
+			last = key
+			notify(ch)
+		}
+	}
+}
+
+func outputsKey() string {
+	outputs, err := Outputs()
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return fmt.Sprint(outputs)
+}
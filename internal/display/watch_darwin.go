@@ -0,0 +1,31 @@
+package display
+
+import (
+	"sync"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc/native/macos"
+)
+
+var (
+	watchOnce sync.Once
+	changes   = make(chan struct{}, 1)
+)
+
+// Changes returns a channel that receives a value after a display is added,
+// removed or reconfigured, from CoreGraphics reconfiguration callbacks, or by
+// polling when the native bridge isn't compiled in
+func Changes() (<-chan struct{}, error) {
+	watchOnce.Do(func() {
+		native, err := macos.DisplayChanges()
+		if err != nil {
+			go pollChanges(changes)
+			return
+		}
+		go func() {
+			for range native {
+				notify(changes)
+			}
+		}()
+	})
+	return changes, nil
+}
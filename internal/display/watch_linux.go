@@ -0,0 +1,52 @@
+package display
+
+import (
+	"bytes"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+var (
+	watchOnce sync.Once
+	changes   = make(chan struct{}, 1)
+)
+
+// Changes returns a channel that receives a value after a monitor is
+// connected, disconnected or changes state, from DRM uevents. The watch runs
+// for the life of the process.
+func Changes() (<-chan struct{}, error) {
+	watchOnce.Do(func() {
+		fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_KOBJECT_UEVENT)
+		if err == nil {
+			err = unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: 1})
+		}
+		if err != nil {
+			// Containers often lack uevents; sysfs still shows connector status
+			go pollChanges(changes)
+			return
+		}
+		go readUevents(fd)
+	})
+	return changes, nil
+}
+
+// readUevents signals changes for uevents from the drm subsystem, such as
+// "change@/devices/.../drm/card1\x00ACTION=change\x00...SUBSYSTEM=drm\x00HOTPLUG=1"
+func readUevents(fd int) {
+	buf := make([]byte, 64*1024)
+	for {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			if err == unix.EINTR || err == unix.ENOBUFS {
+				continue
+			}
+			unix.Close(fd)
+			pollChanges(changes)
+			return
+		}
+		if bytes.Contains(buf[:n], []byte("\x00SUBSYSTEM=drm\x00")) {
+			notify(changes)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !windows && !darwin
+
+package display
+
+import "sync"
+
+var (
+	watchOnce sync.Once
+	changes   = make(chan struct{}, 1)
+)
+
+// Changes returns a channel that receives a value after the list of outputs
+// changes. There is no notification here, so the outputs are polled.
+func Changes() (<-chan struct{}, error) {
+	watchOnce.Do(func() { go pollChanges(changes) })
+	return changes, nil
+}
@@ -0,0 +1,113 @@
+package display
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procRegisterClassExW = user32.NewProc("RegisterClassExW")
+	procCreateWindowExW  = user32.NewProc("CreateWindowExW")
+	procDefWindowProcW   = user32.NewProc("DefWindowProcW")
+	procGetMessageW      = user32.NewProc("GetMessageW")
+	procTranslateMessage = user32.NewProc("TranslateMessage")
+	procDispatchMessageW = user32.NewProc("DispatchMessageW")
+)
+
+const (
+	wmDisplayChange  = 0x007E
+	wmDeviceChange   = 0x0219
+	wmPowerBroadcast = 0x0218
+)
+
+// wndClassEx mirrors the Win32 WNDCLASSEXW structure
+type wndClassEx struct {
+	Size       uint32
+	Style      uint32
+	WndProc    uintptr
+	ClsExtra   int32
+	WndExtra   int32
+	Instance   windows.Handle
+	Icon       windows.Handle
+	Cursor     windows.Handle
+	Background windows.Handle
+	MenuName   *uint16
+	ClassName  *uint16
+	IconSm     windows.Handle
+}
+
+// msg mirrors the Win32 MSG structure
+type msg struct {
+	Hwnd    windows.Handle
+	Message uint32
+	WParam  uintptr
+	LParam  uintptr
+	Time    uint32
+	Pt      struct{ X, Y int32 }
+}
+
+var (
+	watchOnce sync.Once
+	changes   = make(chan struct{}, 1)
+	watchErr  error
+)
+
+// Changes returns a channel that receives a value after a display is added,
+// removed or reconfigured. WM_DISPLAYCHANGE is only broadcast to top-level
+// windows, so a hidden one is created and pumped for the life of the process.
+func Changes() (<-chan struct{}, error) {
+	watchOnce.Do(func() {
+		ready := make(chan error)
+		go runMessageWindow(ready)
+		watchErr = <-ready
+	})
+	return changes, watchErr
+}
+
+func runMessageWindow(ready chan<- error) {
+	runtime.LockOSThread()
+
+	className, _ := windows.UTF16PtrFromString("MonitorSwitchWatch")
+	wc := wndClassEx{
+		WndProc:   windows.NewCallback(watchWndProc),
+		ClassName: className,
+	}
+	wc.Size = uint32(unsafe.Sizeof(wc))
+	if ret, _, err := procRegisterClassExW.Call(uintptr(unsafe.Pointer(&wc))); ret == 0 {
+		ready <- fmt.Errorf("RegisterClassExW failed: %w", err)
+		return
+	}
+
+	hwnd, _, err := procCreateWindowExW.Call(0, uintptr(unsafe.Pointer(className)), uintptr(unsafe.Pointer(className)),
+		0, 0, 0, 0, 0, 0, 0, 0, 0)
+	if hwnd == 0 {
+		ready <- fmt.Errorf("CreateWindowExW failed: %w", err)
+		return
+	}
+	ready <- nil
+
+	var m msg
+	for {
+		ret, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+		if int32(ret) <= 0 {
+			return
+		}
+		procTranslateMessage.Call(uintptr(unsafe.Pointer(&m)))
+		procDispatchMessageW.Call(uintptr(unsafe.Pointer(&m)))
+	}
+}
+
+// watchWndProc signals display changes, device arrivals (monitors can come
+// up without a mode change) and resume from sleep
+func watchWndProc(hwnd, message, wParam, lParam uintptr) uintptr {
+	switch message {
+	case wmDisplayChange, wmDeviceChange, wmPowerBroadcast:
+		notify(changes)
+	}
+	ret, _, _ := procDefWindowProcW.Call(hwnd, message, wParam, lParam)
+	return ret
+}
@@ -0,0 +1,3 @@
+// Package filelock provides the advisory file locks monitorswitch uses to
+// serialise DDC access and state updates across processes.
+package filelock
@@ -0,0 +1,21 @@
+//go:build !windows
+
+package filelock
+
+import (
+	"os"
+	"syscall"
+)
+
+// NoFollow makes opening a symlink fail
+const NoFollow = syscall.O_NOFOLLOW
+
+// Lock takes an exclusive lock on f, blocking until it is available
+func Lock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+// Unlock releases a lock taken with Lock
+func Unlock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,25 @@
+//go:build windows
+
+package filelock
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// NoFollow is zero: creating symlinks needs privileges on Windows and the
+// temp directory is per user
+const NoFollow = 0
+
+// Lock takes an exclusive lock on f, blocking until it is available
+func Lock(f *os.File) error {
+	var overlapped windows.Overlapped
+	return windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, &overlapped)
+}
+
+// Unlock releases a lock taken with Lock
+func Unlock(f *os.File) error {
+	var overlapped windows.Overlapped
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, &overlapped)
+}
@@ -0,0 +1,136 @@
+package labels
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+)
+
+// Labels maps a monitor's Key -> input name -> friendly label ("HDMI-1" -> "Work MacBook")
+type Labels map[string]map[string]string
+
+func labelsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "monitorswitch", "labels.json"), nil
+}
+
+// Load reads the labels file, returning empty labels if none exists yet
+func Load() (Labels, error) {
+	labels := make(Labels)
+
+	path, err := labelsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return labels, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return nil, fmt.Errorf("corrupt labels file %s: %w", path, err)
+	}
+	return labels, nil
+}
+
+// Save writes the labels file
+func (l Labels) Save() error {
+	path, err := labelsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Key is what labels of m are stored under: its stable ID, which survives
+// reboots and replugging, or its ID when it has none, in upper case
+func Key(m ddc.Monitor) string {
+	return strings.ToUpper(ddc.StableKey(m))
+}
+
+// inputs returns the labels of m, falling back to those saved under its
+// display ID before labels were keyed by stable ID
+func (l Labels) inputs(m ddc.Monitor) map[string]string {
+	if inputs, ok := l[Key(m)]; ok {
+		return inputs
+	}
+	return l[m.ID]
+}
+
+// Set labels an input of a monitor, replacing its label in any letter case
+func (l Labels) Set(m ddc.Monitor, input, label string) {
+	key := Key(m)
+	if l[key] == nil {
+		l[key] = make(map[string]string)
+		if legacy, ok := l[m.ID]; ok && key != m.ID {
+			l[key] = legacy
+			delete(l, m.ID)
+		}
+	}
+	for name := range l[key] {
+		if strings.EqualFold(name, input) {
+			delete(l[key], name)
+		}
+	}
+	l[key][input] = label
+}
+
+// Remove deletes an input's label, reporting whether it existed
+func (l Labels) Remove(m ddc.Monitor, input string) bool {
+	inputs := l.inputs(m)
+	for name := range inputs {
+		if strings.EqualFold(name, input) {
+			delete(inputs, name)
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns the label of an input, or "" if it has none
+func (l Labels) Label(m ddc.Monitor, input string) string {
+	for name, label := range l.inputs(m) {
+		if strings.EqualFold(name, input) {
+			return label
+		}
+	}
+	return ""
+}
+
+// Resolve maps a label back to the input it names
+func (l Labels) Resolve(m ddc.Monitor, label string) (string, bool) {
+	for input, name := range l.inputs(m) {
+		if strings.EqualFold(name, label) {
+			return input, true
+		}
+	}
+	return "", false
+}
+
+// Describe renders an input with its label, e.g. "HDMI-1 (Work MacBook)"
+func (l Labels) Describe(m ddc.Monitor, input string) string {
+	if label := l.Label(m, input); label != "" {
+		return fmt.Sprintf("%s (%s)", input, label)
+	}
+	return input
+}
@@ -0,0 +1,211 @@
+package mccs
+
+// Access says whether a feature can be read, written or both
+type Access string
+
+const (
+	ReadOnly  Access = "RO"
+	WriteOnly Access = "WO"
+	ReadWrite Access = "RW"
+)
+
+// Kind is the MCCS value type of a feature
+type Kind string
+
+const (
+	Continuous    Kind = "C"  // A range from 0 to the reported maximum
+	NonContinuous Kind = "NC" // One of an enumerated set of values
+	Table         Kind = "T"  // A multi-byte table, not readable through Get/SetVCP
+)
+
+// Feature describes one VCP code
+type Feature struct {
+	Code   byte
+	Name   string
+	Access Access
+	Kind   Kind
+}
+
+// features is the VCP code table of MCCS 2.2a. Codes 0xE0-0xFF are
+// manufacturer-specific and deliberately absent.
+var features = map[byte]Feature{
+	0x01: {0x01, "Degauss", WriteOnly, NonContinuous},
+	0x02: {0x02, "New control value", ReadWrite, NonContinuous},
+	0x03: {0x03, "Soft controls", ReadWrite, NonContinuous},
+	0x04: {0x04, "Restore factory defaults", WriteOnly, NonContinuous},
+	0x05: {0x05, "Restore factory brightness/contrast defaults", WriteOnly, NonContinuous},
+	0x06: {0x06, "Restore factory geometry defaults", WriteOnly, NonContinuous},
+	0x08: {0x08, "Restore factory color defaults", WriteOnly, NonContinuous},
+	0x0A: {0x0A, "Restore factory TV defaults", WriteOnly, NonContinuous},
+	0x0B: {0x0B, "Color temperature increment", ReadOnly, Continuous},
+	0x0C: {0x0C, "Color temperature request", ReadWrite, Continuous},
+	0x0E: {0x0E, "Clock", ReadWrite, Continuous},
+	0x10: {0x10, "Brightness", ReadWrite, Continuous},
+	0x11: {0x11, "Flesh tone enhancement", ReadWrite, NonContinuous},
+	0x12: {0x12, "Contrast", ReadWrite, Continuous},
+	0x13: {0x13, "Backlight control", ReadWrite, Continuous},
+	0x14: {0x14, "Select color preset", ReadWrite, NonContinuous},
+	0x16: {0x16, "Video gain: Red", ReadWrite, Continuous},
+	0x17: {0x17, "User color vision compensation", ReadWrite, Continuous},
+	0x18: {0x18, "Video gain: Green", ReadWrite, Continuous},
+	0x1A: {0x1A, "Video gain: Blue", ReadWrite, Continuous},
+	0x1C: {0x1C, "Focus", ReadWrite, Continuous},
+	0x1E: {0x1E, "Auto setup", ReadWrite, NonContinuous},
+	0x1F: {0x1F, "Auto color setup", ReadWrite, NonContinuous},
+	0x20: {0x20, "Horizontal position", ReadWrite, Continuous},
+	0x22: {0x22, "Horizontal size", ReadWrite, Continuous},
+	0x24: {0x24, "Horizontal pincushion", ReadWrite, Continuous},
+	0x26: {0x26, "Horizontal pincushion balance", ReadWrite, Continuous},
+	0x28: {0x28, "Horizontal convergence R/B", ReadWrite, Continuous},
+	0x29: {0x29, "Horizontal convergence M/G", ReadWrite, Continuous},
+	0x2A: {0x2A, "Horizontal linearity", ReadWrite, Continuous},
+	0x2C: {0x2C, "Horizontal linearity balance", ReadWrite, Continuous},
+	0x2E: {0x2E, "Gray scale expansion", ReadWrite, NonContinuous},
+	0x30: {0x30, "Vertical position", ReadWrite, Continuous},
+	0x32: {0x32, "Vertical size", ReadWrite, Continuous},
+	0x34: {0x34, "Vertical pincushion", ReadWrite, Continuous},
+	0x36: {0x36, "Vertical pincushion balance", ReadWrite, Continuous},
+	0x38: {0x38, "Vertical convergence R/B", ReadWrite, Continuous},
+	0x39: {0x39, "Vertical convergence M/G", ReadWrite, Continuous},
+	0x3A: {0x3A, "Vertical linearity", ReadWrite, Continuous},
+	0x3C: {0x3C, "Vertical linearity balance", ReadWrite, Continuous},
+	0x3E: {0x3E, "Clock phase", ReadWrite, Continuous},
+	0x40: {0x40, "Horizontal parallelogram", ReadWrite, Continuous},
+	0x41: {0x41, "Vertical parallelogram", ReadWrite, Continuous},
+	0x42: {0x42, "Horizontal keystone", ReadWrite, Continuous},
+	0x43: {0x43, "Vertical keystone", ReadWrite, Continuous},
+	0x44: {0x44, "Rotation", ReadWrite, Continuous},
+	0x46: {0x46, "Top corner flare", ReadWrite, Continuous},
+	0x48: {0x48, "Top corner hook", ReadWrite, Continuous},
+	0x4A: {0x4A, "Bottom corner flare", ReadWrite, Continuous},
+	0x4C: {0x4C, "Bottom corner hook", ReadWrite, Continuous},
+	0x52: {0x52, "Active control", ReadOnly, NonContinuous},
+	0x54: {0x54, "Performance preservation", ReadWrite, NonContinuous},
+	0x56: {0x56, "Horizontal moire", ReadWrite, Continuous},
+	0x58: {0x58, "Vertical moire", ReadWrite, Continuous},
+	0x59: {0x59, "6-axis saturation: Red", ReadWrite, Continuous},
+	0x5A: {0x5A, "6-axis saturation: Yellow", ReadWrite, Continuous},
+	0x5B: {0x5B, "6-axis saturation: Green", ReadWrite, Continuous},
+	0x5C: {0x5C, "6-axis saturation: Cyan", ReadWrite, Continuous},
+	0x5D: {0x5D, "6-axis saturation: Blue", ReadWrite, Continuous},
+	0x5E: {0x5E, "6-axis saturation: Magenta", ReadWrite, Continuous},
+	0x60: {0x60, "Input source", ReadWrite, NonContinuous},
+	0x62: {0x62, "Audio speaker volume", ReadWrite, Continuous},
+	0x63: {0x63, "Speaker select", ReadWrite, NonContinuous},
+	0x64: {0x64, "Audio microphone volume", ReadWrite, Continuous},
+	0x66: {0x66, "Ambient light sensor", ReadWrite, NonContinuous},
+	0x6B: {0x6B, "Backlight level: White", ReadWrite, Continuous},
+	0x6C: {0x6C, "Video black level: Red", ReadWrite, Continuous},
+	0x6D: {0x6D, "Backlight level: Red", ReadWrite, Continuous},
+	0x6E: {0x6E, "Video black level: Green", ReadWrite, Continuous},
+	0x6F: {0x6F, "Backlight level: Green", ReadWrite, Continuous},
+	0x70: {0x70, "Video black level: Blue", ReadWrite, Continuous},
+	0x71: {0x71, "Backlight level: Blue", ReadWrite, Continuous},
+	0x72: {0x72, "Gamma", ReadWrite, NonContinuous},
+	0x73: {0x73, "LUT size", ReadOnly, Table},
+	0x74: {0x74, "Single point LUT operation", ReadWrite, Table},
+	0x75: {0x75, "Block LUT operation", ReadWrite, Table},
+	0x76: {0x76, "Remote procedure call", WriteOnly, Table},
+	0x78: {0x78, "Display identification operation", ReadOnly, Table},
+	0x7A: {0x7A, "Adjust focal plane", ReadWrite, Continuous},
+	0x7C: {0x7C, "Adjust zoom", ReadWrite, Continuous},
+	0x7E: {0x7E, "Trapezoid", ReadWrite, Continuous},
+	0x80: {0x80, "Keystone", ReadWrite, Continuous},
+	0x82: {0x82, "Horizontal mirror (flip)", ReadWrite, NonContinuous},
+	0x84: {0x84, "Vertical mirror (flip)", ReadWrite, NonContinuous},
+	0x86: {0x86, "Display scaling", ReadWrite, NonContinuous},
+	0x87: {0x87, "Sharpness", ReadWrite, Continuous},
+	0x88: {0x88, "Velocity scan modulation", ReadWrite, Continuous},
+	0x8A: {0x8A, "Color saturation", ReadWrite, Continuous},
+	0x8B: {0x8B, "TV channel up/down", WriteOnly, NonContinuous},
+	0x8C: {0x8C, "TV sharpness", ReadWrite, Continuous},
+	0x8D: {0x8D, "Audio mute", ReadWrite, NonContinuous},
+	0x8E: {0x8E, "TV contrast", ReadWrite, Continuous},
+	0x8F: {0x8F, "Audio treble", ReadWrite, Continuous},
+	0x90: {0x90, "Hue", ReadWrite, Continuous},
+	0x91: {0x91, "Audio bass", ReadWrite, Continuous},
+	0x92: {0x92, "TV black level/luminance", ReadWrite, Continuous},
+	0x93: {0x93, "Audio balance L/R", ReadWrite, Continuous},
+	0x94: {0x94, "Audio processor mode", ReadWrite, NonContinuous},
+	0x95: {0x95, "Window position (TL_X)", ReadWrite, Continuous},
+	0x96: {0x96, "Window position (TL_Y)", ReadWrite, Continuous},
+	0x97: {0x97, "Window position (BR_X)", ReadWrite, Continuous},
+	0x98: {0x98, "Window position (BR_Y)", ReadWrite, Continuous},
+	0x99: {0x99, "Window control on/off", ReadWrite, NonContinuous},
+	0x9A: {0x9A, "Window background", ReadWrite, Continuous},
+	0x9B: {0x9B, "6-axis hue: Red", ReadWrite, Continuous},
+	0x9C: {0x9C, "6-axis hue: Yellow", ReadWrite, Continuous},
+	0x9D: {0x9D, "6-axis hue: Green", ReadWrite, Continuous},
+	0x9E: {0x9E, "6-axis hue: Cyan", ReadWrite, Continuous},
+	0x9F: {0x9F, "6-axis hue: Blue", ReadWrite, Continuous},
+	0xA0: {0xA0, "6-axis hue: Magenta", ReadWrite, Continuous},
+	0xA2: {0xA2, "Auto setup on/off", WriteOnly, NonContinuous},
+	0xA4: {0xA4, "Window mask control", ReadWrite, Table},
+	0xA5: {0xA5, "Change the selected window", ReadWrite, NonContinuous},
+	0xAA: {0xAA, "Screen orientation", ReadOnly, NonContinuous},
+	0xAC: {0xAC, "Horizontal frequency", ReadOnly, Continuous},
+	0xAE: {0xAE, "Vertical frequency", ReadOnly, Continuous},
+	0xB0: {0xB0, "Settings", WriteOnly, NonContinuous},
+	0xB2: {0xB2, "Flat panel sub-pixel layout", ReadOnly, NonContinuous},
+	0xB4: {0xB4, "Source timing mode", ReadWrite, Table},
+	0xB6: {0xB6, "Display technology type", ReadOnly, NonContinuous},
+	0xB7: {0xB7, "Monitor status", ReadOnly, NonContinuous},
+	0xB8: {0xB8, "Packet count", ReadWrite, Continuous},
+	0xB9: {0xB9, "Monitor X origin", ReadWrite, Continuous},
+	0xBA: {0xBA, "Monitor Y origin", ReadWrite, Continuous},
+	0xBB: {0xBB, "Header error count", ReadWrite, Continuous},
+	0xBC: {0xBC, "Body CRC error count", ReadWrite, Continuous},
+	0xBD: {0xBD, "Client ID", ReadWrite, Continuous},
+	0xBE: {0xBE, "Link control", ReadWrite, NonContinuous},
+	0xC0: {0xC0, "Display usage time", ReadOnly, Continuous},
+	0xC2: {0xC2, "Display descriptor length", ReadOnly, Continuous},
+	0xC3: {0xC3, "Transmit display descriptor", ReadWrite, Table},
+	0xC4: {0xC4, "Enable display of display descriptor", ReadWrite, NonContinuous},
+	0xC6: {0xC6, "Application enable key", ReadOnly, NonContinuous},
+	0xC8: {0xC8, "Display controller type", ReadOnly, NonContinuous},
+	0xC9: {0xC9, "Display firmware level", ReadOnly, Continuous},
+	0xCA: {0xCA, "OSD/Button control", ReadWrite, NonContinuous},
+	0xCC: {0xCC, "OSD language", ReadWrite, NonContinuous},
+	0xCD: {0xCD, "Status indicators", ReadWrite, NonContinuous},
+	0xCE: {0xCE, "Auxiliary display size", ReadOnly, NonContinuous},
+	0xCF: {0xCF, "Auxiliary display data", WriteOnly, Table},
+	0xD0: {0xD0, "Output select", ReadWrite, NonContinuous},
+	0xD2: {0xD2, "Asset tag", ReadWrite, Table},
+	0xD4: {0xD4, "Stereo video mode", ReadWrite, NonContinuous},
+	0xD6: {0xD6, "Power mode", ReadWrite, NonContinuous},
+	0xD7: {0xD7, "Auxiliary power output", ReadWrite, NonContinuous},
+	0xDA: {0xDA, "Scan mode", ReadWrite, NonContinuous},
+	0xDB: {0xDB, "Image mode", ReadWrite, NonContinuous},
+	0xDC: {0xDC, "Display mode", ReadWrite, NonContinuous},
+	0xDE: {0xDE, "Scratch pad", ReadWrite, NonContinuous},
+	0xDF: {0xDF, "VCP version", ReadOnly, NonContinuous},
+}
+
+// Lookup returns the definition of a VCP code
+func Lookup(code byte) (Feature, bool) {
+	f, ok := features[code]
+	return f, ok
+}
+
+// Name returns the MCCS name of a VCP code, or a generic one for unknown codes
+func Name(code byte) string {
+	if f, ok := features[code]; ok {
+		return f.Name
+	}
+	if code >= 0xE0 {
+		return "Manufacturer specific"
+	}
+	return "Unknown feature"
+}
+
+// Readable reports whether a code may be read; unknown codes are assumed readable
+func Readable(code byte) bool {
+	f, ok := features[code]
+	return !ok || (f.Access != WriteOnly && f.Kind != Table)
+}
+
+// Writable reports whether a code may be written; unknown codes are assumed writable
+func Writable(code byte) bool {
+	f, ok := features[code]
+	return !ok || (f.Access != ReadOnly && f.Kind != Table)
+}
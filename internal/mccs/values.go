@@ -0,0 +1,110 @@
+// Package mccs holds VESA MCCS (Monitor Control Command Set) definitions.
+package mccs
+
+import "fmt"
+
+// valueNames maps non-continuous VCP codes to their standard value names.
+// Only the low byte (SL) of a value is significant for these features.
+var valueNames = map[byte]map[byte]string{
+	0x14: { // Select color preset
+		0x01: "sRGB",
+		0x02: "Display native",
+		0x03: "4000 K",
+		0x04: "5000 K",
+		0x05: "6500 K",
+		0x06: "7500 K",
+		0x07: "8200 K",
+		0x08: "9300 K",
+		0x09: "10000 K",
+		0x0A: "11500 K",
+		0x0B: "User 1",
+		0x0C: "User 2",
+		0x0D: "User 3",
+	},
+	0x60: { // Input source
+		0x01: "VGA-1",
+		0x02: "VGA-2",
+		0x03: "DVI-1",
+		0x04: "DVI-2",
+		0x05: "Composite-1",
+		0x06: "Composite-2",
+		0x07: "S-Video-1",
+		0x08: "S-Video-2",
+		0x09: "Tuner-1",
+		0x0A: "Tuner-2",
+		0x0B: "Tuner-3",
+		0x0C: "Component-1",
+		0x0D: "Component-2",
+		0x0E: "Component-3",
+		0x0F: "DisplayPort-1",
+		0x10: "DisplayPort-2",
+		0x11: "HDMI-1",
+		0x12: "HDMI-2",
+	},
+	0x8D: { // Audio mute
+		0x01: "Muted",
+		0x02: "Unmuted",
+	},
+	0xB6: { // Display technology type
+		0x01: "CRT (shadow mask)",
+		0x02: "CRT (aperture grill)",
+		0x03: "LCD (active matrix)",
+		0x04: "LCoS",
+		0x05: "Plasma",
+		0x06: "OLED",
+		0x07: "EL",
+		0x08: "Dynamic MEM",
+		0x09: "Static MEM",
+	},
+	0xCC: { // OSD language
+		0x01: "Chinese (traditional)",
+		0x02: "English",
+		0x03: "French",
+		0x04: "German",
+		0x05: "Italian",
+		0x06: "Japanese",
+		0x07: "Korean",
+		0x08: "Portuguese (Portugal)",
+		0x09: "Russian",
+		0x0A: "Spanish",
+		0x0B: "Swedish",
+		0x0C: "Turkish",
+		0x0D: "Chinese (simplified)",
+		0x0E: "Portuguese (Brazil)",
+	},
+	0xD6: { // Power mode
+		0x01: "On",
+		0x02: "Standby",
+		0x03: "Suspend",
+		0x04: "Off (DPM)",
+		0x05: "Off (hard)",
+	},
+	0xDC: { // Display mode
+		0x00: "Standard",
+		0x01: "Productivity",
+		0x02: "Mixed",
+		0x03: "Movie",
+		0x04: "User defined",
+		0x05: "Games",
+		0x06: "Sports",
+		0x07: "Professional",
+		0x08: "Standard (intermediate)",
+		0x09: "Standard (high)",
+		0x0A: "Demonstration",
+		0xF0: "Dynamic contrast",
+	},
+}
+
+// ValueName returns the MCCS name of a value of a non-continuous feature
+func ValueName(code byte, value uint16) (string, bool) {
+	name, ok := valueNames[code][byte(value)]
+	return name, ok
+}
+
+// Decode renders a value with its name when one is known, e.g. "17 (HDMI-1)"
+func Decode(code byte, value uint16) string {
+	if name, ok := ValueName(code, value); ok {
+		return fmt.Sprintf("%d (%s)", value, name)
+	}
+	return fmt.Sprintf("%d", value)
+}
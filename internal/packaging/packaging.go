@@ -0,0 +1,103 @@
+// Package packaging renders distribution manifests (Homebrew, Scoop, Debian)
+// from build metadata so they stay in sync with the command tree.
+package packaging
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// Metadata is what the manifest templates are rendered from
+type Metadata struct {
+	Name        string
+	Version     string
+	Description string
+	Homepage    string
+	Commands    []string          // Top-level subcommands, for descriptions and completions
+	Checksums   map[string]string // Release archive name -> SHA-256
+}
+
+// Archive returns the release archive name for an OS/arch pair
+func (m Metadata) Archive(goos, goarch string) string {
+	ext := "tar.gz"
+	if goos == "windows" {
+		ext = "zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s.%s", m.Name, m.Version, goos, goarch, ext)
+}
+
+// URL returns the download URL of a release archive
+func (m Metadata) URL(goos, goarch string) string {
+	return fmt.Sprintf("%s/releases/download/v%s/%s", m.Homepage, m.Version, m.Archive(goos, goarch))
+}
+
+// SHA256 returns the checksum of a release archive, or a placeholder
+func (m Metadata) SHA256(goos, goarch string) string {
+	if sum, ok := m.Checksums[m.Archive(goos, goarch)]; ok {
+		return sum
+	}
+	return "REPLACE_WITH_SHA256"
+}
+
+// Render writes every manifest into dir and returns the written paths
+func Render(dir string, meta Metadata) ([]string, error) {
+	names, err := templates.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	funcs := template.FuncMap{"join": strings.Join}
+
+	var written []string
+	for _, entry := range names {
+		tmpl, err := template.New(entry.Name()).Funcs(funcs).ParseFS(templates, "templates/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		path := filepath.Join(dir, strings.TrimSuffix(entry.Name(), ".tmpl"))
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		err = tmpl.Execute(f, meta)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("rendering %s: %w", entry.Name(), err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// ReadChecksums parses a sha256sum-style file ("<sum>  <file>" per line)
+func ReadChecksums(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 {
+			sums[strings.TrimPrefix(fields[1], "*")] = fields[0]
+		}
+	}
+	return sums, scanner.Err()
+}
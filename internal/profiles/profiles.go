@@ -0,0 +1,166 @@
+// Package profiles stores named snapshots of every monitor's settings as YAML
+// files in the config directory, one file per profile.
+package profiles
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is a saved configuration of every monitor
+type Profile struct {
+	Name     string    `yaml:"name"`
+	Monitors []Monitor `yaml:"monitors"`
+	Layout   *Layout   `yaml:"layout,omitempty"` // Desktop arrangement, applied through the OS rather than DDC/CI
+}
+
+// Layout is the display arrangement of a profile, in the terms of the layout
+// command. Outputs are named as for the mode command.
+type Layout struct {
+	Primary   string            `yaml:"primary,omitempty"`
+	Positions map[string]string `yaml:"positions,omitempty"` // Output -> "X,Y"
+	Mirror    bool              `yaml:"mirror,omitempty"`
+	Extend    bool              `yaml:"extend,omitempty"`
+}
+
+// Monitor is one monitor's settings in a profile. Settings that couldn't be
+// read when the profile was saved are nil and left alone on apply.
+type Monitor struct {
+	ID         string  `yaml:"id"`
+	StableID   string  `yaml:"stable_id,omitempty"` // Preferred over ID, which can change across reboots
+	Name       string  `yaml:"name,omitempty"`      // Used to find the monitor again if its ID changed
+	Input      *uint16 `yaml:"input,omitempty"`
+	Brightness *uint16 `yaml:"brightness,omitempty"`
+	Contrast   *uint16 `yaml:"contrast,omitempty"`
+
+	// Mode is the resolution and refresh rate to set through the OS, e.g.
+	// "2560x1440@120", on Output, or the monitor's connector if Output is empty
+	Mode   string `yaml:"mode,omitempty"`
+	Output string `yaml:"output,omitempty"`
+}
+
+// Dir returns the directory profiles are stored in
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "monitorswitch", "profiles"), nil
+}
+
+func profilePath(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid profile name %q", name)
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".yaml"), nil
+}
+
+// Load reads a profile by name
+func Load(name string) (*Profile, error) {
+	path, err := profilePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("profile %q not found", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var p Profile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("corrupt profile %s: %w", path, err)
+	}
+	p.Name = name
+	return &p, nil
+}
+
+// Save writes the profile, replacing any profile of the same name
+func (p *Profile) Save() error {
+	path, err := profilePath(p.Name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Delete removes a profile
+func Delete(name string) error {
+	path, err := profilePath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("profile %q not found", name)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// List returns the names of all saved profiles, sorted
+func List() ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".yaml"); ok && !e.IsDir() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Find returns the profile entry for a monitor, matching by stable ID, then
+// ID and then name. Entries with a stable ID only match that monitor.
+func (p *Profile) Find(stableID, id, name string) (Monitor, bool) {
+	for _, m := range p.Monitors {
+		if stableID != "" && m.StableID == stableID {
+			return m, true
+		}
+	}
+	for _, m := range p.Monitors {
+		if m.ID == id && (m.StableID == "" || stableID == "") {
+			return m, true
+		}
+	}
+	for _, m := range p.Monitors {
+		if name != "" && m.Name == name && (m.StableID == "" || stableID == "") {
+			return m, true
+		}
+	}
+	return Monitor{}, false
+}
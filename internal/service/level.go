@@ -0,0 +1,120 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/state"
+)
+
+// LevelRequest reads or changes a continuous feature (brightness, contrast,
+// volume) as a percentage of the maximum the monitor reports
+type LevelRequest struct {
+	Feature   string // Feature name for results and the state store, e.g. "brightness"
+	Code      byte   // VCP code, e.g. ddc.VCPBrightness
+	MonitorID string // Empty selects the only monitor, or every monitor with All
+	All       bool   // Apply to every detected monitor
+	Set       bool   // Change the level; otherwise only read it
+	Percent   int    // New level, or the change when Relative
+	Relative  bool   // Percent is added to the current level
+}
+
+// LevelResult is the outcome of Level
+type LevelResult struct {
+	Feature  string         `json:"feature"`
+	Monitors []MonitorLevel `json:"monitors"`
+}
+
+// MonitorLevel is one monitor's level before and after the request
+type MonitorLevel struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Previous *int   `json:"previous,omitempty"` // Percent before the change, if it could be read
+	Percent  int    `json:"percent"`
+	Raw      uint16 `json:"raw"`
+	Max      uint16 `json:"max,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Failed returns how many monitors reported an error
+func (r *LevelResult) Failed() int {
+	n := 0
+	for _, m := range r.Monitors {
+		if m.Error != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// Level reads or sets a continuous feature, clamping to 0-100% of the
+// monitor-reported maximum. Per-monitor failures are part of the result.
+func (s *Service) Level(req LevelRequest) (*LevelResult, error) {
+	monitors, err := s.Monitors()
+	if err != nil {
+		return nil, fmt.Errorf("monitor detection failed: %w", err)
+	}
+
+	targets, err := SelectMonitors(monitors, req.MonitorID, s.Config)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no DDC/CI compatible monitors detected")
+	}
+	if len(targets) > 1 && !req.All {
+		return nil, fmt.Errorf("%d monitors detected; choose one with --monitor or use --all", len(targets))
+	}
+
+	result := &LevelResult{Feature: req.Feature, Monitors: []MonitorLevel{}}
+	for _, m := range targets {
+		result.Monitors = append(result.Monitors, s.level(m, req))
+	}
+
+	if req.Set {
+		s.updateState(func(st *state.State) {
+			for i, ml := range result.Monitors {
+				if ml.Error == "" {
+					st.RecordValue(ddc.StableKey(targets[i]), targets[i].Name, req.Code, ml.Raw, req.Feature)
+				}
+			}
+		})
+	}
+	return result, nil
+}
+
+func (s *Service) level(m ddc.Monitor, req LevelRequest) MonitorLevel {
+	ml := MonitorLevel{ID: m.ID, Name: m.Name}
+
+	current, readErr := s.Client.GetVCPValue(m.ID, req.Code)
+	if readErr == nil {
+		percent := ddc.RawToPercent(current.Current, current.Max)
+		ml.Previous = &percent
+		ml.Percent, ml.Raw, ml.Max = percent, current.Current, current.Max
+	}
+
+	if !req.Set {
+		if readErr != nil {
+			ml.Error = readErr.Error()
+		}
+		return ml
+	}
+
+	percent := req.Percent
+	if req.Relative {
+		if readErr != nil {
+			ml.Error = fmt.Sprintf("cannot read the current %s to adjust it: %v", req.Feature, readErr)
+			return ml
+		}
+		percent += *ml.Previous
+	}
+	percent = min(max(percent, 0), 100)
+
+	raw := ddc.PercentToRaw(percent, current.Max)
+	if err := s.Client.SetVCP(m.ID, req.Code, raw); err != nil {
+		ml.Error = err.Error()
+		return ml
+	}
+	ml.Percent, ml.Raw = percent, raw
+	return ml
+}
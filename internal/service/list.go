@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+)
+
+// ListResult is the outcome of List
+type ListResult struct {
+	Monitors []MonitorInputs `json:"monitors"`
+}
+
+// MonitorInputs lists the inputs one monitor advertises
+type MonitorInputs struct {
+	ID     string      `json:"id"`
+	Name   string      `json:"name"`
+	Asleep bool        `json:"asleep,omitempty"`
+	Error  string      `json:"error,omitempty"`
+	Inputs []InputInfo `json:"inputs"`
+}
+
+// InputInfo is one input source (a VCP 0x60 value)
+type InputInfo struct {
+	Name   string `json:"name"`
+	Code   byte   `json:"code"`
+	Label  string `json:"label,omitempty"`
+	Active bool   `json:"active"`
+}
+
+// List reports the inputs each monitor advertises in its capabilities string,
+// sorted by VCP value, and marks the active one
+func (s *Service) List(monitorID string) (*ListResult, error) {
+	monitors, err := s.Monitors()
+	if err != nil {
+		return nil, fmt.Errorf("monitor detection failed: %w", err)
+	}
+
+	targets, err := SelectMonitors(monitors, monitorID, s.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResult{Monitors: []MonitorInputs{}}
+	for _, m := range targets {
+		mi := MonitorInputs{ID: m.ID, Name: m.Name, Asleep: ddc.MonitorAsleep(m), Inputs: []InputInfo{}}
+		if mi.Asleep {
+			result.Monitors = append(result.Monitors, mi)
+			continue
+		}
+
+		inputs := m.Inputs
+		caps, err := s.Client.GetCapabilities(m.ID)
+		if err != nil {
+			mi.Error = err.Error()
+		} else if len(caps.SupportedInputs) > 0 {
+			inputs = caps.SupportedInputs
+		}
+
+		current, currentErr := s.Client.GetVCP(m.ID, ddc.VCPInputSource)
+		for name, code := range inputs {
+			mi.Inputs = append(mi.Inputs, InputInfo{
+				Name:   name,
+				Code:   code,
+				Label:  s.Labels.Label(m, name),
+				Active: currentErr == nil && uint16(code) == current,
+			})
+		}
+		sort.Slice(mi.Inputs, func(i, j int) bool { return mi.Inputs[i].Code < mi.Inputs[j].Code })
+
+		result.Monitors = append(result.Monitors, mi)
+	}
+	return result, nil
+}
@@ -0,0 +1,309 @@
+// Package service implements monitorswitch operations as functions returning
+// typed results, so the CLI renderers (and anything else) share one code path.
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/sibteali786/monitorswitch/internal/audio"
+	"github.com/sibteali786/monitorswitch/internal/config"
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/labels"
+	"github.com/sibteali786/monitorswitch/internal/state"
+)
+
+// Service runs operations against one DDC client and detection pass
+type Service struct {
+	Client   ddc.DDCClient
+	Monitors func() ([]ddc.Monitor, error) // Detection, typically memoized by the caller
+	Labels   labels.Labels
+	Audio    audio.Links   // Audio output to select after switching to an input
+	Config   config.Config // Monitor aliases and input names
+
+	// StateError, if set, is told about state store failures. The state
+	// store is best-effort and never fails an operation.
+	StateError func(err error)
+
+	// Logger, if set, receives diagnostics from OS and monitor detection
+	Logger *slog.Logger
+}
+
+// DetectOptions controls how much probing Detect does
+type DetectOptions struct {
+	Deep bool // Probe every feature to build the support matrix
+	GPUs bool // List graphics adapters and drivers
+}
+
+// DetectResult is the outcome of Detect
+type DetectResult struct {
+	OS           ddc.OSInfo          `json:"os"`
+	DDCSupported bool                `json:"ddc_supported"`
+	DDCMessage   string              `json:"ddc_message"`
+	Session      *ddc.Session        `json:"session,omitempty"`
+	Virtualized  *ddc.Virtualization `json:"virtualization,omitempty"`
+	Advice       []string            `json:"advice,omitempty"`
+	GPUs         []ddc.GPU           `json:"gpus,omitempty"`
+	DetectError  string              `json:"detect_error,omitempty"`
+	Monitors     []MonitorResult     `json:"monitors"`
+}
+
+// MonitorResult describes one detected monitor
+type MonitorResult struct {
+	ID           string            `json:"id"`
+	StableID     string            `json:"stable_id,omitempty"`
+	Name         string            `json:"name"`
+	Serial       string            `json:"serial,omitempty"`
+	Connector    string            `json:"connector,omitempty"`
+	CurrentInput string            `json:"current_input,omitempty"`
+	InputLabel   string            `json:"input_label,omitempty"`
+	Inputs       map[string]byte   `json:"inputs,omitempty"`
+	Asleep       bool              `json:"asleep,omitempty"`
+	DDCSupported bool              `json:"ddc_supported"`
+	DDCTool      string            `json:"ddc_tool,omitempty"`
+	Features     []ddc.Feature     `json:"features,omitempty"` // What the backends can control
+	Support      ddc.SupportMatrix `json:"support,omitempty"`
+
+	// Validation is set by backends that probe whether the monitor applies
+	// writes before trusting it (the macOS CLI tool fallback)
+	Validation *ddc.DDCValidationResult `json:"validation,omitempty"`
+
+	// Fingerprint hashes capabilities and EDID. PreviousFingerprint is set
+	// when it differs from the last run, e.g. after a firmware update.
+	Fingerprint         string `json:"fingerprint,omitempty"`
+	PreviousFingerprint string `json:"previous_fingerprint,omitempty"`
+}
+
+// SwitchRequest asks Switch to change a monitor's input
+type SwitchRequest struct {
+	MonitorID    string // Required when several monitors are connected
+	Input        string // Input name, label or numeric code
+	ReadPrevious bool   // Read the current input first, e.g. to revert later
+	AudioDevice  string // Audio output to select afterwards, overriding Service.Audio
+	SkipAudio    bool   // Leave the audio output alone
+}
+
+// SwitchResult is the outcome of Switch
+type SwitchResult struct {
+	MonitorID   string `json:"monitor_id"`
+	MonitorName string `json:"monitor_name"`
+	Input       string `json:"input"`
+	Code        byte   `json:"code"`
+	Previous    uint16 `json:"previous,omitempty"`
+	RevertToken string `json:"revert_token,omitempty"`
+	RevertAfter string `json:"revert_after,omitempty"`
+	AudioDevice string `json:"audio_device,omitempty"`
+	AudioError  string `json:"audio_error,omitempty"`
+}
+
+// Detect reports the OS, DDC support and every monitor. Detection failures
+// are part of the result rather than an error, since partial output helps.
+func (s *Service) Detect(opts DetectOptions) *DetectResult {
+	detector := ddc.NewDetector()
+	detector.SetLogger(s.Logger)
+	result := &DetectResult{OS: detector.OSInfo(), Monitors: []MonitorResult{}}
+	result.DDCSupported, result.DDCMessage = detector.CheckDDCSupport()
+	if result.Session = detector.Session(); result.Session != nil {
+		result.Advice = result.Session.Advice(result.DDCSupported)
+	}
+	result.Virtualized = ddc.DetectVirtualization()
+	if opts.GPUs {
+		result.GPUs = detector.GPUs()
+	}
+
+	monitors, err := s.Monitors()
+	if err != nil {
+		result.DetectError = err.Error()
+	}
+
+	s.updateState(func(st *state.State) {
+		for _, m := range monitors {
+			if m.CurrentInput != "" {
+				st.RecordInput(ddc.StableKey(m), m.Name, m.CurrentInput)
+			}
+		}
+	})
+
+	for _, m := range monitors {
+		mr := MonitorResult{
+			ID:           m.ID,
+			StableID:     m.StableID,
+			Name:         m.Name,
+			Serial:       m.Serial,
+			Connector:    m.Connector,
+			CurrentInput: m.CurrentInput,
+			InputLabel:   s.Labels.Label(m, m.CurrentInput),
+			Inputs:       m.Inputs,
+			Asleep:       ddc.MonitorAsleep(m),
+			DDCSupported: m.DDCSupported,
+			DDCTool:      m.DDCTool,
+			Features:     ddc.MonitorFeatures(s.Client, m).List(),
+			Validation:   m.Validation,
+		}
+		if !mr.Asleep {
+			mr.Fingerprint, _ = ddc.Fingerprint(s.Client, m)
+		}
+		if opts.Deep && !mr.Asleep {
+			mr.Support = ddc.BuildSupportMatrix(s.Client, m)
+		}
+		result.Monitors = append(result.Monitors, mr)
+	}
+
+	s.updateState(func(st *state.State) {
+		for i, mr := range result.Monitors {
+			if mr.Fingerprint == "" {
+				continue
+			}
+			if previous, changed := st.RecordFingerprint(ddc.StableKey(monitors[i]), mr.Name, mr.Fingerprint); changed {
+				result.Monitors[i].PreviousFingerprint = previous
+			}
+		}
+	})
+	return result
+}
+
+// ResolveSwitch finds the monitor and input code a switch request names,
+// without switching
+func (s *Service) ResolveSwitch(req SwitchRequest) (ddc.Monitor, string, byte, error) {
+	monitors, err := s.Monitors()
+	if err != nil {
+		return ddc.Monitor{}, "", 0, fmt.Errorf("monitor detection failed: %w", err)
+	}
+
+	targets, err := SelectMonitors(monitors, req.MonitorID, s.Config)
+	if err != nil {
+		return ddc.Monitor{}, "", 0, err
+	}
+	if req.MonitorID == "" {
+		// Outputs without DDC/CI don't count towards needing --monitor
+		targets = DDCMonitors(targets)
+	}
+	if len(targets) == 0 {
+		return ddc.Monitor{}, "", 0, fmt.Errorf("no DDC/CI compatible monitors detected")
+	}
+	if len(targets) > 1 {
+		return ddc.Monitor{}, "", 0, fmt.Errorf("%d monitors detected; choose one with --monitor", len(targets))
+	}
+	target := targets[0]
+	if !target.DDCSupported {
+		return ddc.Monitor{}, "", 0, fmt.Errorf("monitor %s (%s) doesn't answer DDC/CI, so its input can't be switched", target.ID, target.Name)
+	}
+
+	// Labels ("Work MacBook") and config input names are accepted wherever
+	// an input name is
+	input := req.Input
+	if labeled, ok := s.Labels.Resolve(target, input); ok {
+		input = labeled
+	} else if named, ok := s.Config.Input(input); ok {
+		input = named
+	}
+
+	code, err := ddc.ResolveInput(target, input)
+	if err != nil {
+		return ddc.Monitor{}, "", 0, err
+	}
+	return target, input, code, nil
+}
+
+// Switch changes one monitor's input over VCP 0x60 and records it in the state store
+func (s *Service) Switch(req SwitchRequest) (*SwitchResult, error) {
+	target, input, code, err := s.ResolveSwitch(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.CompleteSwitch(req, target, input, code, func() error {
+		return s.Client.SetVCP(target.ID, ddc.VCPInputSource, uint16(code))
+	})
+}
+
+// CompleteSwitch performs a switch ResolveSwitch resolved, with write making
+// the input write (e.g. committing a confirmed plan), then records it and
+// changes the audio output
+func (s *Service) CompleteSwitch(req SwitchRequest, target ddc.Monitor, input string, code byte, write func() error) (*SwitchResult, error) {
+	result := &SwitchResult{MonitorID: target.ID, MonitorName: target.Name, Input: input, Code: code}
+	if req.ReadPrevious {
+		var err error
+		if result.Previous, err = s.Client.GetVCP(target.ID, ddc.VCPInputSource); err != nil {
+			return nil, fmt.Errorf("cannot read the current input to revert to: %w", err)
+		}
+	}
+
+	if err := write(); err != nil {
+		return nil, fmt.Errorf("failed to switch monitor %s: %w", target.ID, err)
+	}
+
+	s.updateState(func(st *state.State) {
+		key := ddc.StableKey(target)
+		st.RecordValue(key, target.Name, ddc.VCPInputSource, uint16(code), "switch")
+		st.RecordInput(key, target.Name, input)
+	})
+
+	// Audio is a side effect: a failure here doesn't undo the switch
+	if !req.SkipAudio {
+		device := req.AudioDevice
+		if device == "" {
+			device = s.Audio.Device(target.ID, input)
+		}
+		if device != "" {
+			result.AudioDevice = device
+			if err := audio.SetDefaultOutput(device); err != nil {
+				result.AudioError = err.Error()
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *Service) updateState(fn func(st *state.State)) {
+	if err := state.Update(fn); err != nil && s.StateError != nil {
+		s.StateError(err)
+	}
+}
+
+// DDCMonitors returns the monitors that answered DDC/CI during detection
+func DDCMonitors(monitors []ddc.Monitor) []ddc.Monitor {
+	var supported []ddc.Monitor
+	for _, m := range monitors {
+		if m.DDCSupported {
+			supported = append(supported, m)
+		}
+	}
+	return supported
+}
+
+// SelectMonitors returns the monitor matching id, or every monitor when id is
+// empty. id may be a monitor ID, stable ID or connector (the output name
+// compositors, mode and layout use, e.g. "DP-3"), or an alias from
+// cfg, which in turn names a monitor by ID, stable ID, connector, serial,
+// UUID or name.
+func SelectMonitors(monitors []ddc.Monitor, id string, cfg config.Config) ([]ddc.Monitor, error) {
+	if id == "" {
+		return monitors, nil
+	}
+
+	for _, m := range monitors {
+		if m.ID == id || (m.StableID != "" && strings.EqualFold(m.StableID, id)) {
+			return []ddc.Monitor{m}, nil
+		}
+	}
+	for _, m := range monitors {
+		if m.Connector != "" && strings.EqualFold(m.Connector, id) {
+			return []ddc.Monitor{m}, nil
+		}
+	}
+
+	if target, ok := cfg.Alias(id); ok {
+		for _, m := range monitors {
+			if m.ID == target || strings.EqualFold(m.Name, target) ||
+				(m.StableID != "" && strings.EqualFold(m.StableID, target)) ||
+				(m.Connector != "" && strings.EqualFold(m.Connector, target)) ||
+				(m.Serial != "" && m.Serial == target) ||
+				(m.UUID != "" && strings.EqualFold(m.UUID, target)) {
+				return []ddc.Monitor{m}, nil
+			}
+		}
+		return nil, fmt.Errorf("monitor %q (alias for %q) not found", id, target)
+	}
+	return nil, fmt.Errorf("monitor %q not found", id)
+}
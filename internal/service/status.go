@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/mccs"
+)
+
+// StatusResult is the outcome of Status
+type StatusResult struct {
+	Monitors []MonitorStatus `json:"monitors"`
+}
+
+// MonitorStatus is the live state of one monitor. Settings that couldn't be
+// read are left nil and the reason is recorded in Errors.
+type MonitorStatus struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Asleep     bool              `json:"asleep,omitempty"`
+	Input      string            `json:"input,omitempty"`
+	InputCode  *uint16           `json:"input_code,omitempty"`
+	InputLabel string            `json:"input_label,omitempty"`
+	Brightness *ddc.VCPValue     `json:"brightness,omitempty"`
+	Contrast   *ddc.VCPValue     `json:"contrast,omitempty"`
+	Volume     *ddc.VCPValue     `json:"volume,omitempty"`
+	Errors     map[string]string `json:"errors,omitempty"`
+}
+
+// Status reads input source, brightness, contrast and volume from the
+// monitor matching monitorID, or from every monitor when it's empty
+func (s *Service) Status(monitorID string) (*StatusResult, error) {
+	monitors, err := s.Monitors()
+	if err != nil {
+		return nil, fmt.Errorf("monitor detection failed: %w", err)
+	}
+
+	targets, err := SelectMonitors(monitors, monitorID, s.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StatusResult{Monitors: []MonitorStatus{}}
+	for _, m := range targets {
+		ms := MonitorStatus{ID: m.ID, Name: m.Name, Asleep: ddc.MonitorAsleep(m)}
+		if ms.Asleep {
+			result.Monitors = append(result.Monitors, ms)
+			continue
+		}
+
+		read := func(feature string, code byte) *ddc.VCPValue {
+			v, err := s.Client.GetVCPValue(m.ID, code)
+			if err != nil {
+				if ms.Errors == nil {
+					ms.Errors = map[string]string{}
+				}
+				ms.Errors[feature] = err.Error()
+				return nil
+			}
+			return &v
+		}
+
+		if v := read("input", ddc.VCPInputSource); v != nil {
+			ms.InputCode = &v.Current
+			ms.Input = inputName(m, v.Current)
+			ms.InputLabel = s.Labels.Label(m, ms.Input)
+		}
+		ms.Brightness = read("brightness", ddc.VCPBrightness)
+		ms.Contrast = read("contrast", ddc.VCPContrast)
+		ms.Volume = read("volume", ddc.VCPVolume)
+
+		result.Monitors = append(result.Monitors, ms)
+	}
+	return result, nil
+}
+
+// inputName names an input value, preferring the name the monitor advertised
+func inputName(m ddc.Monitor, value uint16) string {
+	for name, code := range m.Inputs {
+		if uint16(code) == value {
+			return name
+		}
+	}
+	if name, ok := mccs.ValueName(ddc.VCPInputSource, value); ok {
+		return name
+	}
+	return fmt.Sprintf("0x%02X", value)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/audio"
+	"github.com/sibteali786/monitorswitch/internal/config"
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+)
+
+// SwitchAllRequest asks SwitchAll to move every monitor to the same input
+type SwitchAllRequest struct {
+	Input       string        // Input name, label or numeric code
+	Order       []string      // Monitor IDs or aliases to switch first; the rest follow in detection order
+	Delay       time.Duration // Pause between monitors
+	AudioDevice string        // Audio output to select afterwards, overriding Service.Audio
+	SkipAudio   bool          // Leave the audio output alone
+}
+
+// SwitchAllResult is the outcome of SwitchAll, in the order monitors were switched
+type SwitchAllResult struct {
+	Input       string          `json:"input"`
+	Monitors    []MonitorSwitch `json:"monitors"`
+	Skipped     []MonitorSwitch `json:"skipped,omitempty"` // Monitors that don't answer DDC/CI
+	AudioDevice string          `json:"audio_device,omitempty"`
+	AudioError  string          `json:"audio_error,omitempty"`
+}
+
+// MonitorSwitch is one monitor's part of a SwitchAll
+type MonitorSwitch struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Input string `json:"input,omitempty"`
+	Code  byte   `json:"code,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Failed returns how many monitors couldn't be switched
+func (r *SwitchAllResult) Failed() int {
+	n := 0
+	for _, m := range r.Monitors {
+		if m.Error != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// SwitchAll switches every detected monitor to one input, e.g. to hand a
+// multi-monitor desk to another machine behind a KVM. A failing monitor
+// doesn't stop the others; failures are part of the result. Monitors that
+// don't answer DDC/CI are skipped.
+func (s *Service) SwitchAll(req SwitchAllRequest) (*SwitchAllResult, error) {
+	monitors, err := s.Monitors()
+	if err != nil {
+		return nil, fmt.Errorf("monitor detection failed: %w", err)
+	}
+
+	result := &SwitchAllResult{Input: req.Input, Monitors: []MonitorSwitch{}}
+	for _, m := range monitors {
+		if !m.DDCSupported {
+			result.Skipped = append(result.Skipped, MonitorSwitch{ID: m.ID, Name: m.Name})
+		}
+	}
+	monitors = DDCMonitors(monitors)
+	if len(monitors) == 0 {
+		return nil, fmt.Errorf("no DDC/CI compatible monitors detected")
+	}
+
+	ordered, err := orderMonitors(monitors, req.Order, s.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, m := range ordered {
+		if i > 0 && req.Delay > 0 {
+			time.Sleep(req.Delay)
+		}
+
+		ms := MonitorSwitch{ID: m.ID, Name: m.Name}
+		// Audio is switched once below rather than once per monitor
+		switched, err := s.Switch(SwitchRequest{MonitorID: m.ID, Input: req.Input, SkipAudio: true})
+		if err != nil {
+			ms.Error = err.Error()
+		} else {
+			ms.Input, ms.Code = switched.Input, switched.Code
+		}
+		result.Monitors = append(result.Monitors, ms)
+	}
+
+	if !req.SkipAudio {
+		result.AudioDevice = req.AudioDevice
+		for _, ms := range result.Monitors {
+			if result.AudioDevice != "" {
+				break
+			}
+			if ms.Error == "" {
+				result.AudioDevice = s.Audio.Device(ms.ID, ms.Input)
+			}
+		}
+		if result.AudioDevice != "" {
+			if err := audio.SetDefaultOutput(result.AudioDevice); err != nil {
+				result.AudioError = err.Error()
+			}
+		}
+	}
+	return result, nil
+}
+
+// orderMonitors puts the monitors named in order first, then the rest in
+// detection order
+func orderMonitors(monitors []ddc.Monitor, order []string, cfg config.Config) ([]ddc.Monitor, error) {
+	var ordered []ddc.Monitor
+	placed := make(map[string]bool)
+	for _, id := range order {
+		if id == "" {
+			continue
+		}
+		matches, err := SelectMonitors(monitors, id, cfg)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			if !placed[m.ID] {
+				placed[m.ID] = true
+				ordered = append(ordered, m)
+			}
+		}
+	}
+
+	for _, m := range monitors {
+		if !placed[m.ID] {
+			ordered = append(ordered, m)
+		}
+	}
+	return ordered, nil
+}
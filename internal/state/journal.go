@@ -0,0 +1,128 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Journal records the intent of a multi-step operation before it starts, so
+// an interrupted apply can be finished or rolled back on the next run
+type Journal struct {
+	Operation string        `json:"operation"`
+	StartedAt time.Time     `json:"started_at"`
+	Steps     []JournalStep `json:"steps"`
+}
+
+// JournalStep is one VCP write of a journaled operation. MonitorID can
+// point at another monitor after a reboot or replug, so recovery goes by
+// StableID when the monitor has one.
+type JournalStep struct {
+	MonitorID   string `json:"monitor_id"`
+	StableID    string `json:"stable_id,omitempty"`
+	Code        byte   `json:"code"`
+	Value       uint16 `json:"value"`
+	Previous    uint16 `json:"previous"`
+	HasPrevious bool   `json:"has_previous"` // Whether Previous could be read
+	Done        bool   `json:"done"`
+}
+
+func journalPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "journal.json"), nil
+}
+
+// BeginJournal writes the journal for an operation before any step runs
+func BeginJournal(operation string, steps []JournalStep) (*Journal, error) {
+	if pending, err := PendingJournal(); err == nil && pending != nil {
+		return nil, fmt.Errorf("an interrupted %q operation from %s is pending; run 'monitorswitch recover' first",
+			pending.Operation, pending.StartedAt.Format(time.RFC3339))
+	}
+
+	j := &Journal{
+		Operation: operation,
+		StartedAt: time.Now(),
+		Steps:     steps,
+	}
+	return j, j.write()
+}
+
+// MarkDone records that step i completed
+func (j *Journal) MarkDone(i int) error {
+	j.Steps[i].Done = true
+	return j.write()
+}
+
+// Finish removes the journal once the operation completed (or was recovered)
+func (j *Journal) Finish() error {
+	path, err := journalPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// PendingJournal returns the journal left behind by an interrupted operation, or nil
+func PendingJournal() (*Journal, error) {
+	path, err := journalPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var j Journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("corrupt journal %s: %w", path, err)
+	}
+	return &j, nil
+}
+
+func (j *Journal) write() error {
+	path, err := journalPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write-then-rename, and sync, so a power loss leaves either the old or the new journal
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
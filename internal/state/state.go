@@ -0,0 +1,295 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/filelock"
+)
+
+// maxHistory bounds the change log so the state file stays small
+const maxHistory = 500
+
+// State is everything monitorswitch remembers between runs
+type State struct {
+	LastProfile    string                  `json:"last_profile,omitempty"`
+	Monitors       map[string]MonitorState `json:"monitors,omitempty"` // By ddc.StableKey, so records survive reboots
+	PendingReverts []PendingRevert         `json:"pending_reverts,omitempty"`
+	History        []Change                `json:"history,omitempty"`
+}
+
+// MonitorState is the last-known state of one monitor
+type MonitorState struct {
+	Name        string            `json:"name,omitempty"`
+	Input       string            `json:"input,omitempty"`
+	Values      map[string]uint16 `json:"values,omitempty"`       // VCP code ("0x10") -> last value
+	Parked      map[string]uint16 `json:"parked,omitempty"`       // Snapshot taken by 'park', same keys as Values
+	Fingerprint string            `json:"fingerprint,omitempty"`  // Capabilities/EDID hash, see ddc.Fingerprint
+	SettleDelay time.Duration     `json:"settle_delay,omitempty"` // Write settle delay learned from retries
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// PendingRevert is a scheduled restore of a VCP value
+type PendingRevert struct {
+	Token     string    `json:"token"` // Identifies the revert for 'confirm'
+	MonitorID string    `json:"monitor_id"`
+	Code      byte      `json:"code"`
+	Value     uint16    `json:"value"`
+	Due       time.Time `json:"due"`
+}
+
+// Change is one recorded write performed by monitorswitch
+type Change struct {
+	Time      time.Time `json:"time"`
+	MonitorID string    `json:"monitor_id"` // ddc.StableKey of the monitor
+	Code      byte      `json:"code"`
+	Value     uint16    `json:"value"`
+	Source    string    `json:"source,omitempty"` // Command that made the change
+}
+
+// Dir returns the state directory, honouring XDG_STATE_HOME
+func Dir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "monitorswitch"), nil
+	}
+
+	if runtime.GOOS == "linux" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, ".local", "state", "monitorswitch"), nil
+	}
+
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(config, "monitorswitch", "state"), nil
+}
+
+func statePath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "state.json"), nil
+}
+
+// Load reads the state file, returning an empty state if none exists yet
+func Load() (*State, error) {
+	s := &State{Monitors: make(map[string]MonitorState)}
+
+	path, err := statePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("corrupt state file %s: %w", path, err)
+	}
+	if s.Monitors == nil {
+		s.Monitors = make(map[string]MonitorState)
+	}
+	return s, nil
+}
+
+// Save writes the state atomically so a crash never leaves a truncated file
+func (s *State) Save() error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Update loads the state, applies fn and saves it. It holds a lock on a file
+// next to the state for the whole read-modify-write, so concurrent processes
+// (a background revert, a hotkey daemon) don't overwrite each other's changes.
+func Update(fn func(st *State)) error {
+	unlock, err := lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	st, err := Load()
+	if err != nil {
+		return err
+	}
+	fn(st)
+	return st.Save()
+}
+
+// lock takes the state lock, blocking until other processes release it
+func lock() (func(), error) {
+	path, err := statePath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := filelock.Lock(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("couldn't lock the state file: %w", err)
+	}
+	return func() {
+		filelock.Unlock(f)
+		f.Close()
+	}, nil
+}
+
+// RecordValue remembers a VCP value written to a monitor, by ddc.StableKey,
+// and logs the change
+func (s *State) RecordValue(key, name string, code byte, value uint16, source string) {
+	now := time.Now()
+
+	m := s.Monitors[key]
+	if name != "" {
+		m.Name = name
+	}
+	if m.Values == nil {
+		m.Values = make(map[string]uint16)
+	}
+	m.Values[fmt.Sprintf("0x%02X", code)] = value
+	m.UpdatedAt = now
+	s.Monitors[key] = m
+
+	s.History = append(s.History, Change{
+		Time:      now,
+		MonitorID: key,
+		Code:      code,
+		Value:     value,
+		Source:    source,
+	})
+	if len(s.History) > maxHistory {
+		s.History = s.History[len(s.History)-maxHistory:]
+	}
+}
+
+// RecordInput remembers the last-known input of a monitor, by ddc.StableKey
+func (s *State) RecordInput(key, name, input string) {
+	m := s.Monitors[key]
+	if name != "" {
+		m.Name = name
+	}
+	m.Input = input
+	m.UpdatedAt = time.Now()
+	s.Monitors[key] = m
+}
+
+// RecordFingerprint remembers a monitor's fingerprint and returns the one
+// recorded before, if it was different
+func (s *State) RecordFingerprint(key, name, fingerprint string) (previous string, changed bool) {
+	m := s.Monitors[key]
+	if name != "" {
+		m.Name = name
+	}
+	previous, changed = m.Fingerprint, m.Fingerprint != "" && m.Fingerprint != fingerprint
+	m.Fingerprint = fingerprint
+	m.UpdatedAt = time.Now()
+	s.Monitors[key] = m
+	return previous, changed
+}
+
+// RecordSettleDelay remembers the write settle delay learned for a monitor,
+// by ddc.StableKey
+func (s *State) RecordSettleDelay(key string, delay time.Duration) {
+	m := s.Monitors[key]
+	m.SettleDelay = delay
+	m.UpdatedAt = time.Now()
+	s.Monitors[key] = m
+}
+
+// ChangesSince returns the logged changes made after t
+func (s *State) ChangesSince(t time.Time) []Change {
+	var changes []Change
+	for _, c := range s.History {
+		if c.Time.After(t) {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// Park stores a snapshot of a monitor's VCP values, keyed by code, under the
+// monitor's ddc.StableKey so resume finds it after a reboot
+func (s *State) Park(key, name string, values map[byte]uint16) {
+	m := s.Monitors[key]
+	if name != "" {
+		m.Name = name
+	}
+	m.Parked = make(map[string]uint16)
+	for code, value := range values {
+		m.Parked[fmt.Sprintf("0x%02X", code)] = value
+	}
+	m.UpdatedAt = time.Now()
+	s.Monitors[key] = m
+}
+
+// Unpark returns and clears a monitor's parked snapshot
+func (s *State) Unpark(key string) (map[byte]uint16, bool) {
+	m, ok := s.Monitors[key]
+	if !ok || m.Parked == nil {
+		return nil, false
+	}
+
+	values := make(map[byte]uint16)
+	for hex, value := range m.Parked {
+		if code, err := strconv.ParseUint(hex, 0, 8); err == nil {
+			values[byte(code)] = value
+		}
+	}
+	m.Parked = nil
+	s.Monitors[key] = m
+	return values, true
+}
+
+// AddPendingRevert schedules a revert
+func (s *State) AddPendingRevert(p PendingRevert) {
+	s.PendingReverts = append(s.PendingReverts, p)
+}
+
+// TakePendingRevert removes and returns the revert with the given token
+func (s *State) TakePendingRevert(token string) (PendingRevert, bool) {
+	for i, p := range s.PendingReverts {
+		if p.Token == token {
+			s.PendingReverts = append(s.PendingReverts[:i], s.PendingReverts[i+1:]...)
+			return p, true
+		}
+	}
+	return PendingRevert{}, false
+}
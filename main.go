@@ -1,6 +1,6 @@
 package main
 
-import "monitorswitch/cmd"
+import "github.com/sibteali786/monitorswitch/cmd"
 
 func main() {
 	cmd.Execute()
@@ -0,0 +1,310 @@
+// Package monitorswitch controls monitors over DDC/CI from Go programs: it
+// finds the connected monitors, reads and writes VCP features, switches
+// inputs by name and saves and restores profiles. The monitorswitch command
+// is built on it.
+//
+//	c, err := monitorswitch.Open(monitorswitch.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	monitors, err := c.Monitors()
+//	...
+//	_, err = c.SwitchInput(monitors[0].ID, "hdmi-1")
+package monitorswitch
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/config"
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/service"
+)
+
+type (
+	// Monitor is a detected monitor. Its ID addresses it in every call;
+	// StableID, when set, survives reboots and port changes. Monitors with
+	// DDCSupported false are only listed and can't be controlled.
+	Monitor = ddc.Monitor
+	// VCPValue is a VCP reading with the maximum the monitor reports
+	VCPValue = ddc.VCPValue
+	// Capabilities is what a monitor reports it supports
+	Capabilities = ddc.Capabilities
+	// RetryPolicy controls how writes are retried and verified
+	RetryPolicy = ddc.RetryPolicy
+	// Backend is the low-level DDC/CI client for the current OS
+	Backend = ddc.DDCClient
+)
+
+// Common VCP feature codes
+const (
+	VCPBrightness  = ddc.VCPBrightness
+	VCPContrast    = ddc.VCPContrast
+	VCPInputSource = ddc.VCPInputSource
+	VCPVolume      = ddc.VCPVolume
+	VCPPowerMode   = ddc.VCPPowerMode
+)
+
+// DefaultCacheTTL is how long a VCP reading is reused unless Options.CacheTTL says otherwise
+const DefaultCacheTTL = ddc.DefaultCacheTTL
+
+// DefaultCapabilitiesTTL is how long cached capabilities are reused unless
+// Options.CapabilitiesTTL says otherwise
+const DefaultCapabilitiesTTL = ddc.DefaultCapabilitiesTTL
+
+// Options configures Open. The zero value is ready to use.
+type Options struct {
+	// Tool is the command-line tool to prefer when several are installed,
+	// e.g. "ddcutil" or "m1ddc"
+	Tool string
+	// Retry replaces the default retry policy for writes
+	Retry *RetryPolicy
+	// Logger receives diagnostics; nil discards them
+	Logger *slog.Logger
+	// Aliases are extra names Monitor accepts, each naming a monitor by ID,
+	// stable ID, connector, serial, UUID or name, e.g. "left": "DP-1"
+	Aliases map[string]string
+
+	// ReadOnly refuses every write
+	ReadOnly bool
+	// Force writes values the monitor's capabilities don't list
+	Force bool
+	// NoCoexist writes brightness over DDC/CI even when another brightness
+	// tool is running (Windows)
+	NoCoexist bool
+	// CacheTTL is how long VCP readings are reused; negative disables caching
+	CacheTTL time.Duration
+	// NoCapabilitiesCache reads capabilities from the monitors every time
+	// instead of reusing them from earlier runs, kept under the user cache
+	// directory for CapabilitiesTTL (zero means DefaultCapabilitiesTTL)
+	NoCapabilitiesCache bool
+	CapabilitiesTTL     time.Duration
+
+	// SettleDelays seeds the extra delays monitors needed after writes in
+	// earlier runs, by stable ID (ID for monitors without one, see
+	// ddc.StableKey). OnSettleLearn is told when one grows.
+	SettleDelays  map[string]time.Duration
+	OnSettleLearn func(key string, delay time.Duration)
+
+	// WatchSettle is how long Watch waits after a display change before
+	// detecting, and WatchInterval how often it detects regardless; zero
+	// means DefaultWatchSettle and DefaultWatchInterval
+	WatchSettle   time.Duration
+	WatchInterval time.Duration
+
+	// WrapBackend, if set, wraps the OS backend before anything else, e.g.
+	// to inject faults or record traffic
+	WrapBackend func(Backend) (Backend, error)
+}
+
+// Client controls the monitors connected to this machine. It is safe for
+// concurrent use.
+type Client struct {
+	backend  Backend
+	logger   *slog.Logger
+	readOnly bool
+	config   config.Config // Aliases, with lowercase keys as config.Config.Alias expects
+
+	watchSettle   time.Duration
+	watchInterval time.Duration
+
+	mu        sync.Mutex
+	monitors  []Monitor
+	detectErr error
+	detected  bool
+}
+
+// Open creates a client for the current OS. Writes are retried and verified,
+// checked against the monitor's capabilities, and spaced out by the delays
+// the monitor needs; concurrent calls and other monitorswitch processes are
+// serialized.
+func Open(opts Options) (*Client, error) {
+	detector := ddc.NewDetector()
+	detector.SetLogger(opts.Logger)
+	backend, err := detector.CreateDDCClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if impl, ok := backend.(*ddc.DDCClientImpl); ok {
+		impl.PreferTool(opts.Tool)
+		if opts.Retry != nil {
+			impl.SetRetryPolicy(*opts.Retry)
+		}
+		if !opts.NoCapabilitiesCache {
+			if cache, err := ddc.LoadCapabilitiesCache(opts.CapabilitiesTTL); err == nil {
+				impl.SetCapabilitiesCache(cache)
+			}
+		}
+	}
+
+	if opts.WrapBackend != nil {
+		if backend, err = opts.WrapBackend(backend); err != nil {
+			return nil, err
+		}
+	}
+	// Built-in panels don't speak DDC/CI; their brightness goes through the
+	// kernel backlight instead
+	if backlight := ddc.NewBacklightClient(); backlight != nil {
+		backend = ddc.NewRouterClient(backend, backlight)
+	}
+	if !opts.NoCoexist {
+		backend = ddc.NewCoexistClient(backend)
+	}
+
+	settling := ddc.NewSettlingClient(backend)
+	for id, delay := range opts.SettleDelays {
+		settling.Learned[id] = delay
+	}
+	settling.OnLearn = opts.OnSettleLearn
+	backend = ddc.NewStandbyClient(settling)
+	if opts.ReadOnly {
+		backend = ddc.NewReadOnlyClient(backend)
+	}
+	validating := ddc.NewValidatingClient(backend)
+	validating.Force = opts.Force
+	backend = ddc.NewSerializedClient(validating)
+
+	ttl := opts.CacheTTL
+	if ttl == 0 {
+		ttl = DefaultCacheTTL
+	}
+	if ttl > 0 {
+		backend = ddc.NewCachingClient(backend, ttl)
+	}
+	c := &Client{
+		backend:       backend,
+		logger:        opts.Logger,
+		readOnly:      opts.ReadOnly,
+		watchSettle:   opts.WatchSettle,
+		watchInterval: opts.WatchInterval,
+	}
+	for alias, target := range opts.Aliases {
+		if c.config.Aliases == nil {
+			c.config.Aliases = make(map[string]string)
+		}
+		c.config.Aliases[strings.ToLower(alias)] = target
+	}
+	if c.logger == nil {
+		c.logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	if c.watchSettle <= 0 {
+		c.watchSettle = DefaultWatchSettle
+	}
+	if c.watchInterval <= 0 {
+		c.watchInterval = DefaultWatchInterval
+	}
+	return c, nil
+}
+
+// ClearCapabilitiesCache forgets the capabilities cached by every client, so
+// the next detection reads them from the monitors
+func ClearCapabilitiesCache() error {
+	return ddc.ClearCapabilitiesCache()
+}
+
+// Backend returns the client's DDC/CI backend, with retries, validation and
+// caching applied
+func (c *Client) Backend() Backend {
+	return c.backend
+}
+
+// Monitors detects the connected monitors on first use and returns the same
+// result afterwards; call Refresh to detect them again
+func (c *Client) Monitors() ([]Monitor, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.detected {
+		c.monitors, c.detectErr = c.backend.DetectMonitors()
+		c.detected = true
+	}
+	return c.monitors, c.detectErr
+}
+
+// Refresh detects the connected monitors again
+func (c *Client) Refresh() ([]Monitor, error) {
+	c.mu.Lock()
+	c.detected = false
+	c.mu.Unlock()
+	return c.Monitors()
+}
+
+// SetMonitors replaces the detected monitors, for callers that run
+// detection themselves, e.g. to watch for hotplug
+func (c *Client) SetMonitors(monitors []Monitor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.monitors, c.detectErr, c.detected = monitors, nil, true
+}
+
+// Monitor finds a monitor by ID, stable ID, connector or one of
+// Options.Aliases. An empty id means the only monitor that
+// answers DDC/CI.
+func (c *Client) Monitor(id string) (Monitor, error) {
+	monitors, err := c.Monitors()
+	if err != nil {
+		return Monitor{}, fmt.Errorf("monitor detection failed: %w", err)
+	}
+	if id == "" {
+		monitors = service.DDCMonitors(monitors)
+		switch len(monitors) {
+		case 0:
+			return Monitor{}, fmt.Errorf("no DDC/CI compatible monitors detected")
+		case 1:
+			return monitors[0], nil
+		}
+		return Monitor{}, fmt.Errorf("%d monitors detected; choose one by ID", len(monitors))
+	}
+
+	targets, err := service.SelectMonitors(monitors, id, c.config)
+	if err != nil {
+		return Monitor{}, err
+	}
+	return targets[0], nil
+}
+
+// Capabilities returns what the monitor reports it supports
+func (c *Client) Capabilities(monitorID string) (*Capabilities, error) {
+	return c.backend.GetCapabilities(monitorID)
+}
+
+// GetVCP reads a VCP feature
+func (c *Client) GetVCP(monitorID string, code byte) (uint16, error) {
+	return c.backend.GetVCP(monitorID, code)
+}
+
+// GetVCPValue reads a VCP feature with its maximum
+func (c *Client) GetVCPValue(monitorID string, code byte) (VCPValue, error) {
+	return c.backend.GetVCPValue(monitorID, code)
+}
+
+// SetVCP writes a VCP feature
+func (c *Client) SetVCP(monitorID string, code byte, value uint16) error {
+	return c.backend.SetVCP(monitorID, code, value)
+}
+
+// SwitchInput switches a monitor to an input given by name ("hdmi-1", "dp",
+// "usb-c", a name the monitor advertised) or VCP value ("17", "0x11"). An
+// empty monitorID means the only monitor that answers DDC/CI. It returns the
+// VCP value written.
+func (c *Client) SwitchInput(monitorID, input string) (byte, error) {
+	target, err := c.Monitor(monitorID)
+	if err != nil {
+		return 0, err
+	}
+	if !target.DDCSupported {
+		return 0, fmt.Errorf("monitor %s (%s) doesn't answer DDC/CI", target.ID, target.Name)
+	}
+	code, err := ddc.ResolveInput(target, input)
+	if err != nil {
+		return 0, err
+	}
+	if err := c.backend.SetVCP(target.ID, VCPInputSource, uint16(code)); err != nil {
+		return 0, fmt.Errorf("failed to switch monitor %s: %w", target.ID, err)
+	}
+	return code, nil
+}
@@ -0,0 +1,167 @@
+package monitorswitch
+
+import (
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/profiles"
+	"github.com/sibteali786/monitorswitch/internal/state"
+)
+
+// Operation is one VCP write of a plan
+type Operation struct {
+	MonitorID   string  `json:"monitor_id"`
+	MonitorName string  `json:"monitor_name"`
+	Code        byte    `json:"code"`
+	Value       uint16  `json:"value"`
+	Current     *uint16 `json:"current,omitempty"` // Value before the write, if it could be read
+}
+
+// Unchanged reports whether the monitor already has the value
+func (o Operation) Unchanged() bool {
+	return o.Current != nil && *o.Current == o.Value
+}
+
+// Plan is a change resolved into the writes that make it, in order, so it
+// can be reviewed before Commit runs it
+type Plan struct {
+	Name       string      `json:"name,omitempty"` // What the plan does, e.g. "profile work", shown by recover
+	Operations []Operation `json:"operations"`
+}
+
+// Changes counts the operations that change a value
+func (p *Plan) Changes() int {
+	n := 0
+	for _, op := range p.Operations {
+		if !op.Unchanged() {
+			n++
+		}
+	}
+	return n
+}
+
+// PlanSettings resolves settings into a plan, reading the current value of
+// every setting so the plan shows what changes
+func (c *Client) PlanSettings(settings []Setting) (*Plan, error) {
+	monitors, err := c.Monitors()
+	if err != nil {
+		return nil, fmt.Errorf("monitor detection failed: %w", err)
+	}
+	names := make(map[string]string)
+	for _, m := range monitors {
+		names[m.ID] = m.Name
+	}
+
+	plan := &Plan{}
+	for _, s := range settings {
+		name, ok := names[s.MonitorID]
+		if !ok {
+			return nil, fmt.Errorf("monitor %s not found", s.MonitorID)
+		}
+		op := Operation{MonitorID: s.MonitorID, MonitorName: name, Code: s.Code, Value: s.Value}
+		if current, err := c.backend.GetVCP(s.MonitorID, s.Code); err == nil {
+			op.Current = &current
+		}
+		plan.Operations = append(plan.Operations, op)
+	}
+	return plan, nil
+}
+
+// PlanSwitchInput plans SwitchInput without switching
+func (c *Client) PlanSwitchInput(monitorID, input string) (*Plan, error) {
+	target, err := c.Monitor(monitorID)
+	if err != nil {
+		return nil, err
+	}
+	if !target.DDCSupported {
+		return nil, fmt.Errorf("monitor %s (%s) doesn't answer DDC/CI", target.ID, target.Name)
+	}
+	code, err := ddc.ResolveInput(target, input)
+	if err != nil {
+		return nil, err
+	}
+	return c.PlanSettings([]Setting{{MonitorID: target.ID, Code: VCPInputSource, Value: uint16(code)}})
+}
+
+// PlanProfile plans ApplyProfile without applying the profile
+func (c *Client) PlanProfile(name string) (*Plan, error) {
+	p, err := profiles.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := c.ProfileSettings(p)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := c.PlanSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+	plan.Name = "profile " + p.Name
+	return plan, nil
+}
+
+// Commit runs a plan's writes in order and returns the ones made. Operations
+// the plan found already applied are skipped; consecutive writes to one
+// monitor share a backend session.
+//
+// The writes are recorded in the intent journal first, with the values they
+// replace, so an interrupted commit can be finished or rolled back with
+// 'monitorswitch recover'. A failed write leaves the journal in place.
+func (c *Client) Commit(plan *Plan) ([]Operation, error) {
+	var ops []Operation
+	for _, op := range plan.Operations {
+		if !op.Unchanged() {
+			ops = append(ops, op)
+		}
+	}
+	if len(ops) == 0 {
+		return nil, nil
+	}
+
+	stableIDs := make(map[string]string)
+	if monitors, err := c.Monitors(); err == nil {
+		for _, m := range monitors {
+			stableIDs[m.ID] = m.StableID
+		}
+	}
+	steps := make([]state.JournalStep, len(ops))
+	for i, op := range ops {
+		steps[i] = state.JournalStep{MonitorID: op.MonitorID, StableID: stableIDs[op.MonitorID], Code: op.Code, Value: op.Value}
+		if op.Current != nil {
+			steps[i].Previous, steps[i].HasPrevious = *op.Current, true
+		} else if previous, err := c.backend.GetVCP(op.MonitorID, op.Code); err == nil {
+			steps[i].Previous, steps[i].HasPrevious = previous, true
+		}
+	}
+	name := plan.Name
+	if name == "" {
+		name = "commit"
+	}
+	j, err := state.BeginJournal(name, steps)
+	if err != nil {
+		return nil, err
+	}
+
+	for start := 0; start < len(ops); {
+		end := start + 1
+		for end < len(ops) && ops[end].MonitorID == ops[start].MonitorID {
+			end++
+		}
+
+		var writes []ddc.VCPWrite
+		for _, op := range ops[start:end] {
+			writes = append(writes, ddc.VCPWrite{Code: op.Code, Value: op.Value})
+		}
+		if err := ddc.SetVCPs(c.backend, ops[start].MonitorID, writes); err != nil {
+			return ops[:start], fmt.Errorf("monitor %s: failed to apply settings: %w", ops[start].MonitorID, err)
+		}
+		for i := start; i < end; i++ {
+			if err := j.MarkDone(i); err != nil {
+				return ops[:end], err
+			}
+		}
+		start = end
+	}
+	return ops, j.Finish()
+}
@@ -0,0 +1,227 @@
+package monitorswitch
+
+import (
+	"fmt"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/display"
+	"github.com/sibteali786/monitorswitch/internal/profiles"
+)
+
+// Profile is a saved snapshot of every monitor's input, brightness and
+// contrast, plus the modes and layout to set through the OS
+type Profile = profiles.Profile
+
+type (
+	// Mode is a resolution and refresh rate
+	Mode = display.Mode
+	// Layout is an arrangement of outputs on the desktop
+	Layout = display.Layout
+)
+
+// OutputMode is a mode to set on one output
+type OutputMode struct {
+	Output string
+	Mode   Mode
+}
+
+// ProfileDisplay is what a profile sets through the OS display APIs rather
+// than DDC/CI
+type ProfileDisplay struct {
+	Modes  []OutputMode
+	Layout *Layout
+}
+
+// Setting is one VCP write of a profile
+type Setting struct {
+	MonitorID string
+	Code      byte
+	Value     uint16
+}
+
+// Profiles returns the names of the saved profiles, sorted
+func Profiles() ([]string, error) {
+	return profiles.List()
+}
+
+// LoadProfile reads a saved profile
+func LoadProfile(name string) (*Profile, error) {
+	return profiles.Load(name)
+}
+
+// DeleteProfile removes a saved profile
+func DeleteProfile(name string) error {
+	return profiles.Delete(name)
+}
+
+// CaptureProfile reads the settings of every connected monitor into a
+// profile named name, without saving it. Settings that can't be read are
+// left out, and monitors without any readable setting are skipped. Modes and
+// layout can't be read back, so those of a saved profile of the same name
+// are kept.
+func (c *Client) CaptureProfile(name string) (*Profile, error) {
+	monitors, err := c.Monitors()
+	if err != nil {
+		return nil, fmt.Errorf("monitor detection failed: %w", err)
+	}
+
+	p := &Profile{Name: name}
+	for _, m := range monitors {
+		pm := profiles.Monitor{ID: m.ID, StableID: m.StableID, Name: m.Name}
+		read := func(code byte) *uint16 {
+			value, err := c.backend.GetVCP(m.ID, code)
+			if err != nil {
+				return nil
+			}
+			return &value
+		}
+		pm.Input = read(VCPInputSource)
+		pm.Brightness = read(VCPBrightness)
+		pm.Contrast = read(VCPContrast)
+
+		if pm.Input == nil && pm.Brightness == nil && pm.Contrast == nil {
+			continue
+		}
+		p.Monitors = append(p.Monitors, pm)
+	}
+	if len(p.Monitors) == 0 {
+		return nil, fmt.Errorf("no monitor settings could be read")
+	}
+
+	if old, err := profiles.Load(name); err == nil {
+		p.Layout = old.Layout
+		for i, pm := range p.Monitors {
+			if om, ok := old.Find(pm.StableID, pm.ID, pm.Name); ok {
+				p.Monitors[i].Mode, p.Monitors[i].Output = om.Mode, om.Output
+			}
+		}
+	}
+	return p, nil
+}
+
+// ProfileSettings returns the writes that restore p on the connected
+// monitors, matched by stable ID, then ID, then name. Inputs go last:
+// monitors can stop answering right after a switch.
+func (c *Client) ProfileSettings(p *Profile) ([]Setting, error) {
+	monitors, err := c.Monitors()
+	if err != nil {
+		return nil, fmt.Errorf("monitor detection failed: %w", err)
+	}
+
+	var settings []Setting
+	for _, m := range monitors {
+		pm, ok := p.Find(m.StableID, m.ID, m.Name)
+		if !ok {
+			continue
+		}
+		for _, s := range []struct {
+			code  byte
+			value *uint16
+		}{
+			{VCPBrightness, pm.Brightness},
+			{VCPContrast, pm.Contrast},
+			{VCPInputSource, pm.Input},
+		} {
+			if s.value != nil {
+				settings = append(settings, Setting{MonitorID: m.ID, Code: s.code, Value: *s.value})
+			}
+		}
+	}
+	if len(settings) == 0 {
+		return nil, fmt.Errorf("none of the monitors in profile %q are connected", p.Name)
+	}
+	return settings, nil
+}
+
+// ProfileDisplay returns the modes and layout p sets on the connected
+// monitors, checking every entry. Like the DDC/CI settings, modes only apply
+// to connected monitors.
+func (c *Client) ProfileDisplay(p *Profile) (*ProfileDisplay, error) {
+	monitors, err := c.Monitors()
+	if err != nil {
+		return nil, fmt.Errorf("monitor detection failed: %w", err)
+	}
+
+	d := &ProfileDisplay{}
+	for _, m := range monitors {
+		pm, ok := p.Find(m.StableID, m.ID, m.Name)
+		if !ok || pm.Mode == "" {
+			continue
+		}
+		mode, err := display.ParseMode(pm.Mode)
+		if err != nil {
+			return nil, fmt.Errorf("profile %q: monitor %s: %w", p.Name, m.ID, err)
+		}
+		output := pm.Output
+		if output == "" {
+			output = m.Connector
+		}
+		if output == "" {
+			return nil, fmt.Errorf("profile %q: monitor %s (%s): no output name to set mode %s on; set output in the profile",
+				p.Name, m.ID, m.Name, mode)
+		}
+		d.Modes = append(d.Modes, OutputMode{output, mode})
+	}
+
+	if l := p.Layout; l != nil {
+		d.Layout = &Layout{Primary: l.Primary, Positions: make(map[string]display.Point), Mirror: l.Mirror, Extend: l.Extend}
+		for output, position := range l.Positions {
+			_, point, err := display.ParsePosition(output + "=" + position)
+			if err != nil {
+				return nil, fmt.Errorf("profile %q: %w", p.Name, err)
+			}
+			d.Layout.Positions[output] = point
+		}
+		if err := d.Layout.Validate(); err != nil {
+			return nil, fmt.Errorf("profile %q: %w", p.Name, err)
+		}
+	}
+	return d, nil
+}
+
+// SetDisplay sets the modes, then the layout, of d
+func (c *Client) SetDisplay(d *ProfileDisplay) error {
+	if len(d.Modes) == 0 && d.Layout == nil {
+		return nil
+	}
+	if c.readOnly {
+		return ddc.ErrReadOnly
+	}
+	for _, m := range d.Modes {
+		if err := display.SetMode(m.Output, m.Mode); err != nil {
+			return err
+		}
+	}
+	if d.Layout != nil {
+		return display.ApplyLayout(*d.Layout)
+	}
+	return nil
+}
+
+// ApplyProfile restores a saved profile on the connected monitors and
+// returns the DDC/CI settings written. The modes and layout are set first,
+// as the writes can switch a monitor away from this computer; then the
+// settings go through Commit, which skips those the monitors already have.
+func (c *Client) ApplyProfile(name string) ([]Setting, error) {
+	p, err := profiles.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	d, err := c.ProfileDisplay(p)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := c.PlanProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.SetDisplay(d); err != nil {
+		return nil, err
+	}
+	ops, err := c.Commit(plan)
+	settings := make([]Setting, len(ops))
+	for i, op := range ops {
+		settings[i] = Setting{MonitorID: op.MonitorID, Code: op.Code, Value: op.Value}
+	}
+	return settings, err
+}
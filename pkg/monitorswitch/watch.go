@@ -0,0 +1,148 @@
+package monitorswitch
+
+import (
+	"context"
+	"time"
+
+	"github.com/sibteali786/monitorswitch/internal/ddc"
+	"github.com/sibteali786/monitorswitch/internal/display"
+)
+
+// EventKind is what happened to a monitor
+type EventKind string
+
+const (
+	// EventAdded is a monitor being connected
+	EventAdded EventKind = "added"
+	// EventRemoved is a monitor being disconnected
+	EventRemoved EventKind = "removed"
+	// EventChanged is a monitor waking up, going to sleep or switching input
+	EventChanged EventKind = "changed"
+)
+
+// Default timings of Watch, unless Options say otherwise
+const (
+	DefaultWatchSettle   = 2 * time.Second
+	DefaultWatchInterval = time.Minute
+)
+
+// Event is a change between two detections
+type Event struct {
+	Kind    EventKind
+	Monitor Monitor // As detected after the change; before it for EventRemoved
+	Detail  string  // e.g. "woke up" or "input hdmi-1" for EventChanged
+}
+
+// Watch detects monitors again whenever the OS reports a display change (DRM
+// uevents on Linux, CoreGraphics reconfiguration on macOS, WM_DISPLAYCHANGE
+// on Windows), and every Options.WatchInterval for changes it doesn't
+// announce, and sends what changed since the previous detection. The first
+// events are relative to Monitors. Detection results also replace the ones
+// Monitors returns.
+//
+// The channel is closed once ctx is done. Only one Watch per process gets
+// the OS notifications; others fall back to the interval.
+func (c *Client) Watch(ctx context.Context) (<-chan Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	changes, err := display.Changes()
+	if err != nil {
+		c.logger.Info("no display change notifications; polling", "interval", c.watchInterval, "error", err)
+		changes = nil
+	}
+	previous, err := c.Monitors()
+	if err != nil {
+		c.logger.Info("initial monitor detection failed", "error", err)
+	}
+
+	events := make(chan Event, 16)
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(c.watchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changes:
+				// Connecting a monitor fires a burst of events and DDC/CI
+				// only answers once the link has trained
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(c.watchSettle):
+				}
+				select {
+				case <-changes:
+				default:
+				}
+			case <-ticker.C:
+			}
+
+			current, err := c.backend.DetectMonitors()
+			if err != nil {
+				c.logger.Info("monitor detection failed", "error", err)
+				continue
+			}
+			c.SetMonitors(current)
+
+			for _, event := range Diff(previous, current) {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			previous = current
+		}
+	}()
+	return events, nil
+}
+
+// monitorKey identifies a monitor across detections, where IDs can shift
+func monitorKey(m Monitor) string {
+	if m.StableID != "" {
+		return m.StableID
+	}
+	if m.UUID != "" {
+		return m.UUID
+	}
+	if m.Connector != "" {
+		return m.Connector + "|" + m.Name
+	}
+	return m.ID + "|" + m.Name
+}
+
+// Diff lists the monitors added, removed or changed between two detections
+func Diff(previous, current []Monitor) []Event {
+	before := make(map[string]Monitor)
+	for _, m := range previous {
+		before[monitorKey(m)] = m
+	}
+
+	var events []Event
+	for _, m := range current {
+		old, ok := before[monitorKey(m)]
+		delete(before, monitorKey(m))
+		switch {
+		case !ok:
+			events = append(events, Event{Kind: EventAdded, Monitor: m})
+		case ddc.MonitorAsleep(old) != ddc.MonitorAsleep(m):
+			detail := "woke up"
+			if ddc.MonitorAsleep(m) {
+				detail = "went to sleep"
+			}
+			events = append(events, Event{Kind: EventChanged, Monitor: m, Detail: detail})
+		case old.CurrentInput != m.CurrentInput && m.CurrentInput != "":
+			events = append(events, Event{Kind: EventChanged, Monitor: m, Detail: "input " + m.CurrentInput})
+		}
+	}
+	for _, m := range previous {
+		if _, ok := before[monitorKey(m)]; ok {
+			events = append(events, Event{Kind: EventRemoved, Monitor: m})
+		}
+	}
+	return events
+}